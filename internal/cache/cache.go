@@ -0,0 +1,222 @@
+// Package cache persists data loaded from Redmine on disk, so it can be shown while Redmine is unreachable.
+// Every entry is a JSON file in the directory of its bucket, named by the hash of its key.
+// The modification time of the file is the moment the entry was used last.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store keeps cached values in JSON files below a directory.
+// Store is safe for concurrent use.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// Entry describes a cached value.
+type Entry struct {
+	Key      string    // Key is the key the value is stored under
+	StoredAt time.Time // StoredAt is the moment the value was stored
+	UsedAt   time.Time // UsedAt is the moment the value was stored or read last
+}
+
+// file is the content of the file of an entry.
+type file struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// NewStore creates a Store that keeps its files below dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the cache directory of rmt below XDG_CACHE_HOME.
+// DefaultDir falls back to ~/.cache if XDG_CACHE_HOME is not set.
+func DefaultDir() (string, error) {
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		cacheHome = filepath.Join(homeDir, ".cache")
+	}
+
+	return filepath.Join(cacheHome, "rmt"), nil
+}
+
+// Namespace returns a directory name for the data the user identified by token loaded from the Redmine instance at url.
+// Namespace keeps the data of several instances apart, as their issue IDs overlap, and the data of several
+// users of the same instance, as their permissions and time entries differ. Only a hash of token is used.
+func Namespace(url, token string) string {
+	return hash(strings.TrimSuffix(url, "/") + "\n" + token)[:16]
+}
+
+// Put stores v under key in bucket, replacing the value stored before.
+func (s *Store) Put(bucket, key string, v any) error {
+	return s.put(bucket, key, v, false)
+}
+
+// Reload replaces the value stored under key in bucket like Put, but keeps the moment it was used last.
+// Reload is meant for values refreshed in the background, which must not keep unused entries alive.
+func (s *Store) Reload(bucket, key string, v any) error {
+	return s.put(bucket, key, v, true)
+}
+
+// put stores v under key in bucket, keeping the moment the replaced value was used last if keepUsed is set.
+func (s *Store) put(bucket, key string, v any, keepUsed bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	content, err := json.Marshal(file{Key: key, StoredAt: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.dir, bucket)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Writing to a temporary file first never leaves a truncated entry behind
+	f, err := os.CreateTemp(dir, ".entry-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	path := s.path(bucket, key)
+	var usedAt time.Time
+	if info, err := os.Stat(path); err == nil && keepUsed {
+		usedAt = info.ModTime()
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if !usedAt.IsZero() {
+		// Failing to keep the time only keeps the entry longer
+		_ = os.Chtimes(path, usedAt, usedAt)
+	}
+
+	return nil
+}
+
+// Get decodes the value stored under key in bucket into v and returns when it was stored.
+// Get reports false without an error if nothing is stored under key.
+func (s *Store) Get(bucket, key string, v any) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(bucket, key)
+	f, err := s.read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if err := json.Unmarshal(f.Data, v); err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+
+	// Failing to mark the entry as used only lets it expire earlier
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return f.StoredAt, true, nil
+}
+
+// Entries returns the entries of bucket, skipping files that cannot be read.
+func (s *Store) Entries(bucket string) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, bucket, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		f, err := s.read(path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, Entry{Key: f.Key, StoredAt: f.StoredAt, UsedAt: info.ModTime()})
+	}
+	return entries, nil
+}
+
+// Delete removes the value stored under key in bucket.
+func (s *Store) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(bucket, key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes all values of bucket.
+func (s *Store) Clear(bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.RemoveAll(filepath.Join(s.dir, bucket)); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// read reads the entry file at path.
+func (s *Store) read(path string) (*file, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return &f, nil
+}
+
+// path returns the file of the entry stored under key in bucket.
+func (s *Store) path(bucket, key string) string {
+	return filepath.Join(s.dir, bucket, hash(key)+".json")
+}
+
+// hash returns the hex encoded SHA-256 hash of s, which is safe to use as file name.
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStore_PutGet verifies that stored values are read back with the time they were stored
+func TestStore_PutGet(t *testing.T) {
+	dir := t.TempDir()
+	before := time.Now()
+
+	if err := NewStore(dir).Put("issues", "42", map[string]int{"id": 42}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	// A new store reads the entry from disk, as it would after a restart
+	var got map[string]int
+	storedAt, ok, err := NewStore(dir).Get("issues", "42", &got)
+	if err != nil || !ok {
+		t.Fatalf("Get() = %v, %v, want the stored value", ok, err)
+	}
+	if got["id"] != 42 || storedAt.Before(before) {
+		t.Errorf("Get() = %v stored at %v, want id 42 stored after %v", got, storedAt, before)
+	}
+
+	if _, ok, err := NewStore(dir).Get("issues", "43", &got); ok || err != nil {
+		t.Errorf("Get() of a missing key = %v, %v, want false, nil", ok, err)
+	}
+}
+
+// TestStore_EntriesDeleteClear verifies listing and removing entries
+func TestStore_EntriesDeleteClear(t *testing.T) {
+	store := NewStore(t.TempDir())
+	for _, key := range []string{"status_id=1|2", "#42"} {
+		if err := store.Put("searches", key, []int{42}); err != nil {
+			t.Fatalf("Put returned error: %v", err)
+		}
+	}
+
+	entries, err := store.Entries("searches")
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Entries() = %+v, %v, want 2 entries", entries, err)
+	}
+
+	if err := store.Delete("searches", "#42"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if entries, _ := store.Entries("searches"); len(entries) != 1 || entries[0].Key != "status_id=1|2" {
+		t.Errorf("Entries() after Delete = %+v, want the other search", entries)
+	}
+
+	if err := store.Clear("searches"); err != nil {
+		t.Fatalf("Clear returned error: %v", err)
+	}
+	if entries, err := store.Entries("searches"); len(entries) != 0 || err != nil {
+		t.Errorf("Entries() after Clear = %+v, %v, want none", entries, err)
+	}
+}
+
+// TestStore_UsedAt verifies that reading marks an entry as used and reloading it does not
+func TestStore_UsedAt(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	if err := store.Put("issues", "42", 42); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(store.path("issues", "42"), lastWeek, lastWeek); err != nil {
+		t.Fatalf("failed to age the entry: %v", err)
+	}
+	if err := store.Reload("issues", "42", 43); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	entries, err := store.Entries("issues")
+	if err != nil || len(entries) != 1 || !entries[0].UsedAt.Equal(lastWeek) {
+		t.Fatalf("Entries() = %+v, %v, want the entry used %v", entries, err, lastWeek)
+	}
+
+	var got int
+	if _, ok, err := store.Get("issues", "42", &got); !ok || err != nil || got != 43 {
+		t.Fatalf("Get() = %d, %v, %v, want the reloaded value", got, ok, err)
+	}
+	if entries, _ := store.Entries("issues"); len(entries) != 1 || !entries[0].UsedAt.After(lastWeek) {
+		t.Errorf("Entries() after Get = %+v, want the entry marked as used", entries)
+	}
+}
+
+// TestStore_Broken verifies that a damaged entry is reported instead of decoded
+func TestStore_Broken(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	if err := store.Put("issues", "42", 42); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if err := os.WriteFile(store.path("issues", "42"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got int
+	if _, ok, err := store.Get("issues", "42", &got); ok || err == nil {
+		t.Errorf("Get() = %v, %v, want an error", ok, err)
+	}
+}
+
+// TestDefaultDir verifies that the cache lives below XDG_CACHE_HOME
+func TestDefaultDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/cache")
+
+	dir, err := DefaultDir()
+	if err != nil || dir != filepath.Join("/tmp/cache", "rmt") {
+		t.Errorf("DefaultDir() = %q, %v, want /tmp/cache/rmt", dir, err)
+	}
+	if Namespace("https://redmine.example.com/", "token") != Namespace("https://redmine.example.com", "token") {
+		t.Error("expected the trailing slash not to change the namespace")
+	}
+	if Namespace("https://redmine.example.com", "jane") == Namespace("https://redmine.example.com", "john") {
+		t.Error("expected users of the same instance to get different namespaces")
+	}
+}
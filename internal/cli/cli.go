@@ -0,0 +1,257 @@
+// Package cli implements the non-interactive rmt commands used from scripts and shell aliases.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// Exit codes returned by rmt, so scripts can tell failures apart.
+const (
+	ExitOK       = 0 // ExitOK is returned when the command succeeded
+	ExitFailure  = 1 // ExitFailure is returned for failures without a more specific code, e.g. network errors
+	ExitUsage    = 2 // ExitUsage is returned when the command line is invalid
+	ExitConfig   = 3 // ExitConfig is returned when the configuration could not be loaded
+	ExitAuth     = 4 // ExitAuth is returned when Redmine rejected the API key or denied access
+	ExitNotFound = 5 // ExitNotFound is returned when the requested issue or time entry does not exist
+	ExitRejected = 6 // ExitRejected is returned when Redmine rejected the submitted data as invalid
+)
+
+// Usage is the help text describing the available commands.
+const Usage = `Usage:
+  rmt                                   start the interactive UI
+  rmt [--profile name] [--output table|json|csv] <command> [arguments]
+  rmt log <issue> <hours> [-a activity] [-d date] [-m comment]
+                                        log time on an issue
+  rmt search <query>                    search issues by text or Redmine query string
+  rmt show <id>                         show a single issue
+  rmt entries [--week] [--from date] [--to date] [--issue id]
+                                        list my time entries, today by default
+  rmt sync [--list] [--all]             send the time entries queued while Redmine was unreachable
+  rmt help                              show this help
+
+Hours are given as decimal hours (1.5) or as duration (1h30m).
+Dates are given as YYYY-MM-DD, "today" or "yesterday".
+The --output (-o) flag selects the format of the results and may follow the command, too.
+The --profile flag selects the Redmine instance configured under profiles, for the interactive UI as well.
+Time entries the interactive UI could not send wait in the outbox, sync --all retries the ones Redmine rejected.
+`
+
+// Repository combines the domain operations used by the commands.
+type Repository interface {
+	domain.IssueGetter
+	domain.IssueSearcher
+	domain.TimeEntryCreator
+	domain.TimeEntryLister
+	domain.ProjectActivityGetter
+}
+
+// UsageError is returned when the command line is invalid.
+type UsageError struct {
+	Message string
+}
+
+// Error returns the error message for UsageError.
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+// ExitError attaches an exit code to an error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the message of the wrapped error.
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code rmt terminates with after err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	var usageErr *UsageError
+	var missingErr *config.MissingFieldError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.As(err, &missingErr):
+		return ExitConfig
+	case redmine.IsUnauthorized(err), redmine.IsForbidden(err):
+		return ExitAuth
+	case redmine.IsNotFound(err):
+		return ExitNotFound
+	case redmine.IsValidation(err):
+		return ExitRejected
+	}
+
+	return ExitFailure
+}
+
+// IsHelp reports whether arg asks for the usage, which needs no configuration.
+func IsHelp(arg string) bool {
+	switch arg {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
+// SplitProfile removes the --profile flag from args and returns its value along with the remaining arguments.
+// SplitProfile accepts the flag anywhere before a "--" argument, as it applies to the TUI and all commands.
+func SplitProfile(args []string) (string, []string, error) {
+	var profile string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "profile" {
+			rest = append(rest, arg)
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", nil, &UsageError{Message: "flag needs an argument: --profile"}
+			}
+			i++
+			value = args[i]
+		}
+		profile = value
+	}
+
+	return profile, rest, nil
+}
+
+// Runner executes the commands against a Redmine repository.
+type Runner struct {
+	repo   Repository
+	config *config.Config
+	out    io.Writer
+	format Format
+	now    func() time.Time
+	outbox *outbox.Store
+}
+
+// NewRunner creates a Runner writing the command output to out.
+func NewRunner(repo Repository, cfg *config.Config, out io.Writer) *Runner {
+	return &Runner{
+		repo:   repo,
+		config: cfg,
+		out:    out,
+		format: FormatTable,
+		now:    time.Now,
+	}
+}
+
+// SetOutbox sets the outbox holding the time entries the sync command sends.
+func (r *Runner) SetOutbox(store *outbox.Store) {
+	r.outbox = store
+}
+
+// Run executes the command given by args, where args[0] is the command name.
+// Run accepts global flags like --output before the command name.
+func (r *Runner) Run(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("rmt")
+	if err := fs.Parse(args); err != nil {
+		return &UsageError{Message: err.Error()}
+	}
+	args = fs.Args()
+
+	if len(args) == 0 {
+		return &UsageError{Message: "no command given"}
+	}
+
+	switch args[0] {
+	case "log":
+		return r.runLog(ctx, args[1:])
+	case "search":
+		return r.runSearch(ctx, args[1:])
+	case "show":
+		return r.runShow(ctx, args[1:])
+	case "entries":
+		return r.runEntries(ctx, args[1:])
+	case "sync":
+		return r.runSync(ctx, args[1:])
+	}
+
+	if IsHelp(args[0]) {
+		_, err := io.WriteString(r.out, Usage)
+		return err
+	}
+
+	return &UsageError{Message: fmt.Sprintf("unknown command %q", args[0])}
+}
+
+// newFlagSet creates a flag set for the named command that reports errors instead of exiting.
+// newFlagSet registers the global flags, so they are accepted after the command name, too.
+func (r *Runner) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&r.format, "output", "output format: table, json or csv")
+	fs.Var(&r.format, "o", "shorthand for --output")
+	return fs
+}
+
+// parseArgs parses flags given before, between and after the positional arguments.
+// parseArgs returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, &UsageError{Message: fmt.Sprintf("%s: %v", fs.Name(), err)}
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseDate parses a date given on the command line.
+// parseDate accepts YYYY-MM-DD as well as "today" and "yesterday".
+func parseDate(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(value) {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, value, now.Location())
+	if err != nil {
+		return time.Time{}, &UsageError{Message: fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", value)}
+	}
+	return date, nil
+}
@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// fakeRepository implements Repository for testing
+type fakeRepository struct {
+	issues     map[int]*domain.Issue
+	activities []domain.Activity
+	entries    []models.TimeEntry
+	created    []models.CreateTimeEntryParams
+	filters    []models.TimeEntryFilter
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		issues: map[int]*domain.Issue{
+			123: domain.NewIssue(123, "https://redmine.example.com/issues/123", "Jane", "Fix login", "Login fails", domain.NewProject(7, "Website")),
+		},
+		activities: []domain.Activity{{ID: 9, Name: "Development"}, {ID: 10, Name: "Design"}, {ID: 11, Name: "Review"}},
+	}
+}
+
+// GetIssue returns the issue or a not found API error
+func (f *fakeRepository) GetIssue(ctx context.Context, id int) (*domain.Issue, error) {
+	if issue, ok := f.issues[id]; ok {
+		return issue, nil
+	}
+	return nil, &redmine.APIError{StatusCode: 404, Method: "GET", Endpoint: fmt.Sprintf("/issues/%d.json", id)}
+}
+
+// Search returns all issues whose title contains the query
+func (f *fakeRepository) Search(ctx context.Context, query string) ([]*domain.Issue, error) {
+	var result []*domain.Issue
+	for _, issue := range f.issues {
+		if strings.Contains(issue.FullTitle(), query) {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
+
+// SearchWithFilter returns no issues
+func (f *fakeRepository) SearchWithFilter(ctx context.Context, query string) ([]*domain.Issue, error) {
+	return nil, nil
+}
+
+// CreateTimeEntry records the created time entry
+func (f *fakeRepository) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	f.created = append(f.created, params)
+	return &models.TimeEntry{ID: 456}, nil
+}
+
+// ListTimeEntries records the filter and returns the configured entries
+func (f *fakeRepository) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	f.filters = append(f.filters, filter)
+	return f.entries, nil
+}
+
+// GetProjectActivities returns the configured activities
+func (f *fakeRepository) GetProjectActivities(ctx context.Context, projectID int, activityPatterns []string) ([]domain.Activity, error) {
+	return f.activities, nil
+}
+
+// newTestRunner creates a Runner on a fake repository at a fixed point in time (Wednesday, 2025-08-13)
+func newTestRunner(repo *fakeRepository) (*Runner, *bytes.Buffer) {
+	var out bytes.Buffer
+	r := NewRunner(repo, &config.Config{}, &out)
+	r.now = func() time.Time { return time.Date(2025, 8, 13, 15, 0, 0, 0, time.Local) }
+	return r, &out
+}
+
+// TestRun_Log verifies that log creates a time entry with the resolved activity and date
+func TestRun_Log(t *testing.T) {
+	repo := newFakeRepository()
+	r, out := newTestRunner(repo)
+
+	err := r.Run(context.Background(), []string{"log", "#123", "1h30m", "-a", "dev", "-d", "yesterday", "-m", "Fixed the form"})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if len(repo.created) != 1 {
+		t.Fatalf("expected 1 created time entry, got %d", len(repo.created))
+	}
+	want := models.CreateTimeEntryParams{IssueID: 123, Hours: 1.5, ActivityID: 9, Comments: "Fixed the form", SpentOn: "2025-08-12"}
+	if repo.created[0] != want {
+		t.Errorf("created = %+v, want %+v", repo.created[0], want)
+	}
+	if !strings.Contains(out.String(), "time entry #456") {
+		t.Errorf("output = %q, want it to mention the created time entry", out.String())
+	}
+}
+
+// TestRun_LogAmbiguousActivity verifies that an ambiguous activity is a usage error and nothing is logged
+func TestRun_LogAmbiguousActivity(t *testing.T) {
+	repo := newFakeRepository()
+	r, _ := newTestRunner(repo)
+
+	err := r.Run(context.Background(), []string{"log", "123", "2", "-a", "de"})
+	if got := ExitCode(err); got != ExitUsage {
+		t.Errorf("ExitCode = %d, want %d (error: %v)", got, ExitUsage, err)
+	}
+	if len(repo.created) != 0 {
+		t.Errorf("expected no time entry to be created, got %+v", repo.created)
+	}
+}
+
+// TestRun_LogDefaultActivity verifies that the project's default activity is used without -a
+func TestRun_LogDefaultActivity(t *testing.T) {
+	repo := newFakeRepository()
+	r, _ := newTestRunner(repo)
+
+	if err := r.Run(context.Background(), []string{"log", "123", "2"}); ExitCode(err) != ExitUsage {
+		t.Fatalf("expected a usage error without a default activity, got %v", err)
+	}
+
+	repo.activities[2].IsDefault = true
+	if err := r.Run(context.Background(), []string{"log", "123", "2"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(repo.created) != 1 || repo.created[0].ActivityID != 11 {
+		t.Errorf("created = %+v, want a time entry with the default activity", repo.created)
+	}
+}
+
+// TestRun_ShowNotFound verifies that a missing issue results in the not found exit code
+func TestRun_ShowNotFound(t *testing.T) {
+	r, _ := newTestRunner(newFakeRepository())
+
+	err := r.Run(context.Background(), []string{"show", "999"})
+	if got := ExitCode(err); got != ExitNotFound {
+		t.Errorf("ExitCode = %d, want %d (error: %v)", got, ExitNotFound, err)
+	}
+}
+
+// TestRun_Search verifies that search prints one line per issue
+func TestRun_Search(t *testing.T) {
+	r, out := newTestRunner(newFakeRepository())
+
+	if err := r.Run(context.Background(), []string{"search", "Fix", "login"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], "123") || !strings.Contains(lines[1], "Website") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+// TestRun_SearchInvalidQuery verifies that an invalid Redmine query string is a usage error
+func TestRun_SearchInvalidQuery(t *testing.T) {
+	r, _ := newTestRunner(newFakeRepository())
+
+	err := r.Run(context.Background(), []string{"search", "f[]=subject&op[subject]=~"})
+	if got := ExitCode(err); got != ExitUsage {
+		t.Errorf("ExitCode = %d, want %d (error: %v)", got, ExitUsage, err)
+	}
+}
+
+// TestRun_EntriesWeek verifies that --week lists the entries from Monday to Sunday and sums them up
+func TestRun_EntriesWeek(t *testing.T) {
+	repo := newFakeRepository()
+	repo.entries = []models.TimeEntry{
+		{ID: 2, Hours: 2, SpentOn: "2025-08-12"},
+		{ID: 1, Hours: 1.5, SpentOn: "2025-08-11"},
+	}
+	r, out := newTestRunner(repo)
+
+	if err := r.Run(context.Background(), []string{"entries", "--week"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	want := models.TimeEntryFilter{UserID: "me", From: "2025-08-11", To: "2025-08-17"}
+	if len(repo.filters) != 1 || repo.filters[0] != want {
+		t.Errorf("filters = %+v, want [%+v]", repo.filters, want)
+	}
+	if !strings.Contains(out.String(), "3.50") {
+		t.Errorf("output should contain the total of 3.50 hours:\n%s", out.String())
+	}
+	if strings.Index(out.String(), "2025-08-11") > strings.Index(out.String(), "2025-08-12") {
+		t.Errorf("entries should be sorted by date:\n%s", out.String())
+	}
+}
+
+// TestRun_Sync verifies that sync sends the queued entries, keeps held ones until --all is given and fails while entries remain
+func TestRun_Sync(t *testing.T) {
+	repo := newFakeRepository()
+	r, out := newTestRunner(repo)
+	store := outbox.NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	r.SetOutbox(store)
+
+	queued := models.CreateTimeEntryParams{IssueID: 123, Hours: 1.5, ActivityID: 9, Comments: "Fixed the form", SpentOn: "2025-08-12"}
+	held := models.CreateTimeEntryParams{IssueID: 123, Hours: 2, ActivityID: 9, Comments: "Review", SpentOn: "2025-08-12"}
+	for _, e := range []outbox.Entry{{Params: queued}, {Params: held, Held: true, LastError: "Activity is not allowed"}} {
+		if _, err := store.Add(e); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+
+	err := r.Run(context.Background(), []string{"sync"})
+	if err == nil || !strings.Contains(err.Error(), "remaining in the outbox: 1") {
+		t.Fatalf("Run error = %v, want the held entry to remain queued", err)
+	}
+	if len(repo.created) != 1 || repo.created[0] != queued {
+		t.Fatalf("created = %+v, want only the queued entry", repo.created)
+	}
+	for _, want := range []string{"sent (#456)", "held", "Activity is not allowed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := r.Run(context.Background(), []string{"sync", "--all"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(repo.created) != 2 || repo.created[1] != held {
+		t.Errorf("created = %+v, want the held entry to be sent with --all", repo.created)
+	}
+	if entries, _ := store.List(""); len(entries) != 0 {
+		t.Errorf("List() = %+v, want an empty outbox", entries)
+	}
+}
+
+// TestRun_UnknownCommand verifies that unknown commands are usage errors
+func TestRun_UnknownCommand(t *testing.T) {
+	r, _ := newTestRunner(newFakeRepository())
+
+	err := r.Run(context.Background(), []string{"frobnicate"})
+	if got := ExitCode(err); got != ExitUsage {
+		t.Errorf("ExitCode = %d, want %d", got, ExitUsage)
+	}
+}
+
+// TestExitCode verifies the exit codes of the different error kinds
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"generic", errors.New("connection refused"), ExitFailure},
+		{"usage", &UsageError{Message: "bad"}, ExitUsage},
+		{"config", fmt.Errorf("load: %w", &config.MissingFieldError{Field: "redmine.url"}), ExitConfig},
+		{"unauthorized", fmt.Errorf("get: %w", &redmine.APIError{StatusCode: 401}), ExitAuth},
+		{"forbidden", &redmine.APIError{StatusCode: 403}, ExitAuth},
+		{"not found", &redmine.APIError{StatusCode: 404}, ExitNotFound},
+		{"validation", &redmine.APIError{StatusCode: 422}, ExitRejected},
+		{"explicit", &ExitError{Code: ExitConfig, Err: errors.New("no config")}, ExitConfig},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%s) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSplitProfile verifies that --profile is removed from the arguments in all its forms
+func TestSplitProfile(t *testing.T) {
+	tests := []struct {
+		args        []string
+		wantProfile string
+		wantRest    []string
+	}{
+		{nil, "", []string{}},
+		{[]string{"--profile", "customer"}, "customer", []string{}},
+		{[]string{"search", "--profile=customer", "login"}, "customer", []string{"search", "login"}},
+		{[]string{"-profile", "work", "-o", "json", "show", "1"}, "work", []string{"-o", "json", "show", "1"}},
+		{[]string{"log", "1", "2", "--", "--profile", "x"}, "", []string{"log", "1", "2", "--", "--profile", "x"}},
+	}
+
+	for _, tt := range tests {
+		profile, rest, err := SplitProfile(tt.args)
+		if err != nil {
+			t.Errorf("SplitProfile(%v) returned error: %v", tt.args, err)
+			continue
+		}
+		if profile != tt.wantProfile || strings.Join(rest, " ") != strings.Join(tt.wantRest, " ") {
+			t.Errorf("SplitProfile(%v) = %q, %v, want %q, %v", tt.args, profile, rest, tt.wantProfile, tt.wantRest)
+		}
+	}
+
+	if _, _, err := SplitProfile([]string{"--profile"}); ExitCode(err) != ExitUsage {
+		t.Errorf("expected usage error for missing profile name, got %v", err)
+	}
+}
@@ -0,0 +1,408 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// runLog logs time on an issue: log <issue> <hours> [-a activity] [-d date] [-m comment].
+func (r *Runner) runLog(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("log")
+	activity := fs.String("a", "", "activity name or ID")
+	date := fs.String("d", "today", "date of the work")
+	comment := fs.String("m", "", "comment")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return &UsageError{Message: "log expects <issue> <hours>"}
+	}
+
+	issueID, err := parseIssueID(positional[0])
+	if err != nil {
+		return err
+	}
+	hours, err := parseHours(positional[1])
+	if err != nil {
+		return err
+	}
+	spentOn, err := parseDate(*date, r.now())
+	if err != nil {
+		return err
+	}
+
+	issue, err := r.repo.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue #%d: %w", issueID, err)
+	}
+	if issue.Project() == nil {
+		return fmt.Errorf("issue #%d has no project", issueID)
+	}
+
+	activities, err := r.repo.GetProjectActivities(ctx, issue.Project().ID(), r.config.Redmine.Activities.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to get activities of project %s: %w", issue.Project().Name(), err)
+	}
+	selected, err := resolveActivity(*activity, activities)
+	if err != nil {
+		return err
+	}
+
+	entry, err := r.repo.CreateTimeEntry(ctx, models.CreateTimeEntryParams{
+		IssueID:    issueID,
+		Hours:      hours,
+		ActivityID: selected.ID,
+		Comments:   *comment,
+		SpentOn:    spentOn.Format(time.DateOnly),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log time on #%d: %w", issueID, err)
+	}
+
+	return writeRecord(r, NewTimeEntryRecord(*entry), func() error {
+		_, err := fmt.Fprintf(r.out, "Logged %.2fh on #%d (%s) for %s, time entry #%d\n",
+			hours, issueID, selected.Name, spentOn.Format(time.DateOnly), entry.ID)
+		return err
+	})
+}
+
+// runSearch searches issues: search <query>.
+// Queries containing "=" or "&" are sent as Redmine query strings, all others as full-text search.
+func (r *Runner) runSearch(ctx context.Context, args []string) error {
+	positional, err := parseArgs(r.newFlagSet("search"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return &UsageError{Message: "search expects <query>"}
+	}
+
+	query := strings.Join(positional, " ")
+
+	var issues []*domain.Issue
+	if strings.Contains(query, "=") || strings.Contains(query, "&") {
+		if _, err := redmine.ParseIssueQuery(query); err != nil {
+			return &UsageError{Message: err.Error()}
+		}
+		issues, err = r.repo.SearchWithFilter(ctx, query)
+	} else {
+		issues, err = r.repo.Search(ctx, query)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	records := make([]IssueRecord, 0, len(issues))
+	for _, issue := range issues {
+		records = append(records, NewIssueRecord(issue, false))
+	}
+
+	return writeRecords(r, records, func() error {
+		w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tPROJECT\tTITLE")
+		for _, issue := range issues {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", issue.ID(), projectName(issue), issue.FullTitle())
+		}
+		return w.Flush()
+	})
+}
+
+// runShow prints a single issue: show <id>.
+func (r *Runner) runShow(ctx context.Context, args []string) error {
+	positional, err := parseArgs(r.newFlagSet("show"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return &UsageError{Message: "show expects <id>"}
+	}
+
+	issueID, err := parseIssueID(positional[0])
+	if err != nil {
+		return err
+	}
+
+	issue, err := r.repo.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue #%d: %w", issueID, err)
+	}
+
+	return writeRecord(r, NewIssueRecord(issue, true), func() error {
+		_, err := fmt.Fprintf(r.out, "#%d %s\nProject: %s\nAuthor:  %s\nLink:    %s\n\n%s\n",
+			issue.ID(), issue.FullTitle(), projectName(issue), issue.Author(), issue.Link(), issue.FullDescription())
+		return err
+	})
+}
+
+// runEntries lists the time entries of the current user: entries [--week] [--from date] [--to date] [--issue id].
+func (r *Runner) runEntries(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("entries")
+	week := fs.Bool("week", false, "list the entries of the current week")
+	from := fs.String("from", "", "first day to list")
+	to := fs.String("to", "", "last day to list")
+	issue := fs.String("issue", "", "only list entries of this issue")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return &UsageError{Message: "entries expects no arguments"}
+	}
+	if *week && (*from != "" || *to != "") {
+		return &UsageError{Message: "entries: --week cannot be combined with --from or --to"}
+	}
+
+	now := r.now()
+	filter := models.TimeEntryFilter{UserID: "me"}
+	switch {
+	case *week:
+		start := domain.WeekStart(now)
+		filter.From = start.Format(time.DateOnly)
+		filter.To = start.AddDate(0, 0, domain.DaysPerWeek-1).Format(time.DateOnly)
+	case *from != "" || *to != "":
+		if *from != "" {
+			date, err := parseDate(*from, now)
+			if err != nil {
+				return err
+			}
+			filter.From = date.Format(time.DateOnly)
+		}
+		if *to != "" {
+			date, err := parseDate(*to, now)
+			if err != nil {
+				return err
+			}
+			filter.To = date.Format(time.DateOnly)
+		}
+	default:
+		today, _ := parseDate("today", now)
+		filter.From = today.Format(time.DateOnly)
+		filter.To = filter.From
+	}
+	if *issue != "" {
+		if filter.IssueID, err = parseIssueID(*issue); err != nil {
+			return err
+		}
+	}
+
+	entries, err := r.repo.ListTimeEntries(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to list time entries: %w", err)
+	}
+	slices.SortStableFunc(entries, func(a, b models.TimeEntry) int {
+		if c := strings.Compare(a.SpentOn, b.SpentOn); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+
+	records := make([]TimeEntryRecord, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, NewTimeEntryRecord(entry))
+	}
+
+	return writeRecords(r, records, func() error {
+		var total float64
+		w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tDATE\tHOURS\tISSUE\tPROJECT\tACTIVITY\tCOMMENT")
+		for _, entry := range entries {
+			issueRef := "-"
+			if entry.Issue.ID != 0 {
+				issueRef = fmt.Sprintf("#%d", entry.Issue.ID)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%.2f\t%s\t%s\t%s\t%s\n",
+				entry.ID, entry.SpentOn, entry.Hours, issueRef, entry.Project.Name, entry.Activity.Name, entry.Comments)
+			total += entry.Hours
+		}
+		fmt.Fprintf(w, "TOTAL\t\t%.2f\t\t\t\t\n", total)
+		return w.Flush()
+	})
+}
+
+// runSync sends the time entries waiting in the outbox of the active profile: sync [--list] [--all].
+// runSync fails if entries remain queued, so scripts can try again later.
+func (r *Runner) runSync(ctx context.Context, args []string) error {
+	fs := r.newFlagSet("sync")
+	list := fs.Bool("list", false, "only list the queued time entries")
+	all := fs.Bool("all", false, "retry the time entries Redmine rejected, too")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return &UsageError{Message: "sync expects no arguments"}
+	}
+	if *list && *all {
+		return &UsageError{Message: "sync: --list cannot be combined with --all"}
+	}
+	if r.outbox == nil {
+		return fmt.Errorf("the outbox is not available")
+	}
+
+	var results []outbox.Result
+	if !*list {
+		if results, err = r.outbox.Flush(ctx, r.repo, r.config.Profile); err != nil {
+			return fmt.Errorf("failed to send the outbox: %w", err)
+		}
+	}
+	stopped := slices.ContainsFunc(results, func(result outbox.Result) bool { return redmine.IsUnreachable(result.Err) })
+
+	queued, err := r.outbox.List(r.config.Profile)
+	if err != nil {
+		return err
+	}
+	if *all && !stopped {
+		for _, e := range queued {
+			if !e.Held {
+				continue
+			}
+			result, err := r.outbox.Send(ctx, r.repo, e.ID)
+			if err != nil {
+				return fmt.Errorf("failed to send the outbox: %w", err)
+			}
+			results = append(results, result)
+		}
+		if queued, err = r.outbox.List(r.config.Profile); err != nil {
+			return err
+		}
+	}
+
+	// Entries that were not sent are listed with their status, too
+	records := make([]OutboxRecord, 0, len(results)+len(queued))
+	reported := make(map[string]bool)
+	for _, result := range results {
+		entry := result.Entry
+		if result.Outcome != outbox.Failed {
+			entry.LastError = ""
+		}
+		records = append(records, NewOutboxRecord(entry, result.Outcome.String(), result.TimeEntryID, result.Err))
+		reported[result.Entry.ID] = true
+	}
+	for _, e := range queued {
+		if reported[e.ID] {
+			continue
+		}
+		status := "queued"
+		if e.Held {
+			status = "held"
+		}
+		records = append(records, NewOutboxRecord(e, status, 0, nil))
+	}
+
+	err = writeRecords(r, records, func() error {
+		if len(records) == 0 {
+			_, err := fmt.Fprintln(r.out, "No time entries queued")
+			return err
+		}
+
+		w := tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DATE\tHOURS\tISSUE\tSTATUS\tCOMMENT\tERROR")
+		for _, rec := range records {
+			status := rec.Status
+			if rec.TimeEntryID != 0 {
+				status = fmt.Sprintf("%s (#%d)", status, rec.TimeEntryID)
+			}
+			fmt.Fprintf(w, "%s\t%.2f\t#%d\t%s\t%s\t%s\n", rec.SpentOn, rec.Hours, rec.IssueID, status, rec.Comments, rec.Error)
+		}
+		return w.Flush()
+	})
+	if err != nil || *list || len(queued) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("time entries remaining in the outbox: %d", len(queued))
+}
+
+// parseIssueID parses an issue ID given as "123" or "#123".
+func parseIssueID(value string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+	if err != nil || id <= 0 {
+		return 0, &UsageError{Message: fmt.Sprintf("invalid issue ID %q", value)}
+	}
+	return id, nil
+}
+
+// parseHours parses hours given as decimal number ("1.5") or as duration ("1h30m").
+func parseHours(value string) (float64, error) {
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		d, durErr := time.ParseDuration(value)
+		if durErr != nil {
+			return 0, &UsageError{Message: fmt.Sprintf("invalid hours %q, expected e.g. 1.5 or 1h30m", value)}
+		}
+		hours = d.Hours()
+	}
+
+	if hours <= 0 {
+		return 0, &UsageError{Message: fmt.Sprintf("invalid hours %q, must be positive", value)}
+	}
+	return hours, nil
+}
+
+// resolveActivity finds the activity given by ID or name among the activities of a project.
+// resolveActivity matches names case-insensitively, first exactly and then by unique prefix.
+// Without a given activity, resolveActivity picks the default activity or the only one the project offers.
+func resolveActivity(value string, activities []domain.Activity) (domain.Activity, error) {
+	if value == "" {
+		if i := slices.IndexFunc(activities, func(a domain.Activity) bool { return a.IsDefault }); i >= 0 {
+			return activities[i], nil
+		}
+		if len(activities) == 1 {
+			return activities[0], nil
+		}
+		return domain.Activity{}, &UsageError{Message: "log needs an activity (-a), one of: " + activityNames(activities)}
+	}
+
+	if id, err := strconv.Atoi(value); err == nil {
+		if i := slices.IndexFunc(activities, func(a domain.Activity) bool { return a.ID == id }); i >= 0 {
+			return activities[i], nil
+		}
+	}
+
+	var prefixMatches []domain.Activity
+	for _, activity := range activities {
+		name := strings.ToLower(activity.Name)
+		if name == strings.ToLower(value) {
+			return activity, nil
+		}
+		if strings.HasPrefix(name, strings.ToLower(value)) {
+			prefixMatches = append(prefixMatches, activity)
+		}
+	}
+	if len(prefixMatches) == 1 {
+		return prefixMatches[0], nil
+	}
+
+	return domain.Activity{}, &UsageError{Message: fmt.Sprintf("unknown or ambiguous activity %q, one of: %s", value, activityNames(activities))}
+}
+
+// activityNames lists the names of the activities.
+func activityNames(activities []domain.Activity) string {
+	names := make([]string, 0, len(activities))
+	for _, activity := range activities {
+		names = append(names, activity.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// projectName returns the name of the issue's project, or "-" if it has none.
+func projectName(issue *domain.Issue) string {
+	if issue.Project() == nil {
+		return "-"
+	}
+	return issue.Project().Name()
+}
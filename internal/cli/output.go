@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// Format selects how commands print their results.
+type Format string
+
+// Output formats supported by the --output flag.
+const (
+	FormatTable Format = "table" // FormatTable prints human-readable tables
+	FormatJSON  Format = "json"  // FormatJSON prints JSON objects, lists are printed as arrays
+	FormatCSV   Format = "csv"   // FormatCSV prints a header line followed by one line per record
+)
+
+// String returns the name of the format.
+func (f *Format) String() string {
+	return string(*f)
+}
+
+// Set parses the format given on the command line.
+func (f *Format) Set(value string) error {
+	switch Format(value) {
+	case FormatTable, FormatJSON, FormatCSV:
+		*f = Format(value)
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q, expected table, json or csv", value)
+}
+
+// record is a result that can be printed as CSV.
+// The JSON representation is derived from the struct tags of the record.
+type record interface {
+	csvHeader() []string
+	csvFields() []string
+}
+
+// IssueRecord is the machine-readable representation of an issue.
+// The field names are part of the command line interface and must stay stable.
+type IssueRecord struct {
+	ID          int    `json:"id"`
+	ProjectID   int    `json:"project_id"`
+	Project     string `json:"project"`
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	Link        string `json:"link"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewIssueRecord converts an issue, the description is only included if withDescription is set.
+func NewIssueRecord(issue *domain.Issue, withDescription bool) IssueRecord {
+	rec := IssueRecord{
+		ID:     issue.ID(),
+		Title:  issue.FullTitle(),
+		Author: issue.Author(),
+		Link:   issue.Link(),
+	}
+	if p := issue.Project(); p != nil {
+		rec.ProjectID = p.ID()
+		rec.Project = p.Name()
+	}
+	if withDescription {
+		rec.Description = issue.FullDescription()
+	}
+	return rec
+}
+
+func (IssueRecord) csvHeader() []string {
+	return []string{"id", "project_id", "project", "title", "author", "link", "description"}
+}
+
+func (r IssueRecord) csvFields() []string {
+	return []string{strconv.Itoa(r.ID), strconv.Itoa(r.ProjectID), r.Project, r.Title, r.Author, r.Link, r.Description}
+}
+
+// TimeEntryRecord is the machine-readable representation of a time entry.
+// The field names are part of the command line interface and must stay stable.
+type TimeEntryRecord struct {
+	ID         int     `json:"id"`
+	SpentOn    string  `json:"spent_on"`
+	Hours      float64 `json:"hours"`
+	IssueID    int     `json:"issue_id"`
+	ProjectID  int     `json:"project_id"`
+	Project    string  `json:"project"`
+	ActivityID int     `json:"activity_id"`
+	Activity   string  `json:"activity"`
+	Comments   string  `json:"comments"`
+	User       string  `json:"user"`
+}
+
+// NewTimeEntryRecord converts a Redmine time entry.
+func NewTimeEntryRecord(entry models.TimeEntry) TimeEntryRecord {
+	return TimeEntryRecord{
+		ID:         entry.ID,
+		SpentOn:    entry.SpentOn,
+		Hours:      entry.Hours,
+		IssueID:    entry.Issue.ID,
+		ProjectID:  entry.Project.ID,
+		Project:    entry.Project.Name,
+		ActivityID: entry.Activity.ID,
+		Activity:   entry.Activity.Name,
+		Comments:   entry.Comments,
+		User:       entry.User.Name,
+	}
+}
+
+func (TimeEntryRecord) csvHeader() []string {
+	return []string{"id", "spent_on", "hours", "issue_id", "project_id", "project", "activity_id", "activity", "comments", "user"}
+}
+
+func (r TimeEntryRecord) csvFields() []string {
+	return []string{
+		strconv.Itoa(r.ID), r.SpentOn, strconv.FormatFloat(r.Hours, 'f', -1, 64), strconv.Itoa(r.IssueID),
+		strconv.Itoa(r.ProjectID), r.Project, strconv.Itoa(r.ActivityID), r.Activity, r.Comments, r.User,
+	}
+}
+
+// OutboxRecord is the machine-readable representation of a time entry waiting in the outbox.
+// The field names are part of the command line interface and must stay stable.
+type OutboxRecord struct {
+	ID          string  `json:"id"`
+	SpentOn     string  `json:"spent_on"`
+	Hours       float64 `json:"hours"`
+	IssueID     int     `json:"issue_id"`
+	ActivityID  int     `json:"activity_id"`
+	Comments    string  `json:"comments"`
+	Attempts    int     `json:"attempts"`
+	Status      string  `json:"status"`
+	TimeEntryID int     `json:"time_entry_id,omitempty"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// NewOutboxRecord converts an outbox entry with its status, the ID of the time entry it created and the error it failed with.
+// NewOutboxRecord falls back to the last recorded error of the entry if err is nil.
+func NewOutboxRecord(e outbox.Entry, status string, timeEntryID int, err error) OutboxRecord {
+	rec := OutboxRecord{
+		ID:          e.ID,
+		SpentOn:     e.Params.SpentOn,
+		Hours:       e.Params.Hours,
+		IssueID:     e.Params.IssueID,
+		ActivityID:  e.Params.ActivityID,
+		Comments:    e.Params.Comments,
+		Attempts:    e.Attempts,
+		Status:      status,
+		TimeEntryID: timeEntryID,
+		Error:       e.LastError,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	return rec
+}
+
+func (OutboxRecord) csvHeader() []string {
+	return []string{"id", "spent_on", "hours", "issue_id", "activity_id", "comments", "attempts", "status", "time_entry_id", "error"}
+}
+
+func (r OutboxRecord) csvFields() []string {
+	return []string{
+		r.ID, r.SpentOn, strconv.FormatFloat(r.Hours, 'f', -1, 64), strconv.Itoa(r.IssueID), strconv.Itoa(r.ActivityID),
+		r.Comments, strconv.Itoa(r.Attempts), r.Status, strconv.Itoa(r.TimeEntryID), r.Error,
+	}
+}
+
+// writeRecord prints a single record in the selected format, table prints the human-readable form.
+func writeRecord[T record](r *Runner, rec T, table func() error) error {
+	if r.format == FormatJSON {
+		return r.writeJSON(rec)
+	}
+	return writeRecords(r, []T{rec}, table)
+}
+
+// writeRecords prints a list of records in the selected format, table prints the human-readable form.
+func writeRecords[T record](r *Runner, recs []T, table func() error) error {
+	switch r.format {
+	case FormatJSON:
+		if recs == nil {
+			recs = []T{} // Scripts expect an empty array rather than null
+		}
+		return r.writeJSON(recs)
+	case FormatCSV:
+		w := csv.NewWriter(r.out)
+		var zero T
+		if err := w.Write(zero.csvHeader()); err != nil {
+			return err
+		}
+		for _, rec := range recs {
+			if err := w.Write(rec.csvFields()); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	return table()
+}
+
+// writeJSON prints v as indented JSON.
+func (r *Runner) writeJSON(v any) error {
+	enc := json.NewEncoder(r.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestOutput_JSONSearch verifies that search results are printed as JSON array with stable field names
+func TestOutput_JSONSearch(t *testing.T) {
+	r, out := newTestRunner(newFakeRepository())
+
+	if err := r.Run(context.Background(), []string{"--output", "json", "search", "Fix"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(got))
+	}
+	for key, want := range map[string]any{"id": 123.0, "project_id": 7.0, "project": "Website", "title": "Fix login"} {
+		if got[0][key] != want {
+			t.Errorf("%s = %v, want %v", key, got[0][key], want)
+		}
+	}
+	if _, ok := got[0]["description"]; ok {
+		t.Error("search results should not contain the description")
+	}
+}
+
+// TestOutput_JSONEmpty verifies that an empty result is printed as empty JSON array instead of null
+func TestOutput_JSONEmpty(t *testing.T) {
+	r, out := newTestRunner(newFakeRepository())
+
+	if err := r.Run(context.Background(), []string{"search", "nothing", "-o", "json"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "[]" {
+		t.Errorf("output = %q, want []", got)
+	}
+}
+
+// TestOutput_CSVEntries verifies that time entries are printed as CSV with a header line
+func TestOutput_CSVEntries(t *testing.T) {
+	repo := newFakeRepository()
+	var entry models.TimeEntry
+	entry.ID, entry.Hours, entry.SpentOn, entry.Comments = 1, 1.5, "2025-08-13", "Review, part 1"
+	entry.Issue.ID = 123
+	entry.Activity.Name = "Review"
+	repo.entries = []models.TimeEntry{entry}
+	r, out := newTestRunner(repo)
+
+	if err := r.Run(context.Background(), []string{"entries", "--output=csv"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	rows, err := csv.NewReader(out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected header and 1 row, got %d rows", len(rows))
+	}
+	if rows[0][0] != "id" || rows[0][2] != "hours" {
+		t.Errorf("unexpected header: %v", rows[0])
+	}
+	if rows[1][2] != "1.5" || rows[1][3] != "123" || rows[1][8] != "Review, part 1" {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+}
+
+// TestOutput_UnknownFormat verifies that an unknown format is a usage error
+func TestOutput_UnknownFormat(t *testing.T) {
+	r, _ := newTestRunner(newFakeRepository())
+
+	err := r.Run(context.Background(), []string{"--output", "xml", "search", "Fix"})
+	if got := ExitCode(err); got != ExitUsage {
+		t.Errorf("ExitCode = %d, want %d (error: %v)", got, ExitUsage, err)
+	}
+}
@@ -2,25 +2,56 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/b1tray3r/rmt/internal/redmine"
 	yaml "sigs.k8s.io/yaml/goyaml.v3"
 )
 
 // Config holds the application configuration.
+// Config either holds a single Redmine instance in Redmine or several named instances in Profiles.
 type Config struct {
-	Redmine RedmineConfig `yaml:"redmine"`
+	Redmine        RedmineConfig            `yaml:"redmine"`        // Redmine is the active Redmine instance
+	Profiles       map[string]RedmineConfig `yaml:"profiles"`       // Profiles holds the Redmine instances by name
+	DefaultProfile string                   `yaml:"defaultProfile"` // DefaultProfile is the profile used unless another one is selected
+	Favorites      []FavoriteConfig         `yaml:"favorites"`      // Favorites are the saved issue queries, nil selects the defaults
+
+	// Profile is the name of the active profile, empty if no profiles are configured.
+	Profile string `yaml:"-"`
+
+	path string // path is the file the config was loaded from
 }
 
 // RedmineConfig holds Redmine-specific configuration.
+// The API token is either given in Token or read from one of TokenEnv, TokenFile or TokenCmd.
 type RedmineConfig struct {
 	URL             string `yaml:"url"`
 	Token           string `yaml:"token"`
+	TokenEnv        string `yaml:"token_env"`  // TokenEnv names the environment variable holding the token
+	TokenFile       string `yaml:"token_file"` // TokenFile is the path of a file holding the token, "~/" expands to the home directory
+	TokenCmd        string `yaml:"token_cmd"`  // TokenCmd is a shell command printing the token, e.g. "pass show redmine"
 	FollowUpFieldID int    `yaml:"followUpFieldID"`
+	MaxResults      int    `yaml:"maxResults"`
+	TextFormatting  string `yaml:"textFormatting"` // TextFormatting is "textile" or "common_mark", empty guesses it for every text
 	Activities      struct {
 		Prefix []string `yaml:"prefix"`
 	} `yaml:"activities"`
+	Retry     RetryConfig      `yaml:"retry"`
+	Favorites []FavoriteConfig `yaml:"favorites"` // Favorites are the saved issue queries of this instance, nil falls back to the top-level favorites
+}
+
+// RetryConfig holds the limits for retrying failed Redmine requests.
+// Zero values fall back to the defaults of the Redmine client.
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"maxAttempts"` // MaxAttempts is the total number of attempts per request, 1 disables retries
+	BaseDelay   time.Duration `yaml:"baseDelay"`   // BaseDelay is the delay before the first retry (e.g. "500ms")
+	MaxDelay    time.Duration `yaml:"maxDelay"`    // MaxDelay caps the delay between two attempts (e.g. "10s")
 }
 
 // LoadConfig loads configuration from a YAML file.
@@ -35,14 +66,100 @@ func LoadConfig(file string) (*Config, error) {
 	if err := yaml.NewDecoder(f).Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to decode config file: %w", err)
 	}
+	config.path = file
 
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
+	// Tokens of profiles are resolved once they are used, so only the selected password command runs
+	if len(config.Profiles) > 0 {
+		if err := config.UseProfile(""); err != nil {
+			return nil, err
+		}
+	} else if err := config.Redmine.resolveToken("redmine", os.Stdin); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
+// ProfileNames returns the names of the configured profiles in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	return slices.Sorted(maps.Keys(c.Profiles))
+}
+
+// UseProfile makes the named profile the active Redmine instance.
+// UseProfile selects the default profile if name is empty, or the first profile if no default is configured.
+func (c *Config) UseProfile(name string) error {
+	if len(c.Profiles) == 0 {
+		if name != "" {
+			return fmt.Errorf("unknown profile %q: no profiles configured", name)
+		}
+		return nil
+	}
+
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = c.ProfileNames()[0]
+	}
+
+	profile, err := c.resolveProfile(name, os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	c.SetProfile(name, profile)
+	return nil
+}
+
+// ResolveProfile returns the named profile with its API token read from the configured source.
+// ResolveProfile leaves the active profile unchanged and runs token_cmd without input, so it can run in the
+// background while the interactive UI owns the terminal. SetProfile activates the returned profile.
+func (c *Config) ResolveProfile(name string) (RedmineConfig, error) {
+	return c.resolveProfile(name, nil)
+}
+
+// resolveProfile returns the named profile with its token, passing stdin to token_cmd.
+func (c *Config) resolveProfile(name string, stdin io.Reader) (RedmineConfig, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return RedmineConfig{}, fmt.Errorf("unknown profile %q, configured profiles: %v", name, c.ProfileNames())
+	}
+	if err := profile.resolveToken("profiles."+name, stdin); err != nil {
+		return RedmineConfig{}, err
+	}
+	return profile, nil
+}
+
+// SetProfile makes profile, as returned by ResolveProfile, the active Redmine instance under name.
+// Only Redmine holds the resolved token, Profiles keep their token sources so the profile can be resolved again.
+func (c *Config) SetProfile(name string, profile RedmineConfig) {
+	c.Redmine = profile
+	c.Profile = name
+}
+
+// NextProfile returns the name of the profile following the active one, wrapping around at the end.
+// NextProfile returns an empty string if less than two profiles are configured.
+func (c *Config) NextProfile() string {
+	return c.ProfileAfter(c.Profile)
+}
+
+// ProfileAfter returns the name of the profile following name, wrapping around at the end.
+// ProfileAfter lets the rotation skip profiles that could not be used.
+// ProfileAfter returns an empty string if less than two profiles are configured.
+func (c *Config) ProfileAfter(name string) string {
+	names := c.ProfileNames()
+	if len(names) < 2 {
+		return ""
+	}
+
+	index := slices.Index(names, name)
+	return names[(index+1)%len(names)]
+}
+
 // MissingFieldError represents an error for a missing required configuration field.
 type MissingFieldError struct {
 	Field string
@@ -54,12 +171,55 @@ func (e *MissingFieldError) Error() string {
 }
 
 // Validate validates the configuration.
+// Validate checks every profile, so switching profiles later cannot fail on an incomplete one.
 func (c *Config) Validate() error {
-	if c.Redmine.URL == "" {
-		return &MissingFieldError{Field: "redmine.url"}
+	if err := validateFavorites("favorites", c.Favorites); err != nil {
+		var mfe *MissingFieldError
+		if errors.As(err, &mfe) {
+			return err
+		}
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if len(c.Profiles) == 0 {
+		return c.Redmine.validate("redmine")
+	}
+
+	// Once a profile is active, Redmine holds a copy of it instead of a conflicting instance
+	if c.Profile == "" && (c.Redmine.URL != "" || c.Redmine.hasTokenSource()) {
+		return errors.New("invalid config: use either redmine or profiles, not both")
+	}
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			return fmt.Errorf("invalid config: default profile %q is not configured", c.DefaultProfile)
+		}
+	}
+
+	for _, name := range c.ProfileNames() {
+		if err := c.Profiles[name].validate("profiles." + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the required fields of a Redmine instance, prefix is the path of the instance in the config file.
+func (r RedmineConfig) validate(prefix string) error {
+	if r.URL == "" {
+		return &MissingFieldError{Field: prefix + ".url"}
+	}
+	if !r.hasTokenSource() {
+		return &MissingFieldError{Field: prefix + ".token"}
+	}
+	if _, err := redmine.ParseTextFormat(r.TextFormatting); err != nil {
+		return fmt.Errorf("invalid config: %s.textFormatting: %w", prefix, err)
 	}
-	if c.Redmine.Token == "" {
-		return &MissingFieldError{Field: "redmine.token"}
+	if err := validateFavorites(prefix+".favorites", r.Favorites); err != nil {
+		var mfe *MissingFieldError
+		if errors.As(err, &mfe) {
+			return err
+		}
+		return fmt.Errorf("invalid config: %s: %w", prefix, err)
 	}
 	return nil
 }
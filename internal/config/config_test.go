@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestLoadConfig_Success verifies that LoadConfig successfully loads a valid configuration file in YAML format.
@@ -46,6 +47,38 @@ redmine:
 	}
 }
 
+// TestLoadConfig_Retry verifies that retry limits are decoded including durations.
+func TestLoadConfig_Retry(t *testing.T) {
+	dir := t.TempDir()
+	configContent := `
+redmine:
+  url: https://example.com
+  token: secret
+  retry:
+    maxAttempts: 5
+    baseDelay: 250ms
+    maxDelay: 30s
+`
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Redmine.Retry.MaxAttempts != 5 {
+		t.Errorf("Redmine.Retry.MaxAttempts = %d, want 5", cfg.Redmine.Retry.MaxAttempts)
+	}
+	if cfg.Redmine.Retry.BaseDelay != 250*time.Millisecond {
+		t.Errorf("Redmine.Retry.BaseDelay = %v, want 250ms", cfg.Redmine.Retry.BaseDelay)
+	}
+	if cfg.Redmine.Retry.MaxDelay != 30*time.Second {
+		t.Errorf("Redmine.Retry.MaxDelay = %v, want 30s", cfg.Redmine.Retry.MaxDelay)
+	}
+}
+
 // TestConfig_Validate verifies that Validate returns errors for missing required fields.
 func TestConfig_Validate(t *testing.T) {
 	// Missing URL
@@ -78,6 +111,19 @@ func TestConfig_Validate(t *testing.T) {
 		t.Errorf("expected MissingFieldError for redmine.token, got %v", err)
 	}
 
+	// Unknown text formatting
+	cfg = &Config{
+		Redmine: RedmineConfig{
+			URL:            "https://example.com",
+			Token:          "token",
+			TextFormatting: "html",
+		},
+	}
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "redmine.textFormatting") {
+		t.Errorf("expected error for unknown text formatting, got %v", err)
+	}
+
 	// All fields present
 	cfg = &Config{
 		Redmine: RedmineConfig{
@@ -149,3 +195,84 @@ redmine:
 		t.Errorf("expected error to be of type MissingFieldError, got: %v", err)
 	}
 }
+
+// TestLoadConfig_Profiles verifies that profiles are decoded and the default profile becomes the active instance.
+func TestLoadConfig_Profiles(t *testing.T) {
+	dir := t.TempDir()
+	configContent := `
+defaultProfile: internal
+profiles:
+  internal:
+    url: https://redmine.internal.example.com
+    token: internal-secret
+    followUpFieldID: 88
+  customer:
+    url: https://redmine.customer.example.com
+    token: customer-secret
+    activities:
+      prefix:
+        - Consulting
+`
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Profile != "internal" || cfg.Redmine.URL != "https://redmine.internal.example.com" || cfg.Redmine.FollowUpFieldID != 88 {
+		t.Errorf("active profile = %q with %+v, want internal", cfg.Profile, cfg.Redmine)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate after selecting a profile returned error: %v", err)
+	}
+
+	if next := cfg.NextProfile(); next != "customer" {
+		t.Errorf("NextProfile() = %q, want %q", next, "customer")
+	}
+	if err := cfg.UseProfile("customer"); err != nil {
+		t.Fatalf("UseProfile returned error: %v", err)
+	}
+	if cfg.Redmine.Token != "customer-secret" || len(cfg.Redmine.Activities.Prefix) != 1 {
+		t.Errorf("Redmine = %+v, want the customer profile", cfg.Redmine)
+	}
+	if next := cfg.NextProfile(); next != "internal" {
+		t.Errorf("NextProfile() = %q, want %q", next, "internal")
+	}
+
+	if err := cfg.UseProfile("unknown"); err == nil {
+		t.Error("expected error for unknown profile, got nil")
+	}
+}
+
+// TestConfig_ValidateProfiles verifies that every profile is validated and mixing redmine with profiles is rejected.
+func TestConfig_ValidateProfiles(t *testing.T) {
+	cfg := &Config{
+		Profiles: map[string]RedmineConfig{
+			"a": {URL: "https://a.example.com", Token: "token"},
+			"b": {URL: "https://b.example.com"},
+		},
+	}
+	var mfe *MissingFieldError
+	if err := cfg.Validate(); !errors.As(err, &mfe) || mfe.Field != "profiles.b.token" {
+		t.Errorf("expected MissingFieldError for profiles.b.token, got %v", err)
+	}
+
+	cfg = &Config{
+		Redmine:  RedmineConfig{URL: "https://example.com", Token: "token"},
+		Profiles: map[string]RedmineConfig{"a": {URL: "https://a.example.com", Token: "token"}},
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for config with both redmine and profiles, got nil")
+	}
+
+	cfg = &Config{
+		DefaultProfile: "missing",
+		Profiles:       map[string]RedmineConfig{"a": {URL: "https://a.example.com", Token: "token"}},
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown default profile, got nil")
+	}
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/b1tray3r/rmt/internal/http/querystring"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	yaml "sigs.k8s.io/yaml/goyaml.v3"
+)
+
+// FavoriteConfig holds a saved issue query shown in the favorites list.
+// The query is either given as raw Redmine query string in Query or as structured fields in Filter.
+type FavoriteConfig struct {
+	Name   string          `yaml:"name"`             // Name is shown in the favorites list
+	Query  string          `yaml:"query,omitempty"`  // Query is a raw Redmine issue query string
+	Filter *FavoriteFilter `yaml:"filter,omitempty"` // Filter describes the query with structured fields instead
+	Hotkey string          `yaml:"hotkey,omitempty"` // Hotkey runs the favorite from the search view, e.g. "alt+1"
+}
+
+// ReservedHotkeys are the keys the application handles in every view before the search view sees them.
+// A favorite bound to one of them could never run, so they are rejected as hotkeys.
+var ReservedHotkeys = []string{"alt+f", "alt+o", "alt+p", "alt+s", "alt+w", "ctrl+c", "esc"}
+
+// validateFavorites checks that every favorite has a name and a valid query and is not bound to a reserved key.
+// validateFavorites is shared by loading and saving, so a favorite saved from the TUI never breaks the next start.
+// prefix is the path of the list in the config file.
+func validateFavorites(prefix string, favorites []FavoriteConfig) error {
+	for i, favorite := range favorites {
+		if favorite.Name == "" {
+			return &MissingFieldError{Field: fmt.Sprintf("%s[%d].name", prefix, i)}
+		}
+		if favorite.Query == "" && favorite.Filter == nil {
+			return &MissingFieldError{Field: fmt.Sprintf("%s[%d].query", prefix, i)}
+		}
+		if favorite.Filter == nil {
+			if _, err := redmine.ParseIssueQuery(favorite.Query); err != nil {
+				return fmt.Errorf("favorite %q: %w", favorite.Name, err)
+			}
+		}
+		if err := favorite.checkHotkey(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHotkey returns an error if the hotkey of the favorite is reserved by the application.
+func (f FavoriteConfig) checkHotkey() error {
+	if slices.Contains(ReservedHotkeys, strings.ToLower(strings.TrimSpace(f.Hotkey))) {
+		return fmt.Errorf("favorite %q: hotkey %q is reserved, reserved keys: %s", f.Name, f.Hotkey, strings.Join(ReservedHotkeys, ", "))
+	}
+	return nil
+}
+
+// FavoriteFilter describes an issue query with the filter parameters of the Redmine REST API.
+// Empty fields do not restrict the query.
+type FavoriteFilter struct {
+	ProjectID      string         `yaml:"projectId,omitempty" query:"project_id,omitempty"`            // ProjectID is a project ID or identifier
+	StatusID       string         `yaml:"statusId,omitempty" query:"status_id,omitempty"`              // StatusID is "open", "closed", "*" or a status ID
+	TrackerID      string         `yaml:"trackerId,omitempty" query:"tracker_id,omitempty"`            // TrackerID is a tracker ID
+	AssignedToID   string         `yaml:"assignedToId,omitempty" query:"assigned_to_id,omitempty"`     // AssignedToID is "me" or a user ID
+	FixedVersionID string         `yaml:"fixedVersionId,omitempty" query:"fixed_version_id,omitempty"` // FixedVersionID is a version ID
+	Sort           string         `yaml:"sort,omitempty" query:"sort,omitempty"`                       // Sort is e.g. "updated_on:desc"
+	CustomFields   map[int]string `yaml:"customFields,omitempty" query:"cf_,omitempty"`                // CustomFields maps custom field IDs to values
+}
+
+// QueryString returns the Redmine issue query string of the favorite.
+func (f FavoriteConfig) QueryString() (string, error) {
+	if f.Filter == nil {
+		return f.Query, nil
+	}
+
+	encoded, err := querystring.Marshal(*f.Filter)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode filter of favorite %q: %w", f.Name, err)
+	}
+
+	return string(encoded), nil
+}
+
+// DefaultFavorites returns the favorites shown if the config file does not declare any.
+// The follow-up favorite is only included if the follow-up custom field is configured.
+func DefaultFavorites(followUpFieldID int) []FavoriteConfig {
+	var favorites []FavoriteConfig
+	if followUpFieldID != 0 {
+		favorites = append(favorites, FavoriteConfig{
+			Name:  "Follow-up: this week",
+			Query: fmt.Sprintf("f%%5B%%5D=status_id&op%%5Bstatus_id%%5D=o&f%%5B%%5D=assigned_to_id&op%%5Bassigned_to_id%%5D=%%3D&v%%5Bassigned_to_id%%5D%%5B%%5D=me&f%%5B%%5D=cf_%d&op%%5Bcf_%d%%5D=w", followUpFieldID, followUpFieldID),
+		})
+	}
+
+	return append(favorites, FavoriteConfig{
+		Name:  "My open issues",
+		Query: "f%5B%5D=status_id&op%5Bstatus_id%5D=o&f%5B%5D=assigned_to_id&op%5Bassigned_to_id%5D=%3D&v%5Bassigned_to_id%5D%5B%5D=me",
+	})
+}
+
+// ActiveFavorites returns the favorites of the active Redmine instance, falling back to the top-level favorites
+// and then to the defaults. An explicitly empty list in the config file disables the fallback.
+func (c *Config) ActiveFavorites() []FavoriteConfig {
+	if c.Redmine.Favorites != nil {
+		return c.Redmine.Favorites
+	}
+	if c.Favorites == nil {
+		return DefaultFavorites(c.Redmine.FollowUpFieldID)
+	}
+	return c.Favorites
+}
+
+// favoritesPath returns the keys of the mapping in the config file that the favorites are saved in.
+// With profiles the favorites belong to the active profile, otherwise to the redmine block if it declares its own.
+func (c *Config) favoritesPath() []string {
+	if c.Profile != "" {
+		return []string{"profiles", c.Profile}
+	}
+	if c.Redmine.Favorites != nil {
+		return []string{"redmine"}
+	}
+	return nil
+}
+
+// SaveFavorites replaces the favorites in the config file the config was loaded from.
+// With profiles the favorites are saved in the active profile, so every Redmine instance keeps its own list.
+// SaveFavorites keeps everything else in the file as it is, including comments and tokens.
+func (c *Config) SaveFavorites(favorites []FavoriteConfig) error {
+	if c.path == "" {
+		return fmt.Errorf("failed to save favorites: config was not loaded from a file")
+	}
+	if err := validateFavorites("favorites", favorites); err != nil {
+		return fmt.Errorf("failed to save favorites: %w", err)
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to decode config file: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to save favorites: config file is not a mapping")
+	}
+
+	path := c.favoritesPath()
+	mapping := doc.Content[0]
+	for _, key := range path {
+		if mapping = mappingValue(mapping, key); mapping == nil || mapping.Kind != yaml.MappingNode {
+			return fmt.Errorf("failed to save favorites: %s is not a mapping in the config file", strings.Join(path, "."))
+		}
+	}
+
+	var value yaml.Node
+	if err := value.Encode(favorites); err != nil {
+		return fmt.Errorf("failed to encode favorites: %w", err)
+	}
+	setMappingValue(mapping, "favorites", &value)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := writeFileAtomic(c.path, out); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Profile != "":
+		profile := c.Profiles[c.Profile]
+		profile.Favorites = favorites
+		c.Profiles[c.Profile] = profile
+		c.Redmine.Favorites = favorites
+	case path != nil:
+		c.Redmine.Favorites = favorites
+	default:
+		c.Favorites = favorites
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in the mapping node, or nil if the key does not exist.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key in the mapping node to value, appending the key if it does not exist yet.
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// writeFileAtomic replaces the file at path with data, keeping its permissions.
+// writeFileAtomic writes to a temporary file first, so a crash never leaves a truncated config file behind.
+func writeFileAtomic(path string, data []byte) error {
+	// Config files are often symlinked from a dotfiles repository, the link must stay intact
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".config-*.yml")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestFavoriteConfig_QueryString verifies that structured filters are encoded as Redmine query strings.
+func TestFavoriteConfig_QueryString(t *testing.T) {
+	favorite := FavoriteConfig{
+		Name: "Open bugs",
+		Filter: &FavoriteFilter{
+			ProjectID:    "website",
+			StatusID:     "open",
+			TrackerID:    "1",
+			AssignedToID: "me",
+			Sort:         "updated_on:desc",
+			CustomFields: map[int]string{12: "yes"},
+		},
+	}
+
+	got, err := favorite.QueryString()
+	if err != nil {
+		t.Fatalf("QueryString returned error: %v", err)
+	}
+	want := "assigned_to_id=me&cf_12=yes&project_id=website&sort=updated_on%3Adesc&status_id=open&tracker_id=1"
+	if got != want {
+		t.Errorf("QueryString() = %q, want %q", got, want)
+	}
+
+	raw := FavoriteConfig{Name: "Raw", Query: "status_id=closed"}
+	if got, _ := raw.QueryString(); got != "status_id=closed" {
+		t.Errorf("QueryString() = %q, want %q", got, "status_id=closed")
+	}
+}
+
+// TestConfig_ActiveFavorites verifies that the favorites of the active instance are preferred and the defaults are only used if the config file declares no favorites.
+func TestConfig_ActiveFavorites(t *testing.T) {
+	cfg := &Config{Redmine: RedmineConfig{FollowUpFieldID: 88}}
+	if got := cfg.ActiveFavorites(); len(got) != 2 || !strings.Contains(got[0].Query, "cf_88") {
+		t.Errorf("ActiveFavorites() = %+v, want the two defaults", got)
+	}
+
+	cfg = &Config{}
+	if got := cfg.ActiveFavorites(); len(got) != 1 {
+		t.Errorf("ActiveFavorites() = %+v, want only the default without follow-up field", got)
+	}
+
+	cfg = &Config{Favorites: []FavoriteConfig{}}
+	if got := cfg.ActiveFavorites(); len(got) != 0 {
+		t.Errorf("ActiveFavorites() = %+v, want none for an explicitly empty list", got)
+	}
+
+	cfg = &Config{
+		Redmine:   RedmineConfig{Favorites: []FavoriteConfig{{Name: "Profile"}}},
+		Favorites: []FavoriteConfig{{Name: "Shared"}},
+	}
+	if got := cfg.ActiveFavorites(); len(got) != 1 || got[0].Name != "Profile" {
+		t.Errorf("ActiveFavorites() = %+v, want the favorites of the active instance", got)
+	}
+}
+
+// TestLoadConfig_Favorites verifies that favorites are decoded and validated.
+func TestLoadConfig_Favorites(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token: secret
+favorites:
+  - name: Mine
+    query: assigned_to_id=me
+    hotkey: alt+1
+  - name: Website
+    filter:
+      projectId: website
+      statusId: "*"
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if len(cfg.Favorites) != 2 || cfg.Favorites[0].Hotkey != "alt+1" || cfg.Favorites[1].Filter == nil || cfg.Favorites[1].Filter.StatusID != "*" {
+		t.Errorf("Favorites = %+v", cfg.Favorites)
+	}
+
+	_, err = LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token: secret
+favorites:
+  - name: Nothing
+`))
+	if mfe, ok := err.(*MissingFieldError); !ok || mfe.Field != "favorites[0].query" {
+		t.Errorf("expected MissingFieldError for favorites[0].query, got %v", err)
+	}
+
+	_, err = LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token: secret
+favorites:
+  - name: Broken
+    query: f[]=status_id&op[status_id]=%3F
+`))
+	if err == nil || !strings.Contains(err.Error(), `"Broken"`) {
+		t.Errorf("expected error for the invalid query of favorite Broken, got %v", err)
+	}
+
+	_, err = LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token: secret
+favorites:
+  - name: Shadowed
+    query: assigned_to_id=me
+    hotkey: alt+p
+`))
+	if err == nil || !strings.Contains(err.Error(), `"alt+p" is reserved`) {
+		t.Errorf("expected error for the reserved hotkey of favorite Shadowed, got %v", err)
+	}
+
+	_, err = LoadConfig(writeConfig(t, `
+profiles:
+  a:
+    url: https://a.example.com
+    token: secret
+    favorites:
+      - name: Nothing
+`))
+	if mfe, ok := err.(*MissingFieldError); !ok || mfe.Field != "profiles.a.favorites[0].query" {
+		t.Errorf("expected MissingFieldError for profiles.a.favorites[0].query, got %v", err)
+	}
+}
+
+// TestConfig_SaveFavorites verifies that saving favorites keeps the rest of the config file untouched.
+func TestConfig_SaveFavorites(t *testing.T) {
+	t.Setenv("RMT_TEST_TOKEN", "resolved-secret")
+	path := writeConfig(t, `# rmt configuration
+redmine:
+  url: https://example.com
+  # the token lives in the environment
+  token_env: RMT_TEST_TOKEN
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	favorites := []FavoriteConfig{{Name: "Mine", Query: "assigned_to_id=me", Hotkey: "alt+1"}}
+	if err := cfg.SaveFavorites(favorites); err != nil {
+		t.Fatalf("SaveFavorites returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"# rmt configuration", "# the token lives in the environment", "token_env: RMT_TEST_TOKEN", "name: Mine", "hotkey: alt+1"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("config file should contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "resolved-secret") {
+		t.Errorf("config file must not contain the resolved token:\n%s", content)
+	}
+
+	reloaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig of the saved file returned error: %v", err)
+	}
+	if len(reloaded.Favorites) != 1 || reloaded.Favorites[0] != favorites[0] {
+		t.Errorf("Favorites = %+v, want %+v", reloaded.Favorites, favorites)
+	}
+
+	// Saving again replaces the favorites instead of adding a second key
+	if err := reloaded.SaveFavorites(nil); err != nil {
+		t.Fatalf("SaveFavorites returned error: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Count(string(data), "favorites:") != 1 {
+		t.Errorf("config file should contain exactly one favorites key:\n%s", data)
+	}
+
+	// A favorite bound to a key of the application would never run
+	if err := reloaded.SaveFavorites([]FavoriteConfig{{Name: "Mine", Query: "assigned_to_id=me", Hotkey: "alt+w"}}); err == nil {
+		t.Error("expected error for a reserved hotkey, got nil")
+	}
+
+	// A query the next start would reject is not written
+	if err := reloaded.SaveFavorites([]FavoriteConfig{{Name: "Broken", Query: "f[]=status_id&op[status_id]=%3F"}}); err == nil {
+		t.Error("expected error for an invalid query, got nil")
+	}
+	if unchanged, _ := os.ReadFile(path); string(unchanged) != string(data) {
+		t.Errorf("config file changed although saving failed:\n%s", unchanged)
+	}
+	if _, err := LoadConfig(path); err != nil {
+		t.Errorf("LoadConfig after the refused save returned error: %v", err)
+	}
+}
+
+// TestConfig_SaveFavorites_Profiles verifies that favorites are saved in the active profile and other profiles keep falling back to the top-level favorites.
+func TestConfig_SaveFavorites_Profiles(t *testing.T) {
+	path := writeConfig(t, `
+defaultProfile: a
+profiles:
+  a:
+    url: https://a.example.com
+    token: secret-a
+  b:
+    url: https://b.example.com
+    token: secret-b
+favorites:
+  - name: Shared
+    query: assigned_to_id=me
+`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if got := cfg.ActiveFavorites(); len(got) != 1 || got[0].Name != "Shared" {
+		t.Fatalf("ActiveFavorites() = %+v, want the top-level favorites", got)
+	}
+
+	favorites := []FavoriteConfig{{Name: "Only a", Query: "project_id=a"}}
+	if err := cfg.SaveFavorites(favorites); err != nil {
+		t.Fatalf("SaveFavorites returned error: %v", err)
+	}
+	if got := cfg.ActiveFavorites(); len(got) != 1 || got[0] != favorites[0] {
+		t.Errorf("ActiveFavorites() = %+v, want the saved favorites", got)
+	}
+	if cfg.Profiles["a"].Token != "secret-a" || len(cfg.Profiles["a"].Favorites) != 1 {
+		t.Errorf("Profiles[a] = %+v, want the saved favorites next to the token", cfg.Profiles["a"])
+	}
+
+	reloaded, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig of the saved file returned error: %v", err)
+	}
+	if got := reloaded.ActiveFavorites(); len(got) != 1 || got[0] != favorites[0] {
+		t.Errorf("ActiveFavorites() of profile a = %+v, want %+v", got, favorites)
+	}
+	if len(reloaded.Favorites) != 1 || reloaded.Favorites[0].Name != "Shared" {
+		t.Errorf("Favorites = %+v, want the top-level favorites unchanged", reloaded.Favorites)
+	}
+
+	if err := reloaded.UseProfile("b"); err != nil {
+		t.Fatalf("UseProfile returned error: %v", err)
+	}
+	if got := reloaded.ActiveFavorites(); len(got) != 1 || got[0].Name != "Shared" {
+		t.Errorf("ActiveFavorites() of profile b = %+v, want the top-level favorites", got)
+	}
+}
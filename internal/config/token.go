@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// TokenCmdTimeout limits how long token_cmd may run, e.g. while a password manager waits for a passphrase.
+const TokenCmdTimeout = 30 * time.Second
+
+// TokenSourceError is returned when the API token could not be read from the configured source.
+// TokenSourceError never contains the token itself.
+type TokenSourceError struct {
+	Field  string // Field is the config field of the source, e.g. "redmine.token_env"
+	Reason string // Reason describes why no token could be read
+	Err    error  // Err is the underlying error, if any
+}
+
+// Error returns the error message for TokenSourceError.
+func (e *TokenSourceError) Error() string {
+	msg := fmt.Sprintf("invalid token source %s: %s", e.Field, e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *TokenSourceError) Unwrap() error {
+	return e.Err
+}
+
+// String returns a description of the Redmine instance that leaves out the API token.
+// String keeps the token out of logs and error messages that print the config.
+func (r RedmineConfig) String() string {
+	token := "<empty>"
+	if r.Token != "" {
+		token = "<redacted>"
+	}
+	return fmt.Sprintf("{URL:%s Token:%s FollowUpFieldID:%d}", r.URL, token, r.FollowUpFieldID)
+}
+
+// GoString returns the same redacted description as String for the %#v verb.
+func (r RedmineConfig) GoString() string {
+	return r.String()
+}
+
+// hasTokenSource reports whether a token or a source to read it from is configured.
+func (r *RedmineConfig) hasTokenSource() bool {
+	return r.Token != "" || r.TokenEnv != "" || r.TokenFile != "" || r.TokenCmd != ""
+}
+
+// resolveToken reads the API token from the configured source into Token.
+// prefix is the path of the instance in the config file, used in error messages.
+// stdin is passed to token_cmd, e.g. for a passphrase prompt, nil runs the command without input.
+func (r *RedmineConfig) resolveToken(prefix string, stdin io.Reader) error {
+	sources := 0
+	for _, value := range []string{r.Token, r.TokenEnv, r.TokenFile, r.TokenCmd} {
+		if value != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return &TokenSourceError{Field: prefix + ".token", Reason: "only one of token, token_env, token_file and token_cmd may be set"}
+	}
+
+	switch {
+	case r.TokenEnv != "":
+		r.Token = strings.TrimSpace(os.Getenv(r.TokenEnv))
+		if r.Token == "" {
+			return &TokenSourceError{Field: prefix + ".token_env", Reason: fmt.Sprintf("environment variable %s is not set or empty", r.TokenEnv)}
+		}
+
+	case r.TokenFile != "":
+		path, err := expandHome(r.TokenFile)
+		if err != nil {
+			return &TokenSourceError{Field: prefix + ".token_file", Reason: "cannot expand path", Err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			// The path error does not contain any file content
+			return &TokenSourceError{Field: prefix + ".token_file", Reason: "cannot read file", Err: err}
+		}
+		r.Token = strings.TrimSpace(string(data))
+		if r.Token == "" {
+			return &TokenSourceError{Field: prefix + ".token_file", Reason: fmt.Sprintf("file %s is empty", path)}
+		}
+
+	case r.TokenCmd != "":
+		token, err := runTokenCmd(r.TokenCmd, stdin)
+		if err != nil {
+			return &TokenSourceError{Field: prefix + ".token_cmd", Reason: fmt.Sprintf("command %q failed", r.TokenCmd), Err: err}
+		}
+		r.Token = token
+		if r.Token == "" {
+			return &TokenSourceError{Field: prefix + ".token_cmd", Reason: fmt.Sprintf("command %q printed no token", r.TokenCmd)}
+		}
+	}
+
+	return nil
+}
+
+// runTokenCmd runs command in the shell and returns the first line it prints to stdout.
+// Errors only carry stderr of the command, stdout may contain the token.
+func runTokenCmd(command string, stdin io.Reader) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TokenCmdTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = stdin // Password managers may ask for a passphrase
+	}
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+
+	// Password managers like pass print the password on the first line, followed by metadata
+	line, _, _ := strings.Cut(stdout.String(), "\n")
+	return strings.TrimSpace(line), nil
+}
+
+// expandHome replaces a leading "~/" in path with the home directory of the user.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if home == "" {
+		return "", errors.New("home directory is unknown")
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes content to a config file in a temporary directory and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+// TestLoadConfig_TokenEnv verifies that the token is read from the environment variable named by token_env.
+func TestLoadConfig_TokenEnv(t *testing.T) {
+	t.Setenv("RMT_TEST_TOKEN", "env-secret\n")
+
+	cfg, err := LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token_env: RMT_TEST_TOKEN
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Redmine.Token != "env-secret" {
+		t.Errorf("Redmine.Token = %q, want %q", cfg.Redmine.Token, "env-secret")
+	}
+}
+
+// TestLoadConfig_TokenFile verifies that the token is read from the file given by token_file, including "~/" paths.
+func TestLoadConfig_TokenFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".secrets"), 0700); err != nil {
+		t.Fatalf("failed to create secrets directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".secrets", "redmine"), []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	cfg, err := LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token_file: ~/.secrets/redmine
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Redmine.Token != "file-secret" {
+		t.Errorf("Redmine.Token = %q, want %q", cfg.Redmine.Token, "file-secret")
+	}
+}
+
+// TestLoadConfig_TokenCmd verifies that the token is read from the first line printed by token_cmd.
+func TestLoadConfig_TokenCmd(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, `
+redmine:
+  url: https://example.com
+  token_cmd: "printf 'cmd-secret\nlogin: jane\n'"
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Redmine.Token != "cmd-secret" {
+		t.Errorf("Redmine.Token = %q, want %q", cfg.Redmine.Token, "cmd-secret")
+	}
+}
+
+// TestConfig_ResolveProfile verifies that token_cmd gets no input and the active profile only changes with SetProfile.
+func TestConfig_ResolveProfile(t *testing.T) {
+	cfg := &Config{
+		Profile: "a",
+		Profiles: map[string]RedmineConfig{
+			"a": {URL: "https://a.example.com", Token: "a-secret"},
+			"b": {URL: "https://b.example.com", TokenCmd: "read line; echo \"b-secret$line\""},
+			"c": {URL: "https://c.example.com", TokenCmd: "exit 1"},
+		},
+	}
+	cfg.Redmine = cfg.Profiles["a"]
+
+	profile, err := cfg.ResolveProfile("b")
+	if err != nil {
+		t.Fatalf("ResolveProfile returned error: %v", err)
+	}
+	if profile.Token != "b-secret" {
+		t.Errorf("Token = %q, want %q", profile.Token, "b-secret")
+	}
+	if cfg.Profile != "a" || cfg.Redmine.Token != "a-secret" {
+		t.Errorf("active profile = %q, want a unchanged", cfg.Profile)
+	}
+
+	if _, err := cfg.ResolveProfile("c"); err == nil {
+		t.Error("expected error for failing token_cmd, got nil")
+	}
+	if next := cfg.ProfileAfter("c"); next != "a" {
+		t.Errorf("ProfileAfter(c) = %q, want %q", next, "a")
+	}
+
+	cfg.SetProfile("b", profile)
+	if cfg.Profile != "b" || cfg.Redmine.Token != "b-secret" || cfg.Profiles["b"].Token != "" {
+		t.Errorf("active profile = %q with token %q, want b", cfg.Profile, cfg.Redmine.Token)
+	}
+	if next := cfg.NextProfile(); next != "c" {
+		t.Errorf("NextProfile() = %q, want %q", next, "c")
+	}
+}
+
+// TestConfig_SwitchBackToTokenSource verifies that a profile reading its token from a source can be activated again.
+func TestConfig_SwitchBackToTokenSource(t *testing.T) {
+	t.Setenv("RMT_TEST_TOKEN", "env-secret")
+	cfg, err := LoadConfig(writeConfig(t, `
+defaultProfile: a
+profiles:
+  a:
+    url: https://a.example.com
+    token_env: RMT_TEST_TOKEN
+  b:
+    url: https://b.example.com
+    token: b-secret
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	for _, name := range []string{"b", "a", "b", "a"} {
+		profile, err := cfg.ResolveProfile(name)
+		if err != nil {
+			t.Fatalf("ResolveProfile(%q) returned error: %v", name, err)
+		}
+		cfg.SetProfile(name, profile)
+	}
+	if cfg.Profile != "a" || cfg.Redmine.Token != "env-secret" {
+		t.Errorf("active profile = %q with token %q, want a with the token from the environment", cfg.Profile, cfg.Redmine.Token)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate after switching profiles returned error: %v", err)
+	}
+}
+
+// TestLoadConfig_TokenSourceErrors verifies the errors for empty or conflicting token sources.
+func TestLoadConfig_TokenSourceErrors(t *testing.T) {
+	t.Setenv("RMT_TEST_EMPTY", "")
+
+	tests := []struct {
+		name      string
+		source    string
+		wantField string
+	}{
+		{"empty env", "token_env: RMT_TEST_EMPTY", "redmine.token_env"},
+		{"missing file", "token_file: " + filepath.Join(t.TempDir(), "missing"), "redmine.token_file"},
+		{"failing cmd", `token_cmd: "printf '%s-%s' secret stdout; exit 3"`, "redmine.token_cmd"},
+		{"silent cmd", `token_cmd: "true"`, "redmine.token_cmd"},
+		{"several sources", "token: secret-in-config\n  token_env: RMT_TEST_EMPTY", "redmine.token"},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadConfig(writeConfig(t, fmt.Sprintf("redmine:\n  url: https://example.com\n  %s\n", tt.source)))
+
+		var sourceErr *TokenSourceError
+		if !errors.As(err, &sourceErr) {
+			t.Errorf("%s: expected TokenSourceError, got %v", tt.name, err)
+			continue
+		}
+		if sourceErr.Field != tt.wantField {
+			t.Errorf("%s: Field = %q, want %q", tt.name, sourceErr.Field, tt.wantField)
+		}
+		if strings.Contains(err.Error(), "secret-") {
+			t.Errorf("%s: error must not contain the token: %v", tt.name, err)
+		}
+	}
+}
+
+// TestRedmineConfig_String verifies that printing the config never reveals the token.
+func TestRedmineConfig_String(t *testing.T) {
+	r := RedmineConfig{URL: "https://example.com", Token: "top-secret"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		if got := fmt.Sprintf(format, r); strings.Contains(got, "top-secret") {
+			t.Errorf("Sprintf(%q) = %q, must not contain the token", format, got)
+		}
+	}
+}
@@ -1,15 +1,64 @@
 package querystring
 
 import (
+	"encoding"
 	"fmt"
 	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// fieldOptions holds the options of a `query` struct tag.
+type fieldOptions struct {
+	key       string // key is the parameter name, or the parameter name prefix for maps
+	omitempty bool   // omitempty skips zero values
+	separator string // separator joins slice elements into a single parameter instead of repeating it
+	layout    string // layout formats time.Time values
+}
+
+// parseTag parses a `query` struct tag and reports whether the field is encoded at all.
+func parseTag(tag string) (fieldOptions, bool) {
+	if tag == "-" {
+		return fieldOptions{}, false
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "" {
+		return fieldOptions{}, false
+	}
+
+	opts := fieldOptions{key: parts[0], layout: time.RFC3339}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			opts.omitempty = true
+		case opt == "comma":
+			opts.separator = ","
+		case opt == "pipe":
+			opts.separator = "|"
+		case strings.HasPrefix(opt, "layout="):
+			opts.layout = strings.TrimPrefix(opt, "layout=")
+		}
+	}
+	return opts, true
+}
+
 // Marshal converts a struct with `query:"key,omitempty"` tags into a query string.
 // Booleans are encoded as "1"/"0". Omits zero values if `omitempty` is set.
+// Slices repeat the key for every element, unless the `comma` or `pipe` option joins the elements into one value.
+// Redmine expects multiple values of a filter joined by "|", e.g. status_id=1|2.
+// time.Time values are formatted as RFC 3339, or with the layout given as option, e.g. `query:"from,layout=2006-01-02"`.
+// Maps encode one parameter per entry, named by the key followed by the map key, e.g. `query:"cf_"` results in cf_5=value.
+// Pointers are dereferenced, nil pointers are skipped. Types implementing encoding.TextMarshaler are encoded as their text.
 func Marshal(v interface{}) ([]byte, error) {
 	rv := reflect.ValueOf(v)
 	rt := reflect.TypeOf(v)
@@ -24,46 +73,306 @@ func Marshal(v interface{}) ([]byte, error) {
 		field := rv.Field(i)
 		fieldType := rt.Field(i)
 
-		tag := fieldType.Tag.Get("query")
-		if tag == "-" {
+		opts, ok := parseTag(fieldType.Tag.Get("query"))
+		if !ok || !fieldType.IsExported() {
+			continue
+		}
+
+		if opts.omitempty && field.IsZero() {
+			continue
+		}
+
+		if field.Kind() == reflect.Map {
+			if err := encodeMap(values, field, opts); err != nil {
+				return nil, fmt.Errorf("MarshalQuery: field %s: %w", fieldType.Name, err)
+			}
+			continue
+		}
+
+		strs, err := encodeField(field, opts.layout)
+		if err != nil {
+			return nil, fmt.Errorf("MarshalQuery: field %s: %w", fieldType.Name, err)
+		}
+		if opts.separator != "" && len(strs) > 0 {
+			strs = []string{strings.Join(strs, opts.separator)}
+		}
+		for _, str := range strs {
+			values.Add(opts.key, str)
+		}
+	}
+
+	return []byte(values.Encode()), nil
+}
+
+// encodeField returns the values of a field, one for every element of a slice.
+func encodeField(v reflect.Value, layout string) ([]string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && !isText(v.Type()) {
+		strs := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			str, err := formatValue(v.Index(i), layout)
+			if err != nil {
+				return nil, err
+			}
+			strs = append(strs, str)
+		}
+		return strs, nil
+	}
+
+	str, err := formatValue(v, layout)
+	if err != nil {
+		return nil, err
+	}
+	return []string{str}, nil
+}
+
+// encodeMap adds one parameter per map entry to values.
+func encodeMap(values url.Values, v reflect.Value, opts fieldOptions) error {
+	iter := v.MapRange()
+	for iter.Next() {
+		if opts.omitempty && iter.Value().IsZero() {
 			continue
 		}
 
-		tagParts := strings.Split(tag, ",")
-		if len(tagParts) == 0 || tagParts[0] == "" {
+		key, err := formatValue(iter.Key(), opts.layout)
+		if err != nil {
+			return err
+		}
+		strs, err := encodeField(iter.Value(), opts.layout)
+		if err != nil {
+			return err
+		}
+		if opts.separator != "" && len(strs) > 0 {
+			strs = []string{strings.Join(strs, opts.separator)}
+		}
+		for _, str := range strs {
+			values.Add(opts.key+key, str)
+		}
+	}
+	return nil
+}
+
+// formatValue returns the text of a single value.
+func formatValue(v reflect.Value, layout string) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(layout), nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// Unmarshal decodes values into the struct v points to, using the same `query` tags as Marshal.
+// Unmarshal leaves fields without a parameter untouched. Single value fields take the first value of a repeated parameter.
+// Slices take all values of a repeated parameter, split by the separator if the `comma` or `pipe` option is set.
+// Booleans accept "1"/"0" as well as "true"/"false".
+func Unmarshal(values url.Values, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("UnmarshalQuery: expected pointer to struct, got %T", v)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		fieldType := rt.Field(i)
+
+		opts, ok := parseTag(fieldType.Tag.Get("query"))
+		if !ok || !fieldType.IsExported() {
 			continue
 		}
 
-		key := tagParts[0]
-		omitempty := false
-		for _, opt := range tagParts[1:] {
-			if opt == "omitempty" {
-				omitempty = true
+		if field.Kind() == reflect.Map {
+			if err := decodeMap(values, field, opts); err != nil {
+				return fmt.Errorf("UnmarshalQuery: field %s: %w", fieldType.Name, err)
 			}
+			continue
 		}
 
-		if omitempty && field.IsZero() {
+		strs, ok := values[opts.key]
+		if !ok {
 			continue
 		}
+		if err := decodeField(field, splitValues(strs, opts.separator), opts.layout); err != nil {
+			return fmt.Errorf("UnmarshalQuery: field %s: %w", fieldType.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// splitValues splits every value by separator, if there is one.
+func splitValues(strs []string, separator string) []string {
+	if separator == "" {
+		return strs
+	}
+
+	var split []string
+	for _, str := range strs {
+		split = append(split, strings.Split(str, separator)...)
+	}
+	return split
+}
+
+// decodeField sets a field to the given values, all of them for slices and the first one otherwise.
+func decodeField(v reflect.Value, strs []string, layout string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := decodeField(ptr.Elem(), strs, layout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice && !isText(v.Type()) {
+		s := reflect.MakeSlice(v.Type(), 0, len(strs))
+		for _, str := range strs {
+			// Empty values like "status_id=" do not add an element
+			if str == "" {
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := parseValue(elem, str, layout); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		v.Set(s)
+		return nil
+	}
 
-		var str string
-		switch field.Kind() {
-		case reflect.String:
-			str = field.String()
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			str = strconv.FormatInt(field.Int(), 10)
-		case reflect.Bool:
-			if field.Bool() {
-				str = "1"
-			} else {
-				str = "0"
+	if len(strs) == 0 {
+		return nil
+	}
+	return parseValue(v, strs[0], layout)
+}
+
+// decodeMap sets one map entry for every parameter whose name starts with the prefix given as key.
+func decodeMap(values url.Values, v reflect.Value, opts fieldOptions) error {
+	keys := slices.Sorted(func(yield func(string) bool) {
+		for key := range values {
+			if strings.HasPrefix(key, opts.key) && !yield(key) {
+				return
 			}
-		default:
-			continue // unsupported type
 		}
+	})
+	if len(keys) == 0 {
+		return nil
+	}
 
-		values.Add(key, str)
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
 	}
+	for _, key := range keys {
+		mapKey := reflect.New(v.Type().Key()).Elem()
+		if err := parseValue(mapKey, strings.TrimPrefix(key, opts.key), opts.layout); err != nil {
+			// Parameters like "cf_" followed by something other than an ID belong to another field
+			continue
+		}
+		mapValue := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeField(mapValue, splitValues(values[key], opts.separator), opts.layout); err != nil {
+			return err
+		}
+		v.SetMapIndex(mapKey, mapValue)
+	}
+	return nil
+}
 
-	return []byte(values.Encode()), nil
+// parseValue sets a single value from its text.
+func parseValue(v reflect.Value, str, layout string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := parseValue(ptr.Elem(), str, layout); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		t, err := time.ParseInLocation(layout, str, time.Local)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// isText reports whether values of type t are encoded as text instead of element by element.
+func isText(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
 }
@@ -1,9 +1,11 @@
 package querystring
 
 import (
+	"fmt"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // testStruct is a struct used for testing Marshal function.
@@ -74,17 +76,179 @@ func TestMarshal_OmitemptyZeroValue(t *testing.T) {
 // TestMarshal_UnsupportedType tests Marshal with a struct containing unsupported types.
 func TestMarshal_UnsupportedType(t *testing.T) {
 	type unsupported struct {
-		Data []string `query:"data"`
+		Data chan int `query:"data"`
 	}
 	input := unsupported{
-		Data: []string{"a", "b"},
+		Data: make(chan int),
+	}
+	if _, err := Marshal(input); err == nil {
+		t.Errorf("Expected error for unsupported type, got nil")
+	}
+}
+
+// filterStruct is a struct used for testing slices, maps, times and pointers.
+type filterStruct struct {
+	StatusID  []int          `query:"status_id,omitempty,pipe"`
+	Tags      []string       `query:"tag,omitempty"`
+	Columns   []string       `query:"c,omitempty,comma"`
+	From      time.Time      `query:"from,omitempty,layout=2006-01-02"`
+	Updated   time.Time      `query:"updated,omitempty"`
+	Limit     *int           `query:"limit,omitempty"`
+	Closed    *bool          `query:"closed,omitempty"`
+	Hours     float64        `query:"hours,omitempty"`
+	Level     level          `query:"level,omitempty"`
+	Custom    map[int]string `query:"cf_,omitempty"`
+	Unchanged string         `query:"unchanged,omitempty"`
+}
+
+// level implements encoding.TextMarshaler for testing.
+type level int
+
+// MarshalText returns the name of the level.
+func (l level) MarshalText() ([]byte, error) {
+	return []byte([]string{"low", "high"}[l]), nil
+}
+
+// UnmarshalText parses the name of the level.
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+// TestMarshal_Slices tests Marshal with repeated and joined slices.
+func TestMarshal_Slices(t *testing.T) {
+	input := filterStruct{
+		StatusID: []int{1, 2},
+		Tags:     []string{"a", "b"},
+		Columns:  []string{"subject", "status"},
 	}
 	got, err := Marshal(input)
 	if err != nil {
 		t.Fatalf("Marshal returned error: %v", err)
 	}
-	if string(got) != "" {
-		t.Errorf("Expected empty query string for unsupported type, got %s", string(got))
+	values, err := url.ParseQuery(string(got))
+	if err != nil {
+		t.Fatalf("Failed to parse query string: %v", err)
+	}
+	want := url.Values{
+		"status_id": {"1|2"},
+		"tag":       {"a", "b"},
+		"c":         {"subject,status"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected %v, got %v", want, values)
+	}
+}
+
+// TestMarshal_TimePointerTextMap tests Marshal with times, pointers, text marshalers and maps.
+func TestMarshal_TimePointerTextMap(t *testing.T) {
+	limit := 25
+	input := filterStruct{
+		From:    time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC),
+		Updated: time.Date(2025, 8, 12, 14, 30, 0, 0, time.UTC),
+		Limit:   &limit,
+		Hours:   1.5,
+		Level:   1,
+		Custom:  map[int]string{5: "x", 12: "", 88: "y"},
+	}
+	got, err := Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if want := "cf_5=x&cf_88=y&from=2025-08-11&hours=1.5&level=high&limit=25&updated=2025-08-12T14%3A30%3A00Z"; string(got) != want {
+		t.Errorf("Expected %s, got %s", want, string(got))
+	}
+}
+
+// TestUnmarshal_RoundTrip tests that Unmarshal decodes what Marshal encoded.
+func TestUnmarshal_RoundTrip(t *testing.T) {
+	limit := 25
+	closed := false
+	input := filterStruct{
+		StatusID: []int{1, 2},
+		Tags:     []string{"a", "b"},
+		Columns:  []string{"subject", "status"},
+		From:     time.Date(2025, 8, 11, 0, 0, 0, 0, time.Local),
+		Updated:  time.Date(2025, 8, 12, 14, 30, 0, 0, time.UTC),
+		Limit:    &limit,
+		Closed:   &closed,
+		Hours:    1.5,
+		Level:    1,
+		Custom:   map[int]string{5: "x", 88: "y"},
+	}
+	encoded, err := Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	values, err := url.ParseQuery(string(encoded))
+	if err != nil {
+		t.Fatalf("Failed to parse query string: %v", err)
+	}
+
+	got := filterStruct{Unchanged: "kept"}
+	if err := Unmarshal(values, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if got.Unchanged != "kept" {
+		t.Errorf("Expected fields without a parameter to be left untouched, got %q", got.Unchanged)
+	}
+	got.Unchanged = ""
+	if !got.Updated.Equal(input.Updated) {
+		t.Errorf("Expected updated %v, got %v", input.Updated, got.Updated)
+	}
+	got.Updated = input.Updated
+	if !reflect.DeepEqual(got, input) {
+		t.Errorf("Expected %+v, got %+v", input, got)
+	}
+}
+
+// TestUnmarshal_Values tests Unmarshal with booleans, repeated and empty parameters.
+func TestUnmarshal_Values(t *testing.T) {
+	values := url.Values{
+		"name":      {"Alice", "Bob"},
+		"age":       {"30"},
+		"active":    {"true"},
+		"status_id": {""},
+		"Skip":      {"ignored"},
+	}
+	var got struct {
+		testStruct
+		StatusID []int `query:"status_id,pipe"`
+	}
+	if err := Unmarshal(values, &got.testStruct); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if err := Unmarshal(values, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	want := testStruct{Name: "Alice", Age: 30, Active: true}
+	if got.testStruct != want {
+		t.Errorf("Expected %+v, got %+v", want, got.testStruct)
+	}
+	if got.StatusID == nil || len(got.StatusID) != 0 {
+		t.Errorf("Expected an empty status list, got %#v", got.StatusID)
+	}
+}
+
+// TestUnmarshal_Errors tests Unmarshal with invalid targets and values.
+func TestUnmarshal_Errors(t *testing.T) {
+	if err := Unmarshal(url.Values{}, testStruct{}); err == nil {
+		t.Errorf("Expected error for non-pointer target, got nil")
+	}
+	if err := Unmarshal(url.Values{"age": {"thirty"}}, &testStruct{}); err == nil {
+		t.Errorf("Expected error for invalid integer, got nil")
+	}
+	if err := Unmarshal(url.Values{"level": {"medium"}}, &filterStruct{}); err == nil {
+		t.Errorf("Expected error from UnmarshalText, got nil")
 	}
 }
 
@@ -0,0 +1,288 @@
+// Package outbox keeps time entries that could not be sent to Redmine until it is reachable again.
+// The outbox is persisted to disk so queued entries survive restarts and crashes.
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+var (
+	// ErrDuplicate is returned when a time entry is queued that is already waiting in the outbox.
+	ErrDuplicate = errors.New("the time entry is already queued")
+
+	// ErrNotQueued is returned when an entry is changed that is no longer in the outbox, e.g. because it has been sent meanwhile.
+	ErrNotQueued = errors.New("the time entry is no longer queued")
+
+	// ErrBusy is returned when entries are sent while another process or view is sending them.
+	ErrBusy = errors.New("the outbox is being sent by another process")
+)
+
+// Entry is a time entry waiting in the outbox.
+type Entry struct {
+	ID        string                       `json:"id"`                // ID identifies the entry within the outbox
+	Profile   string                       `json:"profile,omitempty"` // Profile is the Redmine instance the entry belongs to
+	Title     string                       `json:"title,omitempty"`   // Title is the issue title shown in the outbox
+	Params    models.CreateTimeEntryParams `json:"params"`            // Params is the time entry to create
+	QueuedAt  time.Time                    `json:"queued_at"`         // QueuedAt is the moment the entry was queued
+	Attempts  int                          `json:"attempts"`          // Attempts counts the attempts to send the entry
+	LastError string                       `json:"last_error,omitempty"`
+
+	// FirstAttempt is the moment the entry was sent for the first time, zero if it has never been sent.
+	FirstAttempt time.Time `json:"first_attempt,omitzero"`
+
+	// Attempted holds every version of the entry that has been sent, as a request may have reached Redmine
+	// although it failed.
+	Attempted []models.CreateTimeEntryParams `json:"attempted,omitempty"`
+
+	// Held reports whether Redmine rejected the entry, held entries are only sent when retried explicitly.
+	Held bool `json:"held,omitempty"`
+}
+
+// Queueable reports whether a failure to send a time entry may go away by sending it again later.
+// Queueable is true if Redmine is unreachable or failed with a server error, but false if Redmine rejected the entry.
+func Queueable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if redmine.IsUnreachable(err) {
+		return true
+	}
+
+	var apiErr *redmine.APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests)
+}
+
+// Store persists the outbox in a JSON file.
+// Store is safe for concurrent use. Changes and sending entries are additionally guarded against other processes by lock files.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	now  func() time.Time
+}
+
+// NewStore creates a Store that keeps the outbox in the file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path, now: time.Now}
+}
+
+// DefaultPath returns the location of the outbox file below XDG_STATE_HOME.
+// DefaultPath falls back to ~/.local/state if XDG_STATE_HOME is not set.
+func DefaultPath() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		stateHome = filepath.Join(homeDir, ".local", "state")
+	}
+
+	return filepath.Join(stateHome, "rmt", "outbox.json"), nil
+}
+
+// List returns the entries queued for profile in the order they were queued.
+func (s *Store) List(profile string) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(entries, func(e Entry) bool { return e.Profile != profile }), nil
+}
+
+// Add queues e and returns it with its ID.
+// Add returns ErrDuplicate if the same time entry is already queued for the profile of e.
+func (s *Store) Add(e Entry) (*Entry, error) {
+	err := s.write(func(entries []Entry) ([]Entry, error) {
+		if slices.ContainsFunc(entries, func(q Entry) bool { return q.Profile == e.Profile && q.Params == e.Params }) {
+			return nil, fmt.Errorf("%w for #%d on %s", ErrDuplicate, e.Params.IssueID, e.Params.SpentOn)
+		}
+
+		var err error
+		if e.ID, err = newID(); err != nil {
+			return nil, err
+		}
+		if e.QueuedAt.IsZero() {
+			e.QueuedAt = s.now()
+		}
+		return append(entries, e), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Edit replaces the time entry queued under id by params and releases it if it was held.
+// Edit returns ErrDuplicate if the changed time entry equals another queued one.
+func (s *Store) Edit(id string, params models.CreateTimeEntryParams) (*Entry, error) {
+	var edited Entry
+	err := s.write(func(entries []Entry) ([]Entry, error) {
+		i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+		if i < 0 {
+			return nil, ErrNotQueued
+		}
+		if slices.ContainsFunc(entries, func(q Entry) bool {
+			return q.ID != id && q.Profile == entries[i].Profile && q.Params == params
+		}) {
+			return nil, fmt.Errorf("%w for #%d on %s", ErrDuplicate, params.IssueID, params.SpentOn)
+		}
+
+		entries[i].Params = params
+		entries[i].Held = false
+		entries[i].LastError = ""
+		edited = entries[i]
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &edited, nil
+}
+
+// Remove discards the entry queued under id.
+// Remove returns no error if the entry is not queued.
+func (s *Store) Remove(id string) error {
+	return s.write(func(entries []Entry) ([]Entry, error) {
+		return slices.DeleteFunc(entries, func(e Entry) bool { return e.ID == id }), nil
+	})
+}
+
+// get returns the entry queued under id.
+func (s *Store) get(id string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+	if i < 0 {
+		return nil, ErrNotQueued
+	}
+	return &entries[i], nil
+}
+
+// modify applies change to the entry queued under id as it is stored now.
+// modify returns ErrNotQueued if the entry has been sent or discarded meanwhile.
+func (s *Store) modify(id string, change func(e *Entry) error) error {
+	return s.write(func(entries []Entry) ([]Entry, error) {
+		i := slices.IndexFunc(entries, func(e Entry) bool { return e.ID == id })
+		if i < 0 {
+			return nil, ErrNotQueued
+		}
+		if err := change(&entries[i]); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	})
+}
+
+// write reads the queued entries, applies change and saves the result.
+// write holds the mutex and the write lock file, so neither another view nor another process, e.g. rmt sync,
+// changes the outbox between reading and saving it. Nothing is saved if change fails.
+func (s *Store) write(change func(entries []Entry) ([]Entry, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.writeLock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	if entries, err = change(entries); err != nil {
+		return err
+	}
+	return s.save(entries)
+}
+
+// load reads the queued entries from disk.
+func (s *Store) load() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox file: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox file: %w", err)
+	}
+
+	return entries, nil
+}
+
+// save writes the entries to disk.
+// save writes to a temporary file first, so a crash never leaves a truncated outbox behind.
+func (s *Store) save(entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, ".outbox-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create outbox file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+
+	return nil
+}
+
+// newID returns a random ID for a queued entry.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate outbox entry ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
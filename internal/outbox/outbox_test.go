@@ -0,0 +1,346 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// errUnreachable is the error the REST client returns while the network is down
+var errUnreachable = &url.Error{Op: "Post", URL: "https://redmine.example.com/time_entries.json", Err: errors.New("connection refused")}
+
+// fakeSender records the created time entries and fails with err if set
+type fakeSender struct {
+	err     error
+	created []models.CreateTimeEntryParams
+	entries []models.TimeEntry
+	lists   int
+
+	onCreate func() // onCreate runs before a time entry is created, e.g. to edit the outbox meanwhile
+}
+
+// CreateTimeEntry records params unless err is set
+func (f *fakeSender) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	if f.onCreate != nil {
+		f.onCreate()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created = append(f.created, params)
+	return &models.TimeEntry{ID: 100 + len(f.created)}, nil
+}
+
+// ListTimeEntries returns the configured entries of the filtered issue
+func (f *fakeSender) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	f.lists++
+	var entries []models.TimeEntry
+	for _, entry := range f.entries {
+		if entry.Issue.ID == filter.IssueID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// newTestStore creates a Store in a temporary directory at a fixed point in time
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	store := NewStore(filepath.Join(t.TempDir(), "rmt", "outbox.json"))
+	store.now = func() time.Time { return time.Date(2025, 8, 13, 10, 0, 0, 0, time.UTC) }
+	return store
+}
+
+// params returns the parameters of a time entry on the given issue
+func params(issueID int, hours float64) models.CreateTimeEntryParams {
+	return models.CreateTimeEntryParams{IssueID: issueID, Hours: hours, ActivityID: 9, Comments: "Fix login", SpentOn: "2025-08-13"}
+}
+
+// TestStore_AddListRemove verifies that queued entries are persisted per profile and can be discarded
+func TestStore_AddListRemove(t *testing.T) {
+	store := newTestStore(t)
+
+	first, err := store.Add(Entry{Profile: "work", Title: "Fix login", Params: params(123, 1.5)})
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if _, err := store.Add(Entry{Profile: "home", Params: params(123, 1.5)}); err != nil {
+		t.Fatalf("Add returned error for another profile: %v", err)
+	}
+	if _, err := store.Add(Entry{Profile: "work", Params: params(123, 1.5)}); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("Add error = %v, want ErrDuplicate", err)
+	}
+
+	// A new store reads the outbox from disk, as it would after a restart
+	entries, err := NewStore(store.path).List("work")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != first.ID || entries[0].Title != "Fix login" || entries[0].Params != params(123, 1.5) {
+		t.Fatalf("List() = %+v, want the entry queued for work", entries)
+	}
+
+	if err := store.Remove(first.ID); err != nil {
+		t.Fatalf("Remove returned error: %v", err)
+	}
+	if entries, _ := store.List("work"); len(entries) != 0 {
+		t.Errorf("List() = %+v after Remove, want no entries", entries)
+	}
+	if entries, _ := store.List("home"); len(entries) != 1 {
+		t.Errorf("List(home) = %+v, want the entry of the other profile to stay", entries)
+	}
+}
+
+// TestStore_Flush verifies that queued entries are sent and removed, and that sending stops while Redmine is unreachable
+func TestStore_Flush(t *testing.T) {
+	store := newTestStore(t)
+	sender := &fakeSender{err: errUnreachable}
+	for _, issueID := range []int{1, 2} {
+		if _, err := store.Add(Entry{Params: params(issueID, 1)}); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+
+	results, err := store.Flush(context.Background(), sender, "")
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Outcome != Failed || !redmine.IsUnreachable(results[0].Err) {
+		t.Fatalf("Flush() = %+v, want a single unreachable failure", results)
+	}
+	entries, _ := store.List("")
+	if len(entries) != 2 || entries[0].Attempts != 1 || entries[0].LastError == "" || entries[0].Held || entries[1].Attempts != 0 {
+		t.Fatalf("List() = %+v, want the first entry to record the failed attempt", entries)
+	}
+
+	sender.err = nil
+	results, err = store.Flush(context.Background(), sender, "")
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(results) != 2 || results[0].Outcome != Sent || results[1].Outcome != Sent || len(sender.created) != 2 {
+		t.Fatalf("Flush() = %+v, created %v, want both entries sent", results, sender.created)
+	}
+	if entries, _ := store.List(""); len(entries) != 0 {
+		t.Errorf("List() = %+v, want an empty outbox", entries)
+	}
+}
+
+// TestStore_FlushEditedMeanwhile verifies that an entry edited while earlier entries are sent is sent in its edited version
+func TestStore_FlushEditedMeanwhile(t *testing.T) {
+	store := newTestStore(t)
+	if _, err := store.Add(Entry{Params: params(1, 1)}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	second, err := store.Add(Entry{Params: params(2, 1)})
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	sender := &fakeSender{}
+	sender.onCreate = func() {
+		sender.onCreate = nil
+		if _, err := store.Edit(second.ID, params(2, 3)); err != nil {
+			t.Errorf("Edit returned error: %v", err)
+		}
+	}
+
+	if _, err := store.Flush(context.Background(), sender, ""); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(sender.created) != 2 || sender.created[1] != params(2, 3) {
+		t.Errorf("created %+v, want the edited version of the second entry", sender.created)
+	}
+	if entries, _ := store.List(""); len(entries) != 0 {
+		t.Errorf("List() = %+v, want an empty outbox", entries)
+	}
+}
+
+// TestStore_WriteLocked verifies that changes wait for another process writing the outbox
+func TestStore_WriteLocked(t *testing.T) {
+	store := newTestStore(t)
+	lockPath := store.path + ".write.lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o700); err != nil {
+		t.Fatalf("failed to create outbox directory: %v", err)
+	}
+	if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := store.Add(Entry{Params: params(123, 1.5)})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Add returned %v while the outbox was locked", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	os.Remove(lockPath)
+	if err := <-done; err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if entries, _ := store.List(""); len(entries) != 1 {
+		t.Errorf("List() = %+v, want the entry queued once the lock was released", entries)
+	}
+}
+
+// TestStore_FlushCreatedByEarlierAttempt verifies that an entry is not sent again if a failed attempt has created it
+func TestStore_FlushCreatedByEarlierAttempt(t *testing.T) {
+	store := newTestStore(t)
+	attempted := store.now().Add(-time.Hour)
+	if _, err := store.Add(Entry{Params: params(123, 1.5), Attempts: 1, FirstAttempt: attempted, Attempted: []models.CreateTimeEntryParams{params(123, 1.5)}}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	// The request timed out after Redmine had created the time entry
+	var created models.TimeEntry
+	created.ID = 42
+	created.Issue.ID = 123
+	created.Activity.ID = 9
+	created.Hours = 1.5
+	created.Comments = "Fix login"
+	created.SpentOn = "2025-08-13"
+	created.CreatedOn = attempted.Add(time.Second)
+	sender := &fakeSender{entries: []models.TimeEntry{created}}
+
+	results, err := store.Flush(context.Background(), sender, "")
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Outcome != AlreadySent || results[0].TimeEntryID != 42 {
+		t.Fatalf("Flush() = %+v, want the entry to be recognized as sent", results)
+	}
+	if len(sender.created) != 0 {
+		t.Errorf("created %v, want no time entry to be sent again", sender.created)
+	}
+	if entries, _ := store.List(""); len(entries) != 0 {
+		t.Errorf("List() = %+v, want an empty outbox", entries)
+	}
+}
+
+// TestStore_EditAfterAttempt verifies that an edited entry is held instead of sent if its earlier version has been created
+func TestStore_EditAfterAttempt(t *testing.T) {
+	store := newTestStore(t)
+	sender := &fakeSender{err: &redmine.APIError{StatusCode: 504, Method: "POST", Endpoint: "/time_entries.json"}}
+	queued, err := store.Add(Entry{Params: params(123, 1.5)})
+	if err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+	if _, err := store.Flush(context.Background(), sender, ""); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	// The gateway timed out, but Redmine created the time entry nevertheless
+	var created models.TimeEntry
+	created.ID = 42
+	created.Issue.ID = 123
+	created.Activity.ID = 9
+	created.Hours = 1.5
+	created.Comments = "Fix login"
+	created.SpentOn = "2025-08-13"
+	created.CreatedOn = store.now()
+	sender.err = nil
+	sender.entries = []models.TimeEntry{created}
+
+	if _, err := store.Edit(queued.ID, params(123, 2)); err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	result, err := store.Send(context.Background(), sender, queued.ID)
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if result.Outcome != Failed || !errors.Is(result.Err, ErrConflict) || len(sender.created) != 0 {
+		t.Fatalf("Send() = %+v, created %v, want a conflict without sending", result, sender.created)
+	}
+
+	entries, _ := store.List("")
+	if len(entries) != 1 || !entries[0].Held {
+		t.Fatalf("List() = %+v, want the entry to be held", entries)
+	}
+	if results, _ := store.Flush(context.Background(), sender, ""); len(results) != 0 {
+		t.Errorf("Flush() = %+v, want held entries to be skipped", results)
+	}
+}
+
+// TestStore_FlushRejected verifies that entries Redmine rejects are held
+func TestStore_FlushRejected(t *testing.T) {
+	store := newTestStore(t)
+	sender := &fakeSender{err: &redmine.APIError{StatusCode: 422, Method: "POST", Endpoint: "/time_entries.json", Messages: []string{"Activity cannot be blank"}}}
+	if _, err := store.Add(Entry{Params: params(123, 1.5)}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	results, err := store.Flush(context.Background(), sender, "")
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(results) != 1 || !redmine.IsValidation(results[0].Err) {
+		t.Fatalf("Flush() = %+v, want the validation error", results)
+	}
+	if entries, _ := store.List(""); len(entries) != 1 || !entries[0].Held {
+		t.Errorf("List() = %+v, want the rejected entry to be held", entries)
+	}
+}
+
+// TestStore_FlushLocked verifies that the outbox is not sent twice at the same time and stale locks are removed
+func TestStore_FlushLocked(t *testing.T) {
+	store := newTestStore(t)
+	if _, err := store.Add(Entry{Params: params(123, 1.5)}); err != nil {
+		t.Fatalf("Add returned error: %v", err)
+	}
+
+	lockPath := store.path + ".lock"
+	if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	if err := os.Chtimes(lockPath, store.now(), store.now()); err != nil {
+		t.Fatalf("failed to touch lock file: %v", err)
+	}
+
+	sender := &fakeSender{}
+	if _, err := store.Flush(context.Background(), sender, ""); !errors.Is(err, ErrBusy) {
+		t.Fatalf("Flush error = %v, want ErrBusy", err)
+	}
+
+	stale := store.now().Add(-time.Hour)
+	if err := os.Chtimes(lockPath, stale, stale); err != nil {
+		t.Fatalf("failed to age lock file: %v", err)
+	}
+	if results, err := store.Flush(context.Background(), sender, ""); err != nil || len(results) != 1 || results[0].Outcome != Sent {
+		t.Fatalf("Flush() = %+v, %v, want the entry sent after removing the stale lock", results, err)
+	}
+	if _, err := os.Stat(lockPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected lock file to be removed, stat returned %v", err)
+	}
+}
+
+// TestQueueable verifies which failures leave an entry queued for later
+func TestQueueable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errUnreachable, true},
+		{&redmine.APIError{StatusCode: 503}, true},
+		{&redmine.APIError{StatusCode: 429}, true},
+		{&redmine.APIError{StatusCode: 422}, false},
+		{context.Canceled, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := Queueable(tt.err); got != tt.want {
+			t.Errorf("Queueable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
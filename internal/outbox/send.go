@@ -0,0 +1,293 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+const (
+	// clockSkew is the difference between the local clock and Redmine's that is tolerated when looking for
+	// time entries created by earlier attempts.
+	clockSkew = 5 * time.Minute
+
+	// staleLock is the age after which a lock file is considered left behind by a crashed process.
+	staleLock = 10 * time.Minute
+
+	// writeLockWait is the time a change waits for another process to finish writing the outbox.
+	// staleWriteLock is the age after which a write lock file is considered left behind, writing takes milliseconds.
+	writeLockWait  = 5 * time.Second
+	staleWriteLock = 30 * time.Second
+)
+
+// ErrConflict is returned when an earlier version of an edited entry has reached Redmine.
+// Sending the edited version would log the time twice, so the entry is held.
+var ErrConflict = errors.New("an earlier version of the time entry has been created in Redmine")
+
+// errChanged is returned when an entry is edited between reading and sending it.
+var errChanged = errors.New("the time entry was changed while it was being sent, it is sent with the next attempt")
+
+// Sender creates time entries in Redmine and lists the ones that have been created.
+type Sender interface {
+	CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error)
+	ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error)
+}
+
+// Outcome describes what happened to an entry when it was sent.
+type Outcome int
+
+const (
+	Sent        Outcome = iota // Sent reports that the time entry has been created in Redmine
+	AlreadySent                // AlreadySent reports that an earlier attempt had created the time entry, it was not sent again
+	Failed                     // Failed reports that the entry stays queued
+)
+
+// String returns a short description of the outcome.
+func (o Outcome) String() string {
+	switch o {
+	case Sent:
+		return "sent"
+	case AlreadySent:
+		return "already sent"
+	default:
+		return "failed"
+	}
+}
+
+// Result describes sending a single entry.
+type Result struct {
+	Entry       Entry   // Entry is the entry as it was sent
+	Outcome     Outcome // Outcome tells whether the entry has left the outbox
+	TimeEntryID int     // TimeEntryID is the ID of the time entry in Redmine, zero if the entry failed
+	Err         error   // Err is the reason the entry failed
+}
+
+// Flush sends the entries queued for profile that are not held, oldest first.
+// Flush stops at the first entry failing because Redmine is unreachable, as the others would fail as well.
+// Flush returns ErrBusy if the entries are being sent by someone else.
+func (s *Store) Flush(ctx context.Context, sender Sender, profile string) ([]Result, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := s.List(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, listed := range entries {
+		// Earlier entries take a while to send, meanwhile this one may have been edited, sent or discarded
+		e, err := s.get(listed.ID)
+		if errors.Is(err, ErrNotQueued) {
+			continue
+		}
+		if err != nil {
+			return results, err
+		}
+		if e.Held {
+			continue
+		}
+
+		result := s.send(ctx, sender, *e)
+		results = append(results, result)
+		if ctx.Err() != nil || redmine.IsUnreachable(result.Err) {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// Send sends the entry queued under id, even if it is held.
+// Send returns ErrBusy if the entries are being sent by someone else.
+func (s *Store) Send(ctx context.Context, sender Sender, id string) (Result, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return Result{}, err
+	}
+	defer unlock()
+
+	// The entry may have been changed or sent since the caller listed it
+	e, err := s.get(id)
+	if err != nil {
+		return Result{}, err
+	}
+	e.Held = false
+
+	return s.send(ctx, sender, *e), nil
+}
+
+// send sends e unless an earlier attempt has created it already.
+// send records the attempt before sending, so a crash while sending cannot lead to sending the entry twice.
+// Changes are applied to the entry as it is stored, so an edit made while sending is never overwritten.
+func (s *Store) send(ctx context.Context, sender Sender, e Entry) Result {
+	if len(e.Attempted) > 0 {
+		created, version, err := findCreated(ctx, sender, e)
+		switch {
+		case err != nil:
+			return s.fail(e, fmt.Errorf("failed to look for time entries created by earlier attempts: %w", err), false)
+		case created != nil && version == e.Params:
+			if err := s.removeSent(e); err != nil {
+				return Result{Entry: e, Outcome: Failed, Err: err}
+			}
+			return Result{Entry: e, Outcome: AlreadySent, TimeEntryID: created.ID}
+		case created != nil:
+			return s.fail(e, fmt.Errorf("%w as time entry #%d, change that one or discard this entry", ErrConflict, created.ID), true)
+		}
+	}
+
+	err := s.modify(e.ID, func(q *Entry) error {
+		if q.Params != e.Params {
+			return errChanged
+		}
+		q.Attempts++
+		if q.FirstAttempt.IsZero() {
+			q.FirstAttempt = s.now()
+		}
+		if !slices.Contains(q.Attempted, q.Params) {
+			q.Attempted = append(q.Attempted, q.Params)
+		}
+		e = *q
+		return nil
+	})
+	if err != nil {
+		return Result{Entry: e, Outcome: Failed, Err: err}
+	}
+
+	created, err := sender.CreateTimeEntry(ctx, e.Params)
+	if err != nil {
+		// Entries Redmine rejected would fail again until they are changed
+		return s.fail(e, err, !Queueable(err) && !errors.Is(err, context.Canceled))
+	}
+
+	if err := s.removeSent(e); err != nil {
+		return Result{Entry: e, Outcome: Failed, Err: err}
+	}
+	return Result{Entry: e, Outcome: Sent, TimeEntryID: created.ID}
+}
+
+// fail records err as the reason e is still queued and holds e if held is set.
+// An entry edited meanwhile keeps the state the edit gave it, as the failure concerns its earlier version.
+func (s *Store) fail(e Entry, err error, held bool) Result {
+	e.LastError = err.Error()
+	e.Held = held
+	updateErr := s.modify(e.ID, func(q *Entry) error {
+		if q.Params == e.Params {
+			q.LastError = e.LastError
+			q.Held = held
+		}
+		return nil
+	})
+	if updateErr != nil && !errors.Is(updateErr, ErrNotQueued) {
+		err = errors.Join(err, updateErr)
+	}
+	return Result{Entry: e, Outcome: Failed, Err: err}
+}
+
+// removeSent removes e from the outbox once it has been created in Redmine.
+// An entry edited while it was sent stays queued, sending it again finds the created version and holds it as a conflict.
+func (s *Store) removeSent(e Entry) error {
+	return s.write(func(entries []Entry) ([]Entry, error) {
+		return slices.DeleteFunc(entries, func(q Entry) bool { return q.ID == e.ID && q.Params == e.Params }), nil
+	})
+}
+
+// findCreated looks for a time entry in Redmine that was created by an earlier attempt to send e.
+// findCreated returns the time entry along with the version of e it matches, nil if there is none.
+func findCreated(ctx context.Context, lister Sender, e Entry) (*models.TimeEntry, models.CreateTimeEntryParams, error) {
+	since := e.FirstAttempt.Add(-clockSkew)
+	listed := make(map[models.TimeEntryFilter][]models.TimeEntry)
+
+	for _, version := range e.Attempted {
+		filter := models.TimeEntryFilter{UserID: "me", IssueID: version.IssueID, From: version.SpentOn, To: version.SpentOn}
+		entries, ok := listed[filter]
+		if !ok {
+			var err error
+			if entries, err = lister.ListTimeEntries(ctx, filter); err != nil {
+				return nil, models.CreateTimeEntryParams{}, err
+			}
+			listed[filter] = entries
+		}
+
+		for _, entry := range entries {
+			if !entry.CreatedOn.Before(since) && matches(entry, version) {
+				return &entry, version, nil
+			}
+		}
+	}
+
+	return nil, models.CreateTimeEntryParams{}, nil
+}
+
+// matches reports whether the time entry has been created from params.
+// An unset activity in params stands for Redmine's default activity and matches every activity.
+func matches(entry models.TimeEntry, params models.CreateTimeEntryParams) bool {
+	return entry.Issue.ID == params.IssueID &&
+		entry.SpentOn == params.SpentOn &&
+		(params.ActivityID == 0 || entry.Activity.ID == params.ActivityID) &&
+		math.Abs(entry.Hours-params.Hours) < 0.005 &&
+		strings.TrimSpace(entry.Comments) == strings.TrimSpace(params.Comments)
+}
+
+// lock acquires the lock file guarding the outbox against being sent twice at the same time.
+// lock removes lock files left behind by crashed processes and returns ErrBusy while the lock is held.
+func (s *Store) lock() (func(), error) {
+	return s.acquire(s.path+".lock", staleLock)
+}
+
+// writeLock acquires the lock file guarding the outbox file against concurrent changes by other processes.
+// writeLock waits up to writeLockWait for another process to finish its change.
+func (s *Store) writeLock() (func(), error) {
+	deadline := time.Now().Add(writeLockWait)
+	for {
+		unlock, err := s.acquire(s.path+".write.lock", staleWriteLock)
+		if !errors.Is(err, ErrBusy) {
+			return unlock, err
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("timed out waiting for another process to change the outbox")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// acquire creates the lock file at path, removing it first if it is older than stale.
+// acquire returns ErrBusy while another process holds the lock.
+func (s *Store) acquire(path string, stale time.Duration) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	for range 2 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock outbox: %w", err)
+		}
+
+		// A lock released meanwhile has no file to stat, so it is simply acquired again
+		if info, err := os.Stat(path); err == nil {
+			if s.now().Sub(info.ModTime()) < stale {
+				return nil, ErrBusy
+			}
+			os.Remove(path)
+		}
+	}
+
+	return nil, ErrBusy
+}
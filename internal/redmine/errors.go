@@ -0,0 +1,98 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIError is returned when Redmine answers a request with an unexpected status code.
+// APIError carries the validation messages Redmine sends along with 422 responses.
+type APIError struct {
+	StatusCode int      // StatusCode is the HTTP status code of the response
+	Method     string   // Method is the HTTP method of the failed request
+	Endpoint   string   // Endpoint is the path of the failed request without query parameters
+	Messages   []string // Messages contains the error messages parsed from the response body
+}
+
+// Error returns the error message for APIError, including Redmine's messages if there are any.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s failed with status: %d", e.Method, e.Endpoint, e.StatusCode)
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, ", ")
+	}
+	return msg
+}
+
+// newAPIError creates an APIError from resp and parses the error messages contained in its body.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		Endpoint:   resp.Request.URL.Path,
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return apiErr
+	}
+
+	// Redmine reports errors either as a list of strings or as a single string
+	var payload struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || len(payload.Errors) == 0 {
+		return apiErr
+	}
+
+	var messages []string
+	if err := json.Unmarshal(payload.Errors, &messages); err == nil {
+		apiErr.Messages = messages
+		return apiErr
+	}
+
+	var message string
+	if err := json.Unmarshal(payload.Errors, &message); err == nil && message != "" {
+		apiErr.Messages = []string{message}
+	}
+
+	return apiErr
+}
+
+// hasStatus reports whether err is an APIError with the given status code.
+func hasStatus(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// IsNotFound reports whether err is caused by Redmine answering 404 Not Found.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is caused by a missing or invalid API key.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is caused by the user lacking permission for the request.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsValidation reports whether err is caused by Redmine rejecting the submitted data.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsUnreachable reports whether err is caused by Redmine not answering at all, e.g. because the network is down.
+// IsUnreachable is false for cancelled requests.
+func IsUnreachable(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,106 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRestClient_CreateTimeEntryValidationError tests that validation messages of a 422 response are exposed.
+func TestRestClient_CreateTimeEntryValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": ["Activity cannot be blank", "Hours is invalid"]}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	_, err := client.CreateTimeEntry(context.Background(), models.CreateTimeEntryParams{IssueID: 1})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !IsValidation(err) {
+		t.Errorf("expected IsValidation to be true for '%s'", err.Error())
+	}
+	if IsNotFound(err) || IsUnauthorized(err) {
+		t.Errorf("expected IsNotFound and IsUnauthorized to be false for '%s'", err.Error())
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected error to be an APIError, got %T", err)
+	}
+	if apiErr.Method != http.MethodPost {
+		t.Errorf("expected method POST, got '%s'", apiErr.Method)
+	}
+	if apiErr.Endpoint != "/time_entries.json" {
+		t.Errorf("expected endpoint '/time_entries.json', got '%s'", apiErr.Endpoint)
+	}
+	if len(apiErr.Messages) != 2 || apiErr.Messages[0] != "Activity cannot be blank" {
+		t.Errorf("expected validation messages, got %v", apiErr.Messages)
+	}
+	want := "POST /time_entries.json failed with status: 422: Activity cannot be blank, Hours is invalid"
+	if err.Error() != want {
+		t.Errorf("expected error '%s', got '%s'", want, err.Error())
+	}
+}
+
+// TestRestClient_GetIssueNotFound tests that a 404 response without body is reported as not found.
+func TestRestClient_GetIssueNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	_, err := client.GetIssue(context.Background(), 42)
+	if !IsNotFound(err) {
+		t.Fatalf("expected IsNotFound to be true, got %v", err)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && len(apiErr.Messages) != 0 {
+		t.Errorf("expected no messages, got %v", apiErr.Messages)
+	}
+}
+
+// TestIsUnauthorized tests that the sentinel checks see through wrapped errors.
+func TestIsUnauthorized(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusUnauthorized})
+	if !IsUnauthorized(err) {
+		t.Error("expected IsUnauthorized to be true for wrapped APIError")
+	}
+	if IsUnauthorized(errors.New("plain error")) {
+		t.Error("expected IsUnauthorized to be false for plain error")
+	}
+}
+
+// TestIsUnreachable tests that only requests Redmine never answered are reported as unreachable.
+func TestIsUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(1, 0, 0))
+
+	_, err := client.GetIssue(context.Background(), 42)
+	if !IsUnreachable(err) {
+		t.Errorf("expected IsUnreachable to be true for a closed server, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GetIssue(ctx, 42); IsUnreachable(err) {
+		t.Errorf("expected IsUnreachable to be false for a cancelled request, got %v", err)
+	}
+	if IsUnreachable(&APIError{StatusCode: http.StatusBadGateway}) {
+		t.Error("expected IsUnreachable to be false for an answer of Redmine")
+	}
+}
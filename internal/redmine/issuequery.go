@@ -0,0 +1,367 @@
+package redmine
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// bracketReplacer unescapes the brackets of the parameter names, which Redmine accepts as they are.
+var bracketReplacer = strings.NewReplacer("%5B", "[", "%5D", "]")
+
+// Operator compares the field of an issue query filter with its values.
+type Operator string
+
+// Operators of the Redmine issue query filters, as listed by Query.operators in Redmine.
+const (
+	OpEquals          Operator = "="    // OpEquals matches any of the values
+	OpNotEquals       Operator = "!"    // OpNotEquals matches none of the values
+	OpOpen            Operator = "o"    // OpOpen matches open issues, for status_id only
+	OpClosed          Operator = "c"    // OpClosed matches closed issues, for status_id only
+	OpNone            Operator = "!*"   // OpNone matches fields that are not set
+	OpAny             Operator = "*"    // OpAny matches any value that is set
+	OpGreaterOrEqual  Operator = ">="   // OpGreaterOrEqual matches values greater than or equal to the value
+	OpLessOrEqual     Operator = "<="   // OpLessOrEqual matches values less than or equal to the value
+	OpBetween         Operator = "><"   // OpBetween matches values between the two values
+	OpInLessThanDays  Operator = "<t+"  // OpInLessThanDays matches dates less than the number of days ahead
+	OpInMoreThanDays  Operator = ">t+"  // OpInMoreThanDays matches dates more than the number of days ahead
+	OpNextNumDays     Operator = "><t+" // OpNextNumDays matches dates within the next number of days
+	OpInDays          Operator = "t+"   // OpInDays matches dates exactly the number of days ahead
+	OpTomorrow        Operator = "nd"   // OpTomorrow matches dates of tomorrow
+	OpToday           Operator = "t"    // OpToday matches dates of today
+	OpYesterday       Operator = "ld"   // OpYesterday matches dates of yesterday
+	OpNextWeek        Operator = "nw"   // OpNextWeek matches dates of the next week
+	OpThisWeek        Operator = "w"    // OpThisWeek matches dates of the current week
+	OpLastWeek        Operator = "lw"   // OpLastWeek matches dates of the previous week
+	OpLastTwoWeeks    Operator = "l2w"  // OpLastTwoWeeks matches dates of the previous and the current week
+	OpNextMonth       Operator = "nm"   // OpNextMonth matches dates of the next month
+	OpThisMonth       Operator = "m"    // OpThisMonth matches dates of the current month
+	OpLastMonth       Operator = "lm"   // OpLastMonth matches dates of the previous month
+	OpThisYear        Operator = "y"    // OpThisYear matches dates of the current year
+	OpLastNumDays     Operator = ">t-"  // OpLastNumDays matches dates less than the number of days ago
+	OpMoreThanDaysAgo Operator = "<t-"  // OpMoreThanDaysAgo matches dates more than the number of days ago
+	OpPastNumDays     Operator = "><t-" // OpPastNumDays matches dates within the past number of days
+	OpDaysAgo         Operator = "t-"   // OpDaysAgo matches dates exactly the number of days ago
+	OpContains        Operator = "~"    // OpContains matches text containing the value
+	OpNotContains     Operator = "!~"   // OpNotContains matches text not containing the value
+	OpContainsAny     Operator = "*~"   // OpContainsAny matches text containing any of the words of the value
+	OpStartsWith      Operator = "^"    // OpStartsWith matches text starting with the value
+	OpEndsWith        Operator = "$"    // OpEndsWith matches text ending with the value
+	OpAnyInProject    Operator = "=p"   // OpAnyInProject matches relations to any issue of the project
+	OpAnyNotInProject Operator = "=!p"  // OpAnyNotInProject matches relations to any issue outside of the project
+	OpNoneInProject   Operator = "!p"   // OpNoneInProject matches issues without relations to the project
+	OpAnyOpen         Operator = "*o"   // OpAnyOpen matches relations to any open issue
+	OpNoneOpen        Operator = "!o"   // OpNoneOpen matches issues without relations to open issues
+	OpHasBeen         Operator = "ev"   // OpHasBeen matches values the field has had at any time
+	OpHasNeverBeen    Operator = "!ev"  // OpHasNeverBeen matches values the field has never had
+	OpChangedFrom     Operator = "cf"   // OpChangedFrom matches values the field has been changed from
+)
+
+// Arity is the number of values an operator takes.
+type Arity int
+
+// Arities of the operators.
+const (
+	ArityNone Arity = iota // ArityNone operators take no value, e.g. "o"
+	ArityOne               // ArityOne operators take a single value, e.g. "~"
+	ArityTwo               // ArityTwo operators take exactly two values, e.g. "><"
+	ArityMany              // ArityMany operators take one or more values, e.g. "="
+)
+
+// operatorInfo describes an operator.
+type operatorInfo struct {
+	label string
+	arity Arity
+}
+
+// operators describes all known operators, the labels follow the English Redmine web interface.
+var operators = map[Operator]operatorInfo{
+	OpEquals:          {"is", ArityMany},
+	OpNotEquals:       {"is not", ArityMany},
+	OpOpen:            {"open", ArityNone},
+	OpClosed:          {"closed", ArityNone},
+	OpNone:            {"none", ArityNone},
+	OpAny:             {"any", ArityNone},
+	OpGreaterOrEqual:  {">=", ArityOne},
+	OpLessOrEqual:     {"<=", ArityOne},
+	OpBetween:         {"between", ArityTwo},
+	OpInLessThanDays:  {"in less than (days)", ArityOne},
+	OpInMoreThanDays:  {"in more than (days)", ArityOne},
+	OpNextNumDays:     {"in the next (days)", ArityOne},
+	OpInDays:          {"in (days)", ArityOne},
+	OpTomorrow:        {"tomorrow", ArityNone},
+	OpToday:           {"today", ArityNone},
+	OpYesterday:       {"yesterday", ArityNone},
+	OpNextWeek:        {"next week", ArityNone},
+	OpThisWeek:        {"this week", ArityNone},
+	OpLastWeek:        {"last week", ArityNone},
+	OpLastTwoWeeks:    {"last 2 weeks", ArityNone},
+	OpNextMonth:       {"next month", ArityNone},
+	OpThisMonth:       {"this month", ArityNone},
+	OpLastMonth:       {"last month", ArityNone},
+	OpThisYear:        {"this year", ArityNone},
+	OpLastNumDays:     {"less than (days) ago", ArityOne},
+	OpMoreThanDaysAgo: {"more than (days) ago", ArityOne},
+	OpPastNumDays:     {"in the past (days)", ArityOne},
+	OpDaysAgo:         {"(days) ago", ArityOne},
+	OpContains:        {"contains", ArityOne},
+	OpNotContains:     {"doesn't contain", ArityOne},
+	OpContainsAny:     {"contains any of", ArityOne},
+	OpStartsWith:      {"starts with", ArityOne},
+	OpEndsWith:        {"ends with", ArityOne},
+	OpAnyInProject:    {"any issues in project", ArityOne},
+	OpAnyNotInProject: {"any issues not in project", ArityOne},
+	OpNoneInProject:   {"no issues in project", ArityOne},
+	OpAnyOpen:         {"any open issues", ArityNone},
+	OpNoneOpen:        {"no open issues", ArityNone},
+	OpHasBeen:         {"has been", ArityMany},
+	OpHasNeverBeen:    {"has never been", ArityMany},
+	OpChangedFrom:     {"changed from", ArityMany},
+}
+
+// Operators returns all known operators, sorted by their query string representation.
+func Operators() []Operator {
+	ops := make([]Operator, 0, len(operators))
+	for op := range operators {
+		ops = append(ops, op)
+	}
+	slices.Sort(ops)
+	return ops
+}
+
+// Valid reports whether the operator is known.
+func (o Operator) Valid() bool {
+	_, ok := operators[o]
+	return ok
+}
+
+// Label returns the description of the operator, or the operator itself if it is unknown.
+func (o Operator) Label() string {
+	if info, ok := operators[o]; ok {
+		return info.label
+	}
+	return string(o)
+}
+
+// Arity returns the number of values the operator takes.
+// Arity returns ArityMany for unknown operators.
+func (o Operator) Arity() Arity {
+	if info, ok := operators[o]; ok {
+		return info.arity
+	}
+	return ArityMany
+}
+
+// QueryFilter is a single condition of a Redmine issue query.
+type QueryFilter struct {
+	Field    string   // Field is the filtered attribute, e.g. "status_id" or "cf_5"
+	Operator Operator // Operator compares the field with the values
+	Values   []string // Values are the operands, empty for operators like "o" or "t"
+}
+
+// Validate checks that the operator is known and has the number of values it takes.
+func (f QueryFilter) Validate() error {
+	if !f.Operator.Valid() {
+		return fmt.Errorf("filter %q has unknown operator %q", f.Field, f.Operator)
+	}
+
+	var want string
+	n := len(f.Values)
+	switch f.Operator.Arity() {
+	case ArityNone:
+		if n > 0 {
+			want = "no value"
+		}
+	case ArityOne:
+		if n != 1 {
+			want = "one value"
+		}
+	case ArityTwo:
+		if n != 2 {
+			want = "two values"
+		}
+	case ArityMany:
+		if n == 0 {
+			want = "at least one value"
+		}
+	}
+	if want != "" {
+		return fmt.Errorf("filter %q with operator %q takes %s, got %d", f.Field, f.Operator, want, n)
+	}
+
+	return nil
+}
+
+// SortKey is a column the issues are sorted by.
+type SortKey struct {
+	Field      string // Field is the column, e.g. "priority" or "cf_5"
+	Descending bool   // Descending sorts from the highest to the lowest value
+}
+
+// IssueQuery is an issue query in the f[]/op[]/v[] format used by the Redmine web interface.
+// IssueQuery keeps all parameters that are not part of the query, e.g. offset, so they survive a round trip.
+type IssueQuery struct {
+	Filters []QueryFilter // Filters are the conditions in the order they were given
+	Sort    []SortKey     // Sort are the columns the issues are sorted by, in order of precedence
+	GroupBy string        // GroupBy is the column the issues are grouped by
+	Columns []string      // Columns are the columns shown in the issue list, c[] in the query string
+	Totals  []string      // Totals are the columns summed up below the issue list, t[] in the query string
+	Params  url.Values    // Params are all other parameters of the query string
+}
+
+// ParseIssueQuery parses a query string in the format of the Redmine web interface.
+// ParseIssueQuery ignores empty f[] entries, which the web interface appends to every query, and rejects invalid filters.
+// ParseIssueQuery drops the values of filters whose operator takes none.
+func ParseIssueQuery(raw string) (*IssueQuery, error) {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issue query: %w", err)
+	}
+
+	query := &IssueQuery{
+		GroupBy: values.Get("group_by"),
+		Columns: nonEmpty(values["c[]"]),
+		Totals:  nonEmpty(values["t[]"]),
+		Params:  url.Values{},
+	}
+
+	for _, field := range values["f[]"] {
+		if field == "" || query.hasFilter(field) {
+			continue
+		}
+
+		filterValues := values["v["+field+"][]"]
+		if filterValues == nil {
+			filterValues = values["v["+field+"]"]
+		}
+
+		filter := QueryFilter{
+			Field:    field,
+			Operator: Operator(values.Get("op[" + field + "]")),
+			Values:   slices.Clone(filterValues),
+		}
+		if filter.Operator.Arity() == ArityNone {
+			// Values left over from switching the operator in the web interface are ignored by Redmine as well
+			filter.Values = nil
+		}
+		if err := filter.Validate(); err != nil {
+			return nil, fmt.Errorf("failed to parse issue query: %w", err)
+		}
+		query.Filters = append(query.Filters, filter)
+	}
+
+	for _, key := range strings.Split(values.Get("sort"), ",") {
+		field, direction, _ := strings.Cut(strings.TrimSpace(key), ":")
+		if field == "" {
+			continue
+		}
+		query.Sort = append(query.Sort, SortKey{Field: field, Descending: direction == "desc"})
+	}
+
+	for key, value := range values {
+		switch {
+		case key == "f[]", key == "sort", key == "group_by", key == "c[]", key == "t[]":
+		case strings.HasPrefix(key, "op["), strings.HasPrefix(key, "v["):
+		default:
+			query.Params[key] = value
+		}
+	}
+
+	return query, nil
+}
+
+// String returns the query string of the query.
+// String sorts the parameters by name, so equal queries result in equal strings, and keeps brackets readable.
+func (q *IssueQuery) String() string {
+	values := url.Values{}
+	for key, value := range q.Params {
+		values[key] = slices.Clone(value)
+	}
+
+	for _, filter := range q.Filters {
+		values.Add("f[]", filter.Field)
+		values.Set("op["+filter.Field+"]", string(filter.Operator))
+		for _, value := range filter.Values {
+			values.Add("v["+filter.Field+"][]", value)
+		}
+	}
+
+	if len(q.Sort) > 0 {
+		keys := make([]string, 0, len(q.Sort))
+		for _, key := range q.Sort {
+			if key.Descending {
+				keys = append(keys, key.Field+":desc")
+			} else {
+				keys = append(keys, key.Field)
+			}
+		}
+		values.Set("sort", strings.Join(keys, ","))
+	}
+	if q.GroupBy != "" {
+		values.Set("group_by", q.GroupBy)
+	}
+	for _, column := range q.Columns {
+		values.Add("c[]", column)
+	}
+	for _, column := range q.Totals {
+		values.Add("t[]", column)
+	}
+
+	return bracketReplacer.Replace(values.Encode())
+}
+
+// Validate checks all filters of the query.
+func (q *IssueQuery) Validate() error {
+	for _, filter := range q.Filters {
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter returns the filter on field.
+func (q *IssueQuery) Filter(field string) (QueryFilter, bool) {
+	for _, filter := range q.Filters {
+		if filter.Field == field {
+			return filter, true
+		}
+	}
+	return QueryFilter{}, false
+}
+
+// SetFilter replaces the filter on the same field, or appends filter if there is none.
+func (q *IssueQuery) SetFilter(filter QueryFilter) {
+	for i := range q.Filters {
+		if q.Filters[i].Field == filter.Field {
+			q.Filters[i] = filter
+			return
+		}
+	}
+	q.Filters = append(q.Filters, filter)
+}
+
+// RemoveFilter removes the filter on field, if there is one.
+func (q *IssueQuery) RemoveFilter(field string) {
+	q.Filters = slices.DeleteFunc(q.Filters, func(filter QueryFilter) bool {
+		return filter.Field == field
+	})
+}
+
+// hasFilter reports whether the query has a filter on field.
+func (q *IssueQuery) hasFilter(field string) bool {
+	_, ok := q.Filter(field)
+	return ok
+}
+
+// nonEmpty returns the values that are not empty, nil if there are none.
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
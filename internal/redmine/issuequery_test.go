@@ -0,0 +1,141 @@
+package redmine
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestParseIssueQuery tests parsing filters in the format of the Redmine web interface.
+func TestParseIssueQuery(t *testing.T) {
+	raw := "set_filter=1&sort=priority:desc&f[]=status_id&op[status_id]=o&f[]=assigned_to_id&op[assigned_to_id]==&v[assigned_to_id][]=me&v[assigned_to_id][]=5&f[]=updated_on&op[updated_on]=><&v[updated_on][]=2025-08-01&v[updated_on][]=2025-08-31&f[]="
+
+	query, err := ParseIssueQuery(raw)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []QueryFilter{
+		{Field: "status_id", Operator: OpOpen},
+		{Field: "assigned_to_id", Operator: OpEquals, Values: []string{"me", "5"}},
+		{Field: "updated_on", Operator: OpBetween, Values: []string{"2025-08-01", "2025-08-31"}},
+	}
+	if !reflect.DeepEqual(query.Filters, want) {
+		t.Errorf("Filters = %+v, want %+v", query.Filters, want)
+	}
+	if want := []SortKey{{Field: "priority", Descending: true}}; !reflect.DeepEqual(query.Sort, want) {
+		t.Errorf("Sort = %+v, want %+v", query.Sort, want)
+	}
+	if query.Params.Get("set_filter") != "1" || query.Params.Has("sort") {
+		t.Errorf("expected only set_filter to be kept in Params, got %v", query.Params)
+	}
+}
+
+// TestParseIssueQuery_Options tests parsing the sort, group_by, column and totals options.
+func TestParseIssueQuery_Options(t *testing.T) {
+	query, err := ParseIssueQuery("sort=priority:desc,id&group_by=tracker&c[]=subject&c[]=assigned_to&c[]=&t[]=spent_hours&per_page=50")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := &IssueQuery{
+		Sort:    []SortKey{{Field: "priority", Descending: true}, {Field: "id"}},
+		GroupBy: "tracker",
+		Columns: []string{"subject", "assigned_to"},
+		Totals:  []string{"spent_hours"},
+		Params:  url.Values{"per_page": {"50"}},
+	}
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("ParseIssueQuery = %+v, want %+v", query, want)
+	}
+	if got := query.String(); got != "c[]=subject&c[]=assigned_to&group_by=tracker&per_page=50&sort=priority%3Adesc%2Cid&t[]=spent_hours" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// TestIssueQuery_RoundTrip tests that parsing the encoded query results in the same query.
+func TestIssueQuery_RoundTrip(t *testing.T) {
+	queries := []string{
+		"f%5B%5D=status_id&op%5Bstatus_id%5D=o&f%5B%5D=assigned_to_id&op%5Bassigned_to_id%5D=%3D&v%5Bassigned_to_id%5D%5B%5D=me",
+		"f[]=cf_88&op[cf_88]=w&f[]=subject&op[subject]=~&v[subject][]=login form",
+		"f[]=due_date&op[due_date]=>t-&v[due_date][]=7&sort=id",
+		"status_id=open&assigned_to_id=me",
+		"f[]=tracker_id&op[tracker_id]=!&v[tracker_id][]=1&v[tracker_id][]=2&group_by=status&sort=updated_on:desc,id&c[]=subject&t[]=estimated_hours",
+	}
+
+	for _, raw := range queries {
+		first, err := ParseIssueQuery(raw)
+		if err != nil {
+			t.Fatalf("ParseIssueQuery(%q) returned error: %v", raw, err)
+		}
+
+		encoded := first.String()
+		second, err := ParseIssueQuery(encoded)
+		if err != nil {
+			t.Fatalf("ParseIssueQuery(%q) returned error: %v", encoded, err)
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("round trip of %q changed the query:\n got %+v\nwant %+v", raw, second, first)
+		}
+		if second.String() != encoded {
+			t.Errorf("String() = %q, want the stable encoding %q", second.String(), encoded)
+		}
+	}
+}
+
+// TestIssueQuery_RoundTripOperators tests that every operator survives a round trip with the values it takes.
+func TestIssueQuery_RoundTripOperators(t *testing.T) {
+	values := map[Arity][]string{
+		ArityNone: nil,
+		ArityOne:  {"3"},
+		ArityTwo:  {"2025-08-01", "2025-08-31"},
+		ArityMany: {"me", "5"},
+	}
+
+	for _, op := range Operators() {
+		query := &IssueQuery{Params: url.Values{}}
+		query.SetFilter(QueryFilter{Field: "cf_1", Operator: op, Values: values[op.Arity()]})
+
+		parsed, err := ParseIssueQuery(query.String())
+		if err != nil {
+			t.Errorf("ParseIssueQuery(%q) returned error: %v", query.String(), err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, query) {
+			t.Errorf("round trip of operator %q changed the query:\n got %+v\nwant %+v", op, parsed, query)
+		}
+	}
+}
+
+// TestParseIssueQuery_Invalid tests that filters with a missing or unknown operator or the wrong number of values are errors.
+func TestParseIssueQuery_Invalid(t *testing.T) {
+	queries := []string{
+		"f[]=status_id",
+		"f[]=status_id&op[status_id]=%3F",
+		"f[]=subject&op[subject]=~",
+		"f[]=created_on&op[created_on]=><&v[created_on][]=2025-08-01",
+		"f[]=tracker_id&op[tracker_id]==",
+	}
+
+	for _, raw := range queries {
+		if _, err := ParseIssueQuery(raw); err == nil {
+			t.Errorf("ParseIssueQuery(%q) expected error, got nil", raw)
+		}
+	}
+}
+
+// TestIssueQuery_SetFilter tests replacing, appending and removing filters.
+func TestIssueQuery_SetFilter(t *testing.T) {
+	query, _ := ParseIssueQuery("f[]=status_id&op[status_id]=o")
+
+	query.SetFilter(QueryFilter{Field: "status_id", Operator: OpClosed})
+	query.SetFilter(QueryFilter{Field: "tracker_id", Operator: OpEquals, Values: []string{"1"}})
+	if got := query.String(); got != "f[]=status_id&f[]=tracker_id&op[status_id]=c&op[tracker_id]=%3D&v[tracker_id][]=1" {
+		t.Errorf("String() = %q", got)
+	}
+
+	query.RemoveFilter("status_id")
+	if _, ok := query.Filter("status_id"); ok || len(query.Filters) != 1 {
+		t.Errorf("expected only the tracker filter to remain, got %+v", query.Filters)
+	}
+}
@@ -0,0 +1,122 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRestClient_UpdateIssue tests that only the set fields are sent, including a done ratio of 0.
+func TestRestClient_UpdateIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/issues/42.json" {
+			t.Errorf("expected PUT /issues/42.json, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		issue := payload["issue"]
+		if len(issue) != 3 || issue["status_id"] != float64(3) || issue["done_ratio"] != float64(0) || issue["notes"] != "Reopened" {
+			t.Errorf("unexpected payload: %v", issue)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	doneRatio := 0
+	err := client.UpdateIssue(context.Background(), 42, models.UpdateIssueParams{StatusID: 3, DoneRatio: &doneRatio, Notes: "Reopened"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestRestClient_UpdateIssueRejected tests that a rejected update returns a validation error.
+func TestRestClient_UpdateIssueRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": ["Status is invalid"]}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	err := client.UpdateIssue(context.Background(), 42, models.UpdateIssueParams{StatusID: 99})
+	if !IsValidation(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+// TestRestClient_GetIssueWithAllowedStatuses tests that the allowed statuses are requested and decoded.
+func TestRestClient_GetIssueWithAllowedStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include"); got != "allowed_statuses" {
+			t.Errorf("expected include=allowed_statuses, got '%s'", got)
+		}
+
+		w.Write([]byte(`{"issue": {"id": 42, "status": {"id": 1, "name": "New"}, "done_ratio": 20,
+			"assigned_to": {"id": 3, "name": "Jane"},
+			"custom_fields": [{"id": 9, "name": "Follow-up", "value": "2025-08-14"}],
+			"allowed_statuses": [{"id": 1, "name": "New"}, {"id": 5, "name": "Closed", "is_closed": true}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	issue, err := client.GetIssueWithAllowedStatuses(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.Status.ID != 1 || issue.AssignedTo == nil || issue.AssignedTo.Name != "Jane" || issue.DoneRatio != 20 {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+	if len(issue.CustomFields) != 1 || issue.CustomFields[0].Text() != "2025-08-14" {
+		t.Errorf("unexpected custom fields: %+v", issue.CustomFields)
+	}
+	want := []models.IssueStatus{{ID: 1, Name: "New"}, {ID: 5, Name: "Closed", IsClosed: true}}
+	if len(issue.AllowedStatuses) != 2 || issue.AllowedStatuses[0] != want[0] || issue.AllowedStatuses[1] != want[1] {
+		t.Errorf("AllowedStatuses = %+v, want %+v", issue.AllowedStatuses, want)
+	}
+}
+
+// TestRestClient_GetIssue tests that the history and links of the issue are requested and decoded.
+func TestRestClient_GetIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include"); got != "journals,attachments,relations,children,watchers" {
+			t.Errorf("expected the history and links to be included, got '%s'", got)
+		}
+
+		w.Write([]byte(`{"issue": {"id": 42, "subject": "Fix login",
+			"journals": [{"id": 1, "user": {"id": 3, "name": "Jane"}, "notes": "Started", "created_on": "2025-08-12T14:30:00Z",
+				"details": [{"property": "attr", "name": "status_id", "old_value": "1", "new_value": "2"}]}],
+			"attachments": [{"id": 17, "filename": "log.txt", "filesize": 2048, "author": {"id": 3, "name": "Jane"}}],
+			"relations": [{"id": 8, "issue_id": 42, "issue_to_id": 12, "relation_type": "blocks"}],
+			"children": [{"id": 43, "tracker": {"id": 1, "name": "Bug"}, "subject": "Fix logout"}],
+			"watchers": [{"id": 4, "name": "John"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	issue, err := client.GetIssue(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(issue.Journals) != 1 || issue.Journals[0].User.Name != "Jane" || len(issue.Journals[0].Details) != 1 {
+		t.Fatalf("unexpected journals: %+v", issue.Journals)
+	}
+	if detail := issue.Journals[0].Details[0]; detail.Name != "status_id" || detail.OldValue != "1" || detail.NewValue != "2" {
+		t.Errorf("unexpected journal detail: %+v", detail)
+	}
+	if len(issue.Attachments) != 1 || len(issue.Relations) != 1 || len(issue.Children) != 1 || len(issue.Watchers) != 1 {
+		t.Errorf("unexpected links: %+v %+v %+v %+v", issue.Attachments, issue.Relations, issue.Children, issue.Watchers)
+	}
+}
@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRestClient_ListIssueMetadata tests listing trackers, issue statuses, time entry activities and the versions of a project.
+func TestRestClient_ListIssueMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			w.Write([]byte(`{"trackers": [{"id": 1, "name": "Bug"}, {"id": 2, "name": "Feature"}]}`))
+		case "/issue_statuses.json":
+			w.Write([]byte(`{"issue_statuses": [{"id": 1, "name": "New"}, {"id": 5, "name": "Closed", "is_closed": true}]}`))
+		case "/enumerations/time_entry_activities.json":
+			w.Write([]byte(`{"time_entry_activities": [{"id": 8, "name": "Design", "is_default": false, "active": true}, {"id": 9, "name": "Development", "is_default": true, "active": true}]}`))
+		case "/projects/web site/versions.json":
+			w.Write([]byte(`{"versions": [{"id": 3, "name": "1.0", "status": "open"}], "total_count": 1}`))
+		default:
+			t.Errorf("unexpected path '%s'", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	ctx := context.Background()
+
+	trackers, err := client.ListTrackers(ctx)
+	if err != nil || len(trackers) != 2 || trackers[1].Name != "Feature" {
+		t.Errorf("ListTrackers() = %+v, %v", trackers, err)
+	}
+
+	statuses, err := client.ListIssueStatuses(ctx)
+	if err != nil || len(statuses) != 2 || !statuses[1].IsClosed {
+		t.Errorf("ListIssueStatuses() = %+v, %v", statuses, err)
+	}
+
+	activities, err := client.ListTimeEntryActivities(ctx)
+	if err != nil || len(activities) != 2 || activities[0].IsDefault || !activities[1].IsDefault {
+		t.Errorf("ListTimeEntryActivities() = %+v, %v", activities, err)
+	}
+
+	versions, err := client.ListVersions(ctx, "web site")
+	if err != nil || len(versions) != 1 || versions[0].Name != "1.0" {
+		t.Errorf("ListVersions() = %+v, %v", versions, err)
+	}
+}
+
+// TestIterateMemberships tests that users and groups of all pages are returned.
+func TestIterateMemberships(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/7/memberships.json" {
+			t.Errorf("expected path '/projects/7/memberships.json', got '%s'", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("offset") != "1" {
+			w.Write([]byte(`{"memberships": [{"id": 1, "user": {"id": 5, "name": "Jane"}}], "total_count": 2, "offset": 0, "limit": 1}`))
+			return
+		}
+		w.Write([]byte(`{"memberships": [{"id": 2, "group": {"id": 9, "name": "Developers"}}], "total_count": 2, "offset": 1, "limit": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	var names []string
+	for membership, err := range IterateMemberships(context.Background(), client, "7", models.MembershipListParams{}) {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		switch {
+		case membership.User != nil:
+			names = append(names, membership.User.Name)
+		case membership.Group != nil:
+			names = append(names, membership.Group.Name)
+		}
+	}
+
+	if len(names) != 2 || names[0] != "Jane" || names[1] != "Developers" {
+		t.Errorf("expected members [Jane Developers], got %v", names)
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Issue represents a Redmine issue.
 // Issue contains the detailed information about a specific issue in Redmine.
@@ -9,6 +13,7 @@ type Issue struct {
 	Subject     string `json:"subject"`     // Subject is the title or summary of the issue
 	Description string `json:"description"` // Description contains the detailed description of the issue
 	Status      struct {
+		ID   int    `json:"id"`   // ID is the status identifier
 		Name string `json:"name"` // Name is the status name (e.g., "New", "In Progress", "Closed")
 	} `json:"status"` // Status represents the current status of the issue
 	Author struct {
@@ -18,6 +23,118 @@ type Issue struct {
 		Name string `json:"name"` // Name is the project name
 		ID   int    `json:"id"`   // ID is the project identifier
 	} `json:"project"` // Project represents the project this issue belongs to
+	AssignedTo *struct {
+		ID   int    `json:"id"`   // ID is the user or group identifier
+		Name string `json:"name"` // Name is the name of the user or group
+	} `json:"assigned_to,omitempty"` // AssignedTo is the assignee, nil for unassigned issues
+	DoneRatio       int                `json:"done_ratio"`                 // DoneRatio is the progress of the issue in percent
+	CustomFields    []IssueCustomField `json:"custom_fields,omitempty"`    // CustomFields contains the values of the custom fields
+	AllowedStatuses []IssueStatus      `json:"allowed_statuses,omitempty"` // AllowedStatuses is only set if requested with include=allowed_statuses
+	Journals        []Journal          `json:"journals,omitempty"`         // Journals is only set if requested with include=journals
+	Attachments     []Attachment       `json:"attachments,omitempty"`      // Attachments is only set if requested with include=attachments
+	Relations       []IssueRelation    `json:"relations,omitempty"`        // Relations is only set if requested with include=relations
+	Children        []IssueChild       `json:"children,omitempty"`         // Children is only set if requested with include=children
+	Watchers        []struct {
+		ID   int    `json:"id"`   // ID is the user identifier
+		Name string `json:"name"` // Name is the user's full name
+	} `json:"watchers,omitempty"` // Watchers is only set if requested with include=watchers and the user may see them
 	CreatedOn time.Time `json:"created_on"` // CreatedOn is the timestamp when the issue was created
 	UpdatedOn time.Time `json:"updated_on"` // UpdatedOn is the timestamp when the issue was last updated
 }
+
+// Journal represents an entry of the issue history, a note, changed fields or both.
+type Journal struct {
+	ID   int `json:"id"` // ID is the unique identifier of the journal
+	User struct {
+		ID   int    `json:"id"`   // ID is the user identifier
+		Name string `json:"name"` // Name is the user's full name
+	} `json:"user"` // User made the change
+	Notes        string          `json:"notes"`         // Notes is the comment, empty for pure field changes
+	PrivateNotes bool            `json:"private_notes"` // PrivateNotes indicates that the notes are only visible to privileged users
+	CreatedOn    time.Time       `json:"created_on"`    // CreatedOn is the timestamp of the change
+	Details      []JournalDetail `json:"details"`       // Details lists the changed fields
+}
+
+// JournalDetail represents a single change recorded in a journal.
+// Values of attributes are IDs for fields like status_id and assigned_to_id.
+type JournalDetail struct {
+	Property string `json:"property"`  // Property is "attr", "cf", "attachment" or "relation"
+	Name     string `json:"name"`      // Name is the attribute name, the custom field ID, the attachment ID or the relation type
+	OldValue string `json:"old_value"` // OldValue is the value before the change, empty if there was none
+	NewValue string `json:"new_value"` // NewValue is the value after the change, empty if it was removed
+}
+
+// Attachment represents a file attached to an issue.
+type Attachment struct {
+	ID          int    `json:"id"`           // ID is the unique identifier of the attachment
+	Filename    string `json:"filename"`     // Filename is the name of the file
+	Filesize    int64  `json:"filesize"`     // Filesize is the size of the file in bytes
+	ContentType string `json:"content_type"` // ContentType is the MIME type of the file
+	Description string `json:"description"`  // Description is the optional description of the file
+	ContentURL  string `json:"content_url"`  // ContentURL is the download URL of the file
+	Author      struct {
+		ID   int    `json:"id"`   // ID is the user identifier
+		Name string `json:"name"` // Name is the user's full name
+	} `json:"author"` // Author uploaded the file
+	CreatedOn time.Time `json:"created_on"` // CreatedOn is the timestamp of the upload
+}
+
+// IssueRelation represents a relation between two issues, e.g. "blocks" or "relates".
+type IssueRelation struct {
+	ID           int    `json:"id"`              // ID is the unique identifier of the relation
+	IssueID      int    `json:"issue_id"`        // IssueID is the issue the relation starts from
+	IssueToID    int    `json:"issue_to_id"`     // IssueToID is the related issue
+	RelationType string `json:"relation_type"`   // RelationType is e.g. "relates", "blocks" or "precedes"
+	Delay        *int   `json:"delay,omitempty"` // Delay is the number of days between preceding and following issues
+}
+
+// IssueChild represents a subtask of an issue.
+type IssueChild struct {
+	ID      int `json:"id"` // ID is the issue identifier of the subtask
+	Tracker struct {
+		ID   int    `json:"id"`   // ID is the tracker identifier
+		Name string `json:"name"` // Name is the tracker name
+	} `json:"tracker"` // Tracker is the tracker of the subtask
+	Subject string `json:"subject"` // Subject is the title of the subtask
+}
+
+// IssueCustomField represents the value of a custom field of an issue.
+type IssueCustomField struct {
+	ID       int    `json:"id"`                 // ID is the identifier of the custom field
+	Name     string `json:"name"`               // Name is the display name of the custom field
+	Multiple bool   `json:"multiple,omitempty"` // Multiple indicates that Value is a list
+	Value    any    `json:"value"`              // Value is a string, a list of strings for multiple values, or nil
+}
+
+// Text returns the value of the custom field, joining multiple values with ", ".
+func (f IssueCustomField) Text() string {
+	switch value := f.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, part := range value {
+			parts = append(parts, fmt.Sprint(part))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(f.Value)
+}
+
+// UpdateIssueParams represents the request payload for updating an issue.
+// UpdateIssueParams only sends the fields that are set, all other fields keep their value.
+type UpdateIssueParams struct {
+	StatusID     int                `json:"status_id,omitempty"`      // StatusID is the new status, which must be allowed for the issue
+	AssignedToID int                `json:"assigned_to_id,omitempty"` // AssignedToID is the new assignee, a user or group ID
+	DoneRatio    *int               `json:"done_ratio,omitempty"`     // DoneRatio is the new progress in percent, a pointer so 0 can be set
+	CustomFields []CustomFieldValue `json:"custom_fields,omitempty"`  // CustomFields are the custom field values to change
+	Notes        string             `json:"notes,omitempty"`          // Notes is added as a journal note
+}
+
+// CustomFieldValue sets the value of a single custom field.
+type CustomFieldValue struct {
+	ID    int    `json:"id"`    // ID is the identifier of the custom field
+	Value string `json:"value"` // Value is the new value, empty to clear the field
+}
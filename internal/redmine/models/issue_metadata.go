@@ -0,0 +1,43 @@
+package models
+
+// Tracker represents a Redmine tracker, e.g. "Bug" or "Feature".
+type Tracker struct {
+	ID   int    `json:"id"`   // ID is the unique identifier of the tracker
+	Name string `json:"name"` // Name is the display name of the tracker
+}
+
+// TrackerResults represents the response from a Redmine trackers request.
+type TrackerResults struct {
+	Trackers []Tracker `json:"trackers"` // Trackers contains all trackers
+}
+
+// IssueStatus represents a status an issue can have, e.g. "New" or "Closed".
+type IssueStatus struct {
+	ID       int    `json:"id"`        // ID is the unique identifier of the status
+	Name     string `json:"name"`      // Name is the display name of the status
+	IsClosed bool   `json:"is_closed"` // IsClosed indicates whether issues with this status count as closed
+}
+
+// IssueStatusResults represents the response from a Redmine issue statuses request.
+type IssueStatusResults struct {
+	IssueStatuses []IssueStatus `json:"issue_statuses"` // IssueStatuses contains all issue statuses
+}
+
+// CustomField represents the definition of a Redmine custom field.
+// CustomField definitions can only be listed by administrators.
+type CustomField struct {
+	ID             int    `json:"id"`              // ID is the unique identifier of the custom field
+	Name           string `json:"name"`            // Name is the display name of the custom field
+	CustomizedType string `json:"customized_type"` // CustomizedType is the kind of object the field belongs to, e.g. "issue"
+	FieldFormat    string `json:"field_format"`    // FieldFormat is the type of the values, e.g. "string", "date" or "list"
+	IsFilter       bool   `json:"is_filter"`       // IsFilter indicates whether the field can be used in issue queries
+	PossibleValues []struct {
+		Value string `json:"value"` // Value is the stored value
+		Label string `json:"label"` // Label is the displayed value
+	} `json:"possible_values"` // PossibleValues are the values of list fields
+}
+
+// CustomFieldResults represents the response from a Redmine custom fields request.
+type CustomFieldResults struct {
+	CustomFields []CustomField `json:"custom_fields"` // CustomFields contains all custom field definitions
+}
@@ -24,3 +24,65 @@ type TimeEntryActivity struct {
 	IsDefault bool   `json:"is_default"` // IsDefault indicates whether this is the default activity
 	Active    bool   `json:"active"`     // Active indicates whether this activity is currently available for use
 }
+
+// TimeEntryActivityResults represents the response from a Redmine time entry activities request.
+type TimeEntryActivityResults struct {
+	TimeEntryActivities []TimeEntryActivity `json:"time_entry_activities"` // TimeEntryActivities contains the activities of the instance
+}
+
+// ProjectListParams defines the pagination parameters for listing projects.
+type ProjectListParams struct {
+	Offset int `query:"offset,omitempty"` // Offset specifies the number of results to skip
+	Limit  int `query:"limit,omitempty"`  // Limit specifies the maximum number of results to return
+}
+
+// ProjectResults represents the response from a Redmine projects request.
+// ProjectResults contains paginated projects and metadata.
+type ProjectResults struct {
+	Projects   []Project `json:"projects"`    // Projects contains the array of projects
+	TotalCount int       `json:"total_count"` // TotalCount is the total number of projects available
+	Offset     int       `json:"offset"`      // Offset is the number of results skipped
+	Limit      int       `json:"limit"`       // Limit is the maximum number of results returned
+}
+
+// Version represents a version (milestone) of a Redmine project.
+type Version struct {
+	ID     int    `json:"id"`     // ID is the unique identifier of the version
+	Name   string `json:"name"`   // Name is the display name of the version
+	Status string `json:"status"` // Status is "open", "locked" or "closed"
+}
+
+// VersionResults represents the response from a Redmine versions request.
+type VersionResults struct {
+	Versions   []Version `json:"versions"`    // Versions contains the versions available to the project
+	TotalCount int       `json:"total_count"` // TotalCount is the total number of versions
+}
+
+// Membership represents the membership of a user or group in a Redmine project.
+// Membership has either User or Group set.
+type Membership struct {
+	ID   int `json:"id"` // ID is the unique identifier of the membership
+	User *struct {
+		ID   int    `json:"id"`   // ID is the user identifier
+		Name string `json:"name"` // Name is the user's full name
+	} `json:"user,omitempty"` // User is the member, nil for group memberships
+	Group *struct {
+		ID   int    `json:"id"`   // ID is the group identifier
+		Name string `json:"name"` // Name is the group name
+	} `json:"group,omitempty"` // Group is the member, nil for user memberships
+}
+
+// MembershipListParams defines the pagination parameters for listing project memberships.
+type MembershipListParams struct {
+	Offset int `query:"offset,omitempty"` // Offset specifies the number of results to skip
+	Limit  int `query:"limit,omitempty"`  // Limit specifies the maximum number of results to return
+}
+
+// MembershipResults represents the response from a Redmine memberships request.
+// MembershipResults contains paginated memberships and metadata.
+type MembershipResults struct {
+	Memberships []Membership `json:"memberships"` // Memberships contains the array of memberships
+	TotalCount  int          `json:"total_count"` // TotalCount is the total number of memberships available
+	Offset      int          `json:"offset"`      // Offset is the number of results skipped
+	Limit       int          `json:"limit"`       // Limit is the maximum number of results returned
+}
@@ -0,0 +1,25 @@
+package models
+
+// Query represents a saved issue query of Redmine.
+// Query is maintained in the Redmine web interface and can be public or visible to its author only.
+type Query struct {
+	ID        int    `json:"id"`                   // ID is the unique identifier of the query
+	Name      string `json:"name"`                 // Name is the display name of the query
+	IsPublic  bool   `json:"is_public"`            // IsPublic indicates whether the query is visible to other users
+	ProjectID int    `json:"project_id,omitempty"` // ProjectID is the project the query belongs to, 0 for global queries
+}
+
+// QueryListParams defines the pagination parameters for listing saved queries.
+type QueryListParams struct {
+	Offset int `query:"offset,omitempty"` // Offset specifies the number of results to skip
+	Limit  int `query:"limit,omitempty"`  // Limit specifies the maximum number of results to return
+}
+
+// QueryResults represents the response from a Redmine queries request.
+// QueryResults contains paginated saved queries and metadata.
+type QueryResults struct {
+	Queries    []Query `json:"queries"`     // Queries contains the array of saved queries
+	TotalCount int     `json:"total_count"` // TotalCount is the total number of queries available
+	Offset     int     `json:"offset"`      // Offset is the number of results skipped
+	Limit      int     `json:"limit"`       // Limit is the maximum number of results returned
+}
@@ -12,6 +12,10 @@ type TimeEntry struct {
 	Issue    struct {
 		ID int `json:"id"` // ID is the issue identifier this time entry belongs to
 	} `json:"issue"` // Issue represents the issue this time entry is associated with
+	Project struct {
+		ID   int    `json:"id"`   // ID is the project identifier
+		Name string `json:"name"` // Name is the project name
+	} `json:"project"` // Project represents the project this time entry is booked on
 	Activity struct {
 		ID   int    `json:"id"`   // ID is the activity identifier
 		Name string `json:"name"` // Name is the activity name
@@ -33,3 +37,35 @@ type CreateTimeEntryParams struct {
 	Comments   string  `json:"comments"`    // Comments contain additional notes about the work performed
 	SpentOn    string  `json:"spent_on"`    // SpentOn is the date when the work was performed (YYYY-MM-DD format)
 }
+
+// UpdateTimeEntryParams represents the request payload for updating a time entry.
+// UpdateTimeEntryParams only sends the fields that are set, all other fields keep their value.
+type UpdateTimeEntryParams struct {
+	IssueID    int     `json:"issue_id,omitempty"`    // IssueID moves the time entry to another issue
+	Hours      float64 `json:"hours,omitempty"`       // Hours is the new amount of time logged
+	ActivityID int     `json:"activity_id,omitempty"` // ActivityID is the ID of the new time tracking activity
+	Comments   *string `json:"comments,omitempty"`    // Comments replace the notes about the work performed, an empty string clears them
+	SpentOn    string  `json:"spent_on,omitempty"`    // SpentOn is the new date of the work (YYYY-MM-DD format)
+}
+
+// TimeEntryFilter defines the parameters for listing Redmine time entries.
+// TimeEntryFilter leaves out every filter that is not set.
+type TimeEntryFilter struct {
+	Offset     int    `query:"offset,omitempty"`      // Offset specifies the number of results to skip
+	Limit      int    `query:"limit,omitempty"`       // Limit specifies the maximum number of results to return
+	UserID     string `query:"user_id,omitempty"`     // UserID filters by user ("me" for the current user)
+	ProjectID  string `query:"project_id,omitempty"`  // ProjectID filters by project ID or identifier
+	IssueID    int    `query:"issue_id,omitempty"`    // IssueID filters by issue
+	From       string `query:"from,omitempty"`        // From is the first day to include (YYYY-MM-DD format)
+	To         string `query:"to,omitempty"`          // To is the last day to include (YYYY-MM-DD format)
+	ActivityID int    `query:"activity_id,omitempty"` // ActivityID filters by time tracking activity
+}
+
+// TimeEntryResults represents the response from a Redmine time entries request.
+// TimeEntryResults contains paginated time entries and metadata.
+type TimeEntryResults struct {
+	TimeEntries []TimeEntry `json:"time_entries"` // TimeEntries contains the array of time entries
+	TotalCount  int         `json:"total_count"`  // TotalCount is the total number of time entries available
+	Offset      int         `json:"offset"`       // Offset is the number of results skipped
+	Limit       int         `json:"limit"`        // Limit is the maximum number of results returned
+}
@@ -0,0 +1,145 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// PageSize is the number of items requested per page.
+// PageSize matches the maximum limit Redmine accepts for a single request.
+const PageSize = 100
+
+// fetchPage retrieves a single page of items starting at offset.
+// fetchPage returns the items of the page and the total number of items available.
+type fetchPage[T any] func(ctx context.Context, offset, limit int) ([]T, int, error)
+
+// paginate returns an iterator over all items of a paginated Redmine endpoint.
+// paginate follows offset and limit until total_count is reached or a page comes back empty.
+// An error stops the iteration after it has been yielded.
+func paginate[T any](ctx context.Context, offset int, fetch fetchPage[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, total, err := fetch(ctx, offset, PageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(items)
+			if len(items) == 0 || offset >= total {
+				return
+			}
+		}
+	}
+}
+
+// IterateIssues returns an iterator over all issues matching the raw Redmine query string.
+// IterateIssues replaces offset and limit of the query string to request the issues page by page.
+func IterateIssues(ctx context.Context, client RedmineIssueSearcher, queryString string) iter.Seq2[models.Issue, error] {
+	values, err := url.ParseQuery(queryString)
+	if err != nil {
+		return func(yield func(models.Issue, error) bool) {
+			yield(models.Issue{}, fmt.Errorf("failed to parse issue query: %w", err))
+		}
+	}
+
+	offset, _ := strconv.Atoi(values.Get("offset"))
+
+	return paginate(ctx, offset, func(ctx context.Context, offset, limit int) ([]models.Issue, int, error) {
+		values.Set("offset", strconv.Itoa(offset))
+		values.Set("limit", strconv.Itoa(limit))
+
+		results, err := client.SearchIssuesRaw(ctx, values.Encode())
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.Issues, results.TotalCount, nil
+	})
+}
+
+// IterateSearch returns an iterator over all full-text search results for the given parameters.
+// IterateSearch starts at params.Offset and ignores params.Limit in favour of PageSize.
+func IterateSearch(ctx context.Context, client RedmineSearcher, params models.SearchParams) iter.Seq2[models.SearchResult, error] {
+	return paginate(ctx, params.Offset, func(ctx context.Context, offset, limit int) ([]models.SearchResult, int, error) {
+		params.Offset = offset
+		params.Limit = limit
+
+		results, err := client.Search(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.Results, results.TotalCount, nil
+	})
+}
+
+// IterateTimeEntries returns an iterator over all time entries matching the filter.
+// IterateTimeEntries starts at filter.Offset and ignores filter.Limit in favour of PageSize.
+func IterateTimeEntries(ctx context.Context, client RedmineTimeEntryLister, filter models.TimeEntryFilter) iter.Seq2[models.TimeEntry, error] {
+	return paginate(ctx, filter.Offset, func(ctx context.Context, offset, limit int) ([]models.TimeEntry, int, error) {
+		filter.Offset = offset
+		filter.Limit = limit
+
+		results, err := client.ListTimeEntries(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.TimeEntries, results.TotalCount, nil
+	})
+}
+
+// IterateQueries returns an iterator over all saved issue queries visible to the user.
+// IterateQueries starts at params.Offset and ignores params.Limit in favour of PageSize.
+func IterateQueries(ctx context.Context, client RedmineQueryLister, params models.QueryListParams) iter.Seq2[models.Query, error] {
+	return paginate(ctx, params.Offset, func(ctx context.Context, offset, limit int) ([]models.Query, int, error) {
+		params.Offset = offset
+		params.Limit = limit
+
+		results, err := client.ListQueries(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.Queries, results.TotalCount, nil
+	})
+}
+
+// IterateProjects returns an iterator over all projects visible to the user.
+// IterateProjects starts at params.Offset and ignores params.Limit in favour of PageSize.
+func IterateProjects(ctx context.Context, client RedmineProjectLister, params models.ProjectListParams) iter.Seq2[models.Project, error] {
+	return paginate(ctx, params.Offset, func(ctx context.Context, offset, limit int) ([]models.Project, int, error) {
+		params.Offset = offset
+		params.Limit = limit
+
+		results, err := client.ListProjects(ctx, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.Projects, results.TotalCount, nil
+	})
+}
+
+// IterateMemberships returns an iterator over all memberships of the project.
+// IterateMemberships starts at params.Offset and ignores params.Limit in favour of PageSize.
+func IterateMemberships(ctx context.Context, client RedmineProjectMetadataLister, projectID string, params models.MembershipListParams) iter.Seq2[models.Membership, error] {
+	return paginate(ctx, params.Offset, func(ctx context.Context, offset, limit int) ([]models.Membership, int, error) {
+		params.Offset = offset
+		params.Limit = limit
+
+		results, err := client.ListMemberships(ctx, projectID, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		return results.Memberships, results.TotalCount, nil
+	})
+}
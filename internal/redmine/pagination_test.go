@@ -0,0 +1,111 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// newIssuePagesServer creates a mock server serving total issues in pages of the requested limit.
+func newIssuePagesServer(t *testing.T, total int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		query := r.URL.Query()
+		if query.Get("f[]") != "status_id" {
+			t.Errorf("expected original filter to be preserved, got '%s'", r.URL.RawQuery)
+		}
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		if limit != PageSize {
+			t.Errorf("expected limit %d, got %d", PageSize, limit)
+		}
+
+		results := models.IssueResults{TotalCount: total, Offset: offset, Limit: limit}
+		for id := offset + 1; id <= total && id <= offset+limit; id++ {
+			results.Issues = append(results.Issues, models.Issue{ID: id})
+		}
+
+		json.NewEncoder(w).Encode(results)
+	}))
+}
+
+// TestIterateIssues_FollowsAllPages tests that IterateIssues requests pages until total_count is reached.
+func TestIterateIssues_FollowsAllPages(t *testing.T) {
+	requests := 0
+	server := newIssuePagesServer(t, 250, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	var ids []int
+	for issue, err := range IterateIssues(context.Background(), client, "f%5B%5D=status_id&op%5Bstatus_id%5D=o") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	if len(ids) != 250 {
+		t.Fatalf("expected 250 issues, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("expected issue %d at position %d, got %d", i+1, i, id)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+// TestIterateIssues_StopsEarly tests that breaking out of the loop does not fetch further pages.
+func TestIterateIssues_StopsEarly(t *testing.T) {
+	requests := 0
+	server := newIssuePagesServer(t, 250, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	count := 0
+	for _, err := range IterateIssues(context.Background(), client, "f%5B%5D=status_id") {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		count++
+		if count == 10 {
+			break
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// TestIterateSearch_Error tests that IterateSearch yields the error of a failing page.
+func TestIterateSearch_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	var errs int
+	for _, err := range IterateSearch(context.Background(), client, models.SearchParams{Query: "test"}) {
+		if err != nil {
+			errs++
+		}
+	}
+
+	if errs != 1 {
+		t.Errorf("expected exactly one error, got %d", errs)
+	}
+}
@@ -0,0 +1,62 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRestClient_ListQueries tests that public and personal queries are decoded with their project.
+func TestRestClient_ListQueries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/queries.json" {
+			t.Errorf("expected path '/queries.json', got '%s'", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "100" {
+			t.Errorf("expected limit=100, got '%s'", got)
+		}
+
+		w.Write([]byte(`{"queries": [
+			{"id": 3, "name": "Open bugs", "is_public": true},
+			{"id": 8, "name": "My sprint", "is_public": false, "project_id": 2}
+		], "total_count": 2, "offset": 0, "limit": 100}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	results, err := client.ListQueries(context.Background(), models.QueryListParams{Limit: 100})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []models.Query{
+		{ID: 3, Name: "Open bugs", IsPublic: true},
+		{ID: 8, Name: "My sprint", ProjectID: 2},
+	}
+	if len(results.Queries) != len(want) {
+		t.Fatalf("expected %d queries, got %+v", len(want), results.Queries)
+	}
+	for i, query := range results.Queries {
+		if query != want[i] {
+			t.Errorf("queries[%d] = %+v, want %+v", i, query, want[i])
+		}
+	}
+}
+
+// TestRestClient_ListQueriesForbidden tests that a rejected request returns an API error.
+func TestRestClient_ListQueriesForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	if _, err := client.ListQueries(context.Background(), models.QueryListParams{}); !IsForbidden(err) {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+}
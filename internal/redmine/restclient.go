@@ -15,24 +15,68 @@ import (
 )
 
 type RedmineSearcher interface {
-	Search(params models.SearchParams) (*models.SearchResults, error)
+	Search(ctx context.Context, params models.SearchParams) (*models.SearchResults, error)
 }
 
 type RedmineIssueGetter interface {
-	GetIssue(issueID int) (*models.Issue, error)
+	GetIssue(ctx context.Context, issueID int) (*models.Issue, error)
+}
+
+type RedmineIssueUpdater interface {
+	UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error
+	GetIssueWithAllowedStatuses(ctx context.Context, issueID int) (*models.Issue, error)
 }
 
 type RedmineIssueSearcher interface {
-	SearchIssues(filter models.IssueFilter) (*models.IssueResults, error)
-	SearchIssuesRaw(queryString string) (*models.IssueResults, error)
+	SearchIssues(ctx context.Context, filter models.IssueFilter) (*models.IssueResults, error)
+	SearchIssuesRaw(ctx context.Context, queryString string) (*models.IssueResults, error)
 }
 
 type RedmineProjectGetter interface {
-	GetProject(projectID int) (*models.Project, error)
+	GetProject(ctx context.Context, projectID int) (*models.Project, error)
 }
 
 type RedmineTimeEntryCreator interface {
-	CreateTimeEntry(params models.CreateTimeEntryParams) (*models.TimeEntry, error)
+	CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error)
+}
+
+type RedmineTimeEntryLister interface {
+	ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) (*models.TimeEntryResults, error)
+}
+
+type RedmineTimeEntryGetter interface {
+	GetTimeEntry(ctx context.Context, id int) (*models.TimeEntry, error)
+}
+
+type RedmineTimeEntryUpdater interface {
+	UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error
+}
+
+type RedmineTimeEntryDeleter interface {
+	DeleteTimeEntry(ctx context.Context, id int) error
+}
+
+type RedmineQueryLister interface {
+	ListQueries(ctx context.Context, params models.QueryListParams) (*models.QueryResults, error)
+}
+
+type RedmineProjectLister interface {
+	ListProjects(ctx context.Context, params models.ProjectListParams) (*models.ProjectResults, error)
+}
+
+type RedmineIssueMetadataLister interface {
+	ListTrackers(ctx context.Context) ([]models.Tracker, error)
+	ListIssueStatuses(ctx context.Context) ([]models.IssueStatus, error)
+	ListCustomFields(ctx context.Context) ([]models.CustomField, error)
+}
+
+type RedmineTimeEntryActivityLister interface {
+	ListTimeEntryActivities(ctx context.Context) ([]models.TimeEntryActivity, error)
+}
+
+type RedmineProjectMetadataLister interface {
+	ListVersions(ctx context.Context, projectID string) ([]models.Version, error)
+	ListMemberships(ctx context.Context, projectID string, params models.MembershipListParams) (*models.MembershipResults, error)
 }
 
 type RedmineBaseURLGetter interface {
@@ -43,15 +87,26 @@ type RedmineAPI interface {
 	RedmineSearcher
 	RedmineProjectGetter
 	RedmineIssueGetter
+	RedmineIssueUpdater
 	RedmineIssueSearcher
 	RedmineBaseURLGetter
 	RedmineTimeEntryCreator
+	RedmineTimeEntryLister
+	RedmineTimeEntryGetter
+	RedmineTimeEntryUpdater
+	RedmineTimeEntryDeleter
+	RedmineQueryLister
+	RedmineProjectLister
+	RedmineIssueMetadataLister
+	RedmineProjectMetadataLister
+	RedmineTimeEntryActivityLister
 }
 
 type RestClient struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
 }
 
 func NewRestClient(baseURL, apiKey string) *RestClient {
@@ -61,7 +116,17 @@ func NewRestClient(baseURL, apiKey string) *RestClient {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		retryPolicy: NewBackoffPolicy(0, 0, 0),
+	}
+}
+
+// SetRetryPolicy replaces the policy deciding whether failed requests are retried.
+// SetRetryPolicy disables retries if policy is nil.
+func (c *RestClient) SetRetryPolicy(policy RetryPolicy) {
+	if policy == nil {
+		policy = NoRetry{}
 	}
+	c.retryPolicy = policy
 }
 
 func (c *RestClient) GetBaseURL() string {
@@ -74,18 +139,18 @@ func (c *RestClient) Login(ctx context.Context) error {
 		return fmt.Errorf("failed to create login request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute login request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("authentication failed: invalid API key")
+		return fmt.Errorf("authentication failed: invalid API key: %w", newAPIError(resp))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("login failed with status: %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
@@ -105,11 +170,47 @@ func (c *RestClient) newRequest(ctx context.Context, method, path string, body i
 	return req, nil
 }
 
+// do sends the request and retries it as long as the retry policy asks for it.
+// do waits between attempts and gives up early when the request context is done.
+func (c *RestClient) do(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+
+		delay, retry := c.retryPolicy.Next(attempt, req, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		// A request body that cannot be rewound cannot be sent again
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
 // Search performs a search operation on the Redmine instance.
 // Search queries the Redmine search API and returns paginated results based on the provided parameters.
-func (c *RestClient) Search(params models.SearchParams) (*models.SearchResults, error) {
-	ctx := context.Background()
-
+func (c *RestClient) Search(ctx context.Context, params models.SearchParams) (*models.SearchResults, error) {
 	// Marshal search parameters using http/querystring for cleaner query construction.
 	queryParams, err := querystring.Marshal(params)
 	if err != nil {
@@ -127,14 +228,14 @@ func (c *RestClient) Search(params models.SearchParams) (*models.SearchResults,
 		return nil, fmt.Errorf("failed to create search request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute search request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("search failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -150,22 +251,24 @@ func (c *RestClient) Search(params models.SearchParams) (*models.SearchResults,
 	return &results, nil
 }
 
-func (c *RestClient) GetIssue(id int) (*models.Issue, error) {
-	ctx := context.Background()
+// issueIncludes are the associations GetIssue requests along with the issue.
+const issueIncludes = "journals,attachments,relations,children,watchers"
 
-	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/issues/%d.json", id), nil)
+// GetIssue retrieves a single issue along with its history, attachments, relations, subtasks and watchers.
+func (c *RestClient) GetIssue(ctx context.Context, id int) (*models.Issue, error) {
+	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/issues/%d.json?include=%s", id, issueIncludes), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GetIssue request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute GetIssue request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GetIssue failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var issueResponse struct {
@@ -178,22 +281,65 @@ func (c *RestClient) GetIssue(id int) (*models.Issue, error) {
 	return &issueResponse.Issue, nil
 }
 
-func (c *RestClient) GetProject(id int) (*models.Project, error) {
-	ctx := context.Background()
+// UpdateIssue changes the fields of an existing issue that are set in params.
+// UpdateIssue adds params.Notes as journal note, along with the changed fields.
+func (c *RestClient) UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error {
+	payload := struct {
+		Issue models.UpdateIssueParams `json:"issue"`
+	}{
+		Issue: params,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UpdateIssue payload: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, "PUT", fmt.Sprintf("/issues/%d.json", id), strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to create UpdateIssue request: %w", err)
+	}
 
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute UpdateIssue request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// GetIssueWithAllowedStatuses retrieves an issue along with the statuses the workflow allows the user to change it to.
+// The allowed statuses include the current status of the issue.
+func (c *RestClient) GetIssueWithAllowedStatuses(ctx context.Context, issueID int) (*models.Issue, error) {
+	var issueResponse struct {
+		Issue models.Issue `json:"issue"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("/issues/%d.json?include=allowed_statuses", issueID), "GetIssueWithAllowedStatuses", &issueResponse); err != nil {
+		return nil, err
+	}
+
+	return &issueResponse.Issue, nil
+}
+
+func (c *RestClient) GetProject(ctx context.Context, id int) (*models.Project, error) {
 	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/projects/%d.json?include=time_entry_activities", id), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GetProject request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute GetProject request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GetProject failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var projectResponse struct {
@@ -206,9 +352,7 @@ func (c *RestClient) GetProject(id int) (*models.Project, error) {
 	return &projectResponse.Project, nil
 }
 
-func (c *RestClient) CreateTimeEntry(params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
-	ctx := context.Background()
-
+func (c *RestClient) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
 	payload := struct {
 		TimeEntry models.CreateTimeEntryParams `json:"time_entry"`
 	}{
@@ -225,14 +369,14 @@ func (c *RestClient) CreateTimeEntry(params models.CreateTimeEntryParams) (*mode
 		return nil, fmt.Errorf("failed to create CreateTimeEntry request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute CreateTimeEntry request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("CreateTimeEntry failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var timeEntryResponse struct {
@@ -245,39 +389,289 @@ func (c *RestClient) CreateTimeEntry(params models.CreateTimeEntryParams) (*mode
 	return &timeEntryResponse.TimeEntry, nil
 }
 
-// SearchIssues searches for issues using advanced filtering including custom fields.
-// SearchIssues queries the Redmine issues API with filtering parameters and returns paginated results.
-func (c *RestClient) SearchIssues(filter models.IssueFilter) (*models.IssueResults, error) {
-	ctx := context.Background()
-
-	// Marshal filter parameters using http/querystring for base parameters
+// ListTimeEntries lists time entries matching the filter.
+// ListTimeEntries returns a single page of results, use IterateTimeEntries to follow all pages.
+func (c *RestClient) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) (*models.TimeEntryResults, error) {
 	queryParams, err := querystring.Marshal(filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal issue filter parameters: %w", err)
+		return nil, fmt.Errorf("failed to marshal time entry filter parameters: %w", err)
 	}
 
-	// Add custom field parameters manually with proper URL encoding
-	customFieldParams := make([]string, 0, len(filter.CustomFields))
-	for fieldID, value := range filter.CustomFields {
-		if value != "" {
-			encodedValue := url.QueryEscape(value)
-			customFieldParams = append(customFieldParams, fmt.Sprintf("cf_%d=%s", fieldID, encodedValue))
-		}
+	timeEntriesPath := "/time_entries.json"
+	if len(queryParams) > 0 {
+		timeEntriesPath += "?" + string(queryParams)
 	}
 
-	// Combine regular and custom field parameters
-	allParams := string(queryParams)
-	if len(customFieldParams) > 0 {
-		if len(allParams) > 0 {
-			allParams += "&"
-		}
-		allParams += strings.Join(customFieldParams, "&")
+	req, err := c.newRequest(ctx, "GET", timeEntriesPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ListTimeEntries request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute ListTimeEntries request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var results models.TimeEntryResults
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode ListTimeEntries response: %w", err)
+	}
+
+	return &results, nil
+}
+
+// GetTimeEntry retrieves a single time entry by its ID.
+func (c *RestClient) GetTimeEntry(ctx context.Context, id int) (*models.TimeEntry, error) {
+	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/time_entries/%d.json", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetTimeEntry request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute GetTimeEntry request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var timeEntryResponse struct {
+		TimeEntry models.TimeEntry `json:"time_entry"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&timeEntryResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode GetTimeEntry response: %w", err)
+	}
+
+	return &timeEntryResponse.TimeEntry, nil
+}
+
+// UpdateTimeEntry changes the fields of an existing time entry that are set in params.
+func (c *RestClient) UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error {
+	payload := struct {
+		TimeEntry models.UpdateTimeEntryParams `json:"time_entry"`
+	}{
+		TimeEntry: params,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal UpdateTimeEntry payload: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, "PUT", fmt.Sprintf("/time_entries/%d.json", id), strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("failed to create UpdateTimeEntry request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute UpdateTimeEntry request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// DeleteTimeEntry removes a time entry.
+func (c *RestClient) DeleteTimeEntry(ctx context.Context, id int) error {
+	req, err := c.newRequest(ctx, "DELETE", fmt.Sprintf("/time_entries/%d.json", id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create DeleteTimeEntry request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute DeleteTimeEntry request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// ListQueries lists the saved issue queries visible to the user, public as well as personal ones.
+// ListQueries returns a single page of results, use IterateQueries to follow all pages.
+func (c *RestClient) ListQueries(ctx context.Context, params models.QueryListParams) (*models.QueryResults, error) {
+	queryParams, err := querystring.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal query list parameters: %w", err)
+	}
+
+	queriesPath := "/queries.json"
+	if len(queryParams) > 0 {
+		queriesPath += "?" + string(queryParams)
+	}
+
+	req, err := c.newRequest(ctx, "GET", queriesPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ListQueries request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute ListQueries request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var results models.QueryResults
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode ListQueries response: %w", err)
+	}
+
+	return &results, nil
+}
+
+// getJSON sends a GET request to path and decodes the JSON response into v.
+// getJSON names the failing operation in its errors with name.
+func (c *RestClient) getJSON(ctx context.Context, path, name string, v any) error {
+	req, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", name, err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s request: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", name, err)
+	}
+
+	return nil
+}
+
+// ListProjects lists the projects visible to the user.
+// ListProjects returns a single page of results, use IterateProjects to follow all pages.
+func (c *RestClient) ListProjects(ctx context.Context, params models.ProjectListParams) (*models.ProjectResults, error) {
+	queryParams, err := querystring.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project list parameters: %w", err)
+	}
+
+	projectsPath := "/projects.json"
+	if len(queryParams) > 0 {
+		projectsPath += "?" + string(queryParams)
+	}
+
+	var results models.ProjectResults
+	if err := c.getJSON(ctx, projectsPath, "ListProjects", &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// ListTrackers lists all trackers of the Redmine instance.
+func (c *RestClient) ListTrackers(ctx context.Context) ([]models.Tracker, error) {
+	var results models.TrackerResults
+	if err := c.getJSON(ctx, "/trackers.json", "ListTrackers", &results); err != nil {
+		return nil, err
+	}
+
+	return results.Trackers, nil
+}
+
+// ListIssueStatuses lists all issue statuses of the Redmine instance.
+func (c *RestClient) ListIssueStatuses(ctx context.Context) ([]models.IssueStatus, error) {
+	var results models.IssueStatusResults
+	if err := c.getJSON(ctx, "/issue_statuses.json", "ListIssueStatuses", &results); err != nil {
+		return nil, err
+	}
+
+	return results.IssueStatuses, nil
+}
+
+// ListTimeEntryActivities lists the time entry activities of the Redmine instance.
+// Unlike the activities included with a project, these tell which activity is the default one.
+func (c *RestClient) ListTimeEntryActivities(ctx context.Context) ([]models.TimeEntryActivity, error) {
+	var results models.TimeEntryActivityResults
+	if err := c.getJSON(ctx, "/enumerations/time_entry_activities.json", "ListTimeEntryActivities", &results); err != nil {
+		return nil, err
+	}
+
+	return results.TimeEntryActivities, nil
+}
+
+// ListCustomFields lists the custom field definitions of the Redmine instance.
+// ListCustomFields requires administrator privileges, Redmine answers 403 Forbidden otherwise.
+func (c *RestClient) ListCustomFields(ctx context.Context) ([]models.CustomField, error) {
+	var results models.CustomFieldResults
+	if err := c.getJSON(ctx, "/custom_fields.json", "ListCustomFields", &results); err != nil {
+		return nil, err
+	}
+
+	return results.CustomFields, nil
+}
+
+// ListVersions lists the versions available to the project, including versions shared by other projects.
+// projectID is the numeric ID or the identifier of the project.
+func (c *RestClient) ListVersions(ctx context.Context, projectID string) ([]models.Version, error) {
+	var results models.VersionResults
+	if err := c.getJSON(ctx, fmt.Sprintf("/projects/%s/versions.json", url.PathEscape(projectID)), "ListVersions", &results); err != nil {
+		return nil, err
+	}
+
+	return results.Versions, nil
+}
+
+// ListMemberships lists the users and groups that are members of the project.
+// ListMemberships returns a single page of results, use IterateMemberships to follow all pages.
+func (c *RestClient) ListMemberships(ctx context.Context, projectID string, params models.MembershipListParams) (*models.MembershipResults, error) {
+	queryParams, err := querystring.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal membership list parameters: %w", err)
+	}
+
+	membershipsPath := fmt.Sprintf("/projects/%s/memberships.json", url.PathEscape(projectID))
+	if len(queryParams) > 0 {
+		membershipsPath += "?" + string(queryParams)
+	}
+
+	var results models.MembershipResults
+	if err := c.getJSON(ctx, membershipsPath, "ListMemberships", &results); err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// SearchIssues searches for issues using advanced filtering including custom fields.
+// SearchIssues queries the Redmine issues API with filtering parameters and returns paginated results.
+func (c *RestClient) SearchIssues(ctx context.Context, filter models.IssueFilter) (*models.IssueResults, error) {
+	// Marshal filter parameters using http/querystring, including the custom fields
+	queryParams, err := querystring.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issue filter parameters: %w", err)
 	}
 
 	// Construct the issues endpoint URL
 	issuesPath := "/issues.json"
-	if len(allParams) > 0 {
-		issuesPath += "?" + allParams
+	if len(queryParams) > 0 {
+		issuesPath += "?" + string(queryParams)
 	}
 
 	req, err := c.newRequest(ctx, "GET", issuesPath, nil)
@@ -285,14 +679,14 @@ func (c *RestClient) SearchIssues(filter models.IssueFilter) (*models.IssueResul
 		return nil, fmt.Errorf("failed to create issues search request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute issues search request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("issues search failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -310,9 +704,7 @@ func (c *RestClient) SearchIssues(filter models.IssueFilter) (*models.IssueResul
 
 // SearchIssuesRaw searches for issues using raw Redmine query string format.
 // This method accepts the exact query string format that Redmine uses in its web interface.
-func (c *RestClient) SearchIssuesRaw(queryString string) (*models.IssueResults, error) {
-	ctx := context.Background()
-
+func (c *RestClient) SearchIssuesRaw(ctx context.Context, queryString string) (*models.IssueResults, error) {
 	// Construct the issues endpoint URL with the raw query string
 	issuesPath := "/issues.json"
 	if queryString != "" {
@@ -324,14 +716,14 @@ func (c *RestClient) SearchIssuesRaw(queryString string) (*models.IssueResults,
 		return nil, fmt.Errorf("failed to create raw issues search request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute raw issues search request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("raw issues search failed with status: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -3,6 +3,7 @@ package redmine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -60,7 +61,7 @@ func TestRestClient_Login(t *testing.T) {
 			statusCode:    http.StatusInternalServerError,
 			responseBody:  `{"errors": ["Internal server error"]}`,
 			expectedError: true,
-			errorContains: "login failed with status: 500",
+			errorContains: "GET /users/current.json failed with status: 500: Internal server error",
 		},
 	}
 
@@ -148,7 +149,7 @@ func TestRestClient_Search(t *testing.T) {
 		Issues: true,
 	}
 
-	results, err := client.Search(searchParams)
+	results, err := client.Search(context.Background(), searchParams)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -178,12 +179,44 @@ func TestRestClient_SearchError(t *testing.T) {
 		Query: "test",
 	}
 
-	_, err := client.Search(searchParams)
+	_, err := client.Search(context.Background(), searchParams)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-	if !contains(err.Error(), "search failed with status: 400") {
-		t.Errorf("expected error to contain 'search failed with status: 400', got '%s'", err.Error())
+	if !contains(err.Error(), "GET /search.json failed with status: 400") {
+		t.Errorf("expected error to contain 'GET /search.json failed with status: 400', got '%s'", err.Error())
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected error to be an APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", apiErr.StatusCode)
+	}
+	if len(apiErr.Messages) != 1 || apiErr.Messages[0] != "Bad request" {
+		t.Errorf("expected messages [Bad request], got %v", apiErr.Messages)
+	}
+}
+
+// TestRestClient_SearchCancelled tests that a cancelled context aborts the request.
+func TestRestClient_SearchCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to reach the server")
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Search(ctx, models.SearchParams{Query: "test"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected error to wrap context.Canceled, got '%s'", err.Error())
 	}
 }
 
@@ -207,3 +240,37 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// TestRestClient_SearchIssues tests that multiple IDs and custom fields of the filter are sent.
+func TestRestClient_SearchIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("status_id"); got != "1|2" {
+			t.Errorf("expected status_id=1|2, got '%s'", got)
+		}
+		if got := query.Get("tracker_id"); got != "3" {
+			t.Errorf("expected tracker_id=3, got '%s'", got)
+		}
+		if got := query.Get("cf_5"); got != "*this week*" {
+			t.Errorf("expected cf_5=*this week*, got '%s'", got)
+		}
+		if query.Has("author_id") {
+			t.Errorf("expected no author_id, got '%s'", query.Get("author_id"))
+		}
+
+		w.Write([]byte(`{"issues": [], "total_count": 0, "offset": 0, "limit": 25}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	filter := models.IssueFilter{
+		Limit:        25,
+		StatusID:     []int{1, 2},
+		TrackerID:    []int{3},
+		CustomFields: map[int]string{5: "*this week*"},
+	}
+	if _, err := client.SearchIssues(context.Background(), filter); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package redmine
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default retry limits used when a BackoffPolicy is created with zero values.
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 500 * time.Millisecond
+	DefaultMaxDelay    = 10 * time.Second
+)
+
+// RetryPolicy decides whether a failed request is retried and how long to wait before doing so.
+type RetryPolicy interface {
+	// Next reports the delay before the next attempt of req after attempt number attempt failed.
+	// Next returns false if the request must not be retried. Either resp or err is set.
+	Next(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// NoRetry is a RetryPolicy that never retries a request.
+type NoRetry struct{}
+
+// Next implements RetryPolicy and always returns false.
+func (NoRetry) Next(int, *http.Request, *http.Response, error) (time.Duration, bool) {
+	return 0, false
+}
+
+// BackoffPolicy retries transient failures with exponential backoff and jitter.
+// BackoffPolicy respects the Retry-After header of 429 and 503 responses and only retries
+// idempotent requests unless RetryNonIdempotent is set.
+type BackoffPolicy struct {
+	MaxAttempts        int           // MaxAttempts is the total number of attempts including the first one
+	BaseDelay          time.Duration // BaseDelay is the delay before the first retry
+	MaxDelay           time.Duration // MaxDelay caps the delay between attempts, longer Retry-After values stop retrying
+	RetryNonIdempotent bool          // RetryNonIdempotent allows retrying requests such as POST and PUT
+}
+
+var _ RetryPolicy = (*BackoffPolicy)(nil)
+
+// NewBackoffPolicy creates a BackoffPolicy with the given limits.
+// NewBackoffPolicy falls back to the default limits for every value that is not positive.
+func NewBackoffPolicy(maxAttempts int, baseDelay, maxDelay time.Duration) *BackoffPolicy {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if baseDelay <= 0 {
+		baseDelay = DefaultBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultMaxDelay
+	}
+
+	return &BackoffPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+	}
+}
+
+// Next implements RetryPolicy.
+func (p *BackoffPolicy) Next(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+
+	if err == nil && !isRetryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	if resp != nil {
+		if delay, ok := retryAfter(resp); ok {
+			return delay, delay <= p.MaxDelay
+		}
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential delay for the given attempt with equal jitter applied.
+func (p *BackoffPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// isIdempotent reports whether requests with the given method may safely be sent twice.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether the status code indicates a transient failure.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header of resp, given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
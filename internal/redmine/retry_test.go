@@ -0,0 +1,181 @@
+package redmine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// newFlakyServer creates a mock server that fails with status for the first failures requests.
+func newFlakyServer(failures, status int, header http.Header, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if *requests <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"time_entry": {"id": 1}}`))
+			return
+		}
+		w.Write([]byte(`{"issue": {"id": 1, "subject": "Test Issue"}}`))
+	}))
+}
+
+// TestRestClient_RetriesTransientFailures tests that idempotent requests are retried on transient failures.
+func TestRestClient_RetriesTransientFailures(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		requests := 0
+		server := newFlakyServer(2, status, nil, &requests)
+
+		client := NewRestClient(server.URL, "test-api-key")
+		client.SetRetryPolicy(NewBackoffPolicy(3, time.Millisecond, 5*time.Millisecond))
+
+		issue, err := client.GetIssue(context.Background(), 1)
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("status %d: expected no error, got %v", status, err)
+		}
+		if issue.Subject != "Test Issue" {
+			t.Errorf("status %d: expected subject 'Test Issue', got '%s'", status, issue.Subject)
+		}
+		if requests != 3 {
+			t.Errorf("status %d: expected 3 requests, got %d", status, requests)
+		}
+	}
+}
+
+// TestRestClient_RetryLimit tests that the client gives up after the configured number of attempts.
+func TestRestClient_RetryLimit(t *testing.T) {
+	requests := 0
+	server := newFlakyServer(10, http.StatusServiceUnavailable, nil, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(2, time.Millisecond, 5*time.Millisecond))
+
+	if _, err := client.GetIssue(context.Background(), 1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+// TestRestClient_NoRetryForPost tests that non-idempotent requests are not retried by default.
+func TestRestClient_NoRetryForPost(t *testing.T) {
+	requests := 0
+	server := newFlakyServer(1, http.StatusServiceUnavailable, nil, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(3, time.Millisecond, 5*time.Millisecond))
+
+	if _, err := client.CreateTimeEntry(context.Background(), models.CreateTimeEntryParams{IssueID: 1}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// TestRestClient_RetryNonIdempotent tests that POST requests are resent with their body when allowed.
+func TestRestClient_RetryNonIdempotent(t *testing.T) {
+	requests := 0
+	server := newFlakyServer(1, http.StatusServiceUnavailable, nil, &requests)
+	defer server.Close()
+
+	policy := NewBackoffPolicy(3, time.Millisecond, 5*time.Millisecond)
+	policy.RetryNonIdempotent = true
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(policy)
+
+	if _, err := client.CreateTimeEntry(context.Background(), models.CreateTimeEntryParams{IssueID: 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+// TestRestClient_RetryAfter tests that the Retry-After header determines the delay between attempts.
+func TestRestClient_RetryAfter(t *testing.T) {
+	requests := 0
+	header := http.Header{"Retry-After": []string{"1"}}
+	server := newFlakyServer(1, http.StatusTooManyRequests, header, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(3, time.Millisecond, 5*time.Second))
+
+	start := time.Now()
+	if _, err := client.GetIssue(context.Background(), 1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait at least 1s, waited %v", elapsed)
+	}
+}
+
+// TestRestClient_RetryAfterExceedsMaxDelay tests that a Retry-After longer than the maximum delay stops retrying.
+func TestRestClient_RetryAfterExceedsMaxDelay(t *testing.T) {
+	requests := 0
+	header := http.Header{"Retry-After": []string{"120"}}
+	server := newFlakyServer(1, http.StatusServiceUnavailable, header, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(3, time.Millisecond, time.Second))
+
+	if _, err := client.GetIssue(context.Background(), 1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// TestRestClient_RetryCancelled tests that cancelling the context aborts the wait between attempts.
+func TestRestClient_RetryCancelled(t *testing.T) {
+	requests := 0
+	server := newFlakyServer(10, http.StatusServiceUnavailable, nil, &requests)
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+	client.SetRetryPolicy(NewBackoffPolicy(5, time.Second, 5*time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.GetIssue(ctx, 1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected cancellation to abort the backoff, waited %v", elapsed)
+	}
+}
+
+// TestBackoffPolicy_Backoff tests that the backoff grows exponentially and stays within the maximum delay.
+func TestBackoffPolicy_Backoff(t *testing.T) {
+	policy := NewBackoffPolicy(10, 100*time.Millisecond, time.Second)
+
+	for attempt := 1; attempt <= 8; attempt++ {
+		want := min(100*time.Millisecond<<(attempt-1), time.Second)
+		got := policy.backoff(attempt)
+		if got < want/2 || got > want {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, got, want/2, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package redmine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TextFormat is the text formatting of a Redmine instance, used by issue descriptions and notes.
+type TextFormat int
+
+const (
+	TextFormatAuto     TextFormat = iota // TextFormatAuto detects the formatting of every text
+	TextFormatTextile                    // TextFormatTextile is the Textile formatting, the default of older Redmine instances
+	TextFormatMarkdown                   // TextFormatMarkdown is the CommonMark formatting
+)
+
+// ParseTextFormat parses the name of a text formatting as used in the Redmine settings.
+// An empty name selects TextFormatAuto.
+func ParseTextFormat(name string) (TextFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "auto":
+		return TextFormatAuto, nil
+	case "textile":
+		return TextFormatTextile, nil
+	case "markdown", "common_mark", "commonmark":
+		return TextFormatMarkdown, nil
+	}
+	return TextFormatAuto, fmt.Errorf("unknown text formatting %q, expected textile or common_mark", name)
+}
+
+// String returns the name of the formatting as used in the Redmine settings.
+func (f TextFormat) String() string {
+	switch f {
+	case TextFormatTextile:
+		return "textile"
+	case TextFormatMarkdown:
+		return "common_mark"
+	}
+	return "auto"
+}
@@ -0,0 +1,23 @@
+package redmine
+
+import "testing"
+
+// TestParseTextFormat verifies the names of the Redmine text formattings.
+func TestParseTextFormat(t *testing.T) {
+	tests := map[string]TextFormat{
+		"":            TextFormatAuto,
+		"textile":     TextFormatTextile,
+		"common_mark": TextFormatMarkdown,
+		"Markdown":    TextFormatMarkdown,
+	}
+	for name, want := range tests {
+		got, err := ParseTextFormat(name)
+		if err != nil || got != want {
+			t.Errorf("ParseTextFormat(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseTextFormat("html"); err == nil {
+		t.Error("expected an error for an unknown formatting")
+	}
+}
@@ -0,0 +1,164 @@
+package redmine
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRestClient_ListTimeEntries tests that filters are sent as query parameters.
+func TestRestClient_ListTimeEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/time_entries.json" {
+			t.Errorf("expected path '/time_entries.json', got '%s'", r.URL.Path)
+		}
+
+		query := r.URL.Query()
+		want := map[string]string{
+			"user_id":     "me",
+			"project_id":  "rmt",
+			"issue_id":    "123",
+			"from":        "2025-08-11",
+			"to":          "2025-08-17",
+			"activity_id": "9",
+		}
+		for k, v := range want {
+			if query.Get(k) != v {
+				t.Errorf("expected %s=%s, got '%s'", k, v, query.Get(k))
+			}
+		}
+		if query.Has("offset") {
+			t.Errorf("expected offset to be omitted, got '%s'", query.Get("offset"))
+		}
+
+		w.Write([]byte(`{"time_entries": [{"id": 1, "hours": 1.5, "project": {"id": 2, "name": "RMT"}}], "total_count": 1, "offset": 0, "limit": 25}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	results, err := client.ListTimeEntries(context.Background(), models.TimeEntryFilter{
+		UserID:     "me",
+		ProjectID:  "rmt",
+		IssueID:    123,
+		From:       "2025-08-11",
+		To:         "2025-08-17",
+		ActivityID: 9,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results.TimeEntries) != 1 || results.TimeEntries[0].Hours != 1.5 {
+		t.Fatalf("unexpected time entries: %+v", results.TimeEntries)
+	}
+	if results.TimeEntries[0].Project.Name != "RMT" {
+		t.Errorf("expected project 'RMT', got '%s'", results.TimeEntries[0].Project.Name)
+	}
+}
+
+// TestRestClient_GetTimeEntry tests retrieving a single time entry.
+func TestRestClient_GetTimeEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/time_entries/7.json" {
+			t.Errorf("expected path '/time_entries/7.json', got '%s'", r.URL.Path)
+		}
+		w.Write([]byte(`{"time_entry": {"id": 7, "hours": 2, "comments": "Review"}}`))
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	entry, err := client.GetTimeEntry(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if entry.ID != 7 || entry.Comments != "Review" {
+		t.Errorf("unexpected time entry: %+v", entry)
+	}
+}
+
+// TestRestClient_UpdateTimeEntry tests that only the set fields are sent with PUT.
+func TestRestClient_UpdateTimeEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected method PUT, got '%s'", r.Method)
+		}
+		if r.URL.Path != "/time_entries/7.json" {
+			t.Errorf("expected path '/time_entries/7.json', got '%s'", r.URL.Path)
+		}
+
+		var payload struct {
+			TimeEntry map[string]any `json:"time_entry"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+		if len(payload.TimeEntry) != 2 || payload.TimeEntry["hours"] != 0.75 || payload.TimeEntry["comments"] != "Fixed" {
+			t.Errorf("unexpected payload: %v", payload.TimeEntry)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	comments := "Fixed"
+	err := client.UpdateTimeEntry(context.Background(), 7, models.UpdateTimeEntryParams{Hours: 0.75, Comments: &comments})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestRestClient_UpdateTimeEntry_ClearComments tests that an emptied comment is sent, so Redmine clears it.
+func TestRestClient_UpdateTimeEntry_ClearComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		if !strings.Contains(string(body), `"comments":""`) {
+			t.Errorf("expected the empty comment in the payload, got %s", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	empty := ""
+	if err := client.UpdateTimeEntry(context.Background(), 7, models.UpdateTimeEntryParams{Hours: 1, Comments: &empty}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestRestClient_DeleteTimeEntry tests deleting a time entry and the error for a missing one.
+func TestRestClient_DeleteTimeEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected method DELETE, got '%s'", r.Method)
+		}
+		if r.URL.Path == "/time_entries/8.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, "test-api-key")
+
+	if err := client.DeleteTimeEntry(context.Background(), 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.DeleteTimeEntry(context.Background(), 8); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+// Package timer provides a stopwatch for tracking the time spent on an issue.
+// The running timer is persisted to disk so it survives restarts and crashes, stopped timers are kept
+// until their time has been logged, so the measured time is never lost.
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrRunning is returned when a timer is started while another one is still running.
+var ErrRunning = errors.New("a timer is already running")
+
+// Timer is a stopwatch running for an issue.
+type Timer struct {
+	Profile   string    `json:"profile,omitempty"`   // Profile is the Redmine instance the issue belongs to
+	IssueID   int       `json:"issue_id"`            // IssueID is the issue the time is tracked for
+	Title     string    `json:"title"`               // Title is the issue title shown while the timer runs
+	StartedAt time.Time `json:"started_at"`          // StartedAt is the moment the timer was started
+	StoppedAt time.Time `json:"stopped_at,omitzero"` // StoppedAt is the moment the timer was stopped, zero while it runs
+}
+
+// Elapsed returns the time that passed between starting the timer and now, or stopping it if it has been stopped.
+func (t *Timer) Elapsed(now time.Time) time.Duration {
+	if !t.StoppedAt.IsZero() {
+		now = t.StoppedAt
+	}
+	if now.Before(t.StartedAt) {
+		return 0
+	}
+	return now.Sub(t.StartedAt)
+}
+
+// state is the content of the timer file.
+type state struct {
+	Running *Timer  `json:"running,omitempty"` // Running is the running timer, nil if none is running
+	Stopped []Timer `json:"stopped,omitempty"` // Stopped are the stopped timers whose time has not been logged yet
+}
+
+// Store persists the single running timer and the stopped timers waiting to be logged in a JSON file.
+// Store is safe for concurrent use.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a Store that keeps the running timer in the file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the location of the timer file below XDG_STATE_HOME.
+// DefaultPath falls back to ~/.local/state if XDG_STATE_HOME is not set.
+func DefaultPath() (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		stateHome = filepath.Join(homeDir, ".local", "state")
+	}
+
+	return filepath.Join(stateHome, "rmt", "timer.json"), nil
+}
+
+// Current returns the running timer.
+// Current returns nil without an error if no timer is running.
+func (s *Store) Current() (*Timer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return st.Running, nil
+}
+
+// Stopped returns the stopped timers whose time has not been logged yet, oldest first.
+func (s *Store) Stopped() ([]Timer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return st.Stopped, nil
+}
+
+// Start starts the given timer.
+// Start returns ErrRunning if a timer is already running, it has to be stopped first.
+func (s *Store) Start(t Timer) (*Timer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if st.Running != nil {
+		return nil, fmt.Errorf("%w for issue #%d", ErrRunning, st.Running.IssueID)
+	}
+
+	st.Running = &t
+	if err := s.save(st); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Stop stops the running timer and returns it.
+// The stopped timer is kept until Remove is called, once its time has been logged or the user discarded it.
+// Stop returns nil without an error if no timer is running.
+func (s *Store) Stop() (*Timer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil || st.Running == nil {
+		return nil, err
+	}
+
+	stopped := *st.Running
+	stopped.StoppedAt = time.Now()
+	st.Running = nil
+	st.Stopped = append(st.Stopped, stopped)
+	if err := s.save(st); err != nil {
+		return nil, err
+	}
+
+	return &stopped, nil
+}
+
+// Remove forgets the stopped timer t, once its time has been logged or the user discarded it.
+// Remove is a no-op if t has been removed already.
+func (s *Store) Remove(t Timer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	st.Stopped = slices.DeleteFunc(st.Stopped, func(stopped Timer) bool {
+		return stopped.Profile == t.Profile && stopped.IssueID == t.IssueID && stopped.StartedAt.Equal(t.StartedAt)
+	})
+	return s.save(st)
+}
+
+// load reads the running and stopped timers from disk.
+// load also reads files written by earlier versions, which only contained the running timer.
+func (s *Store) load() (state, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state{}, nil
+	}
+	if err != nil {
+		return state{}, fmt.Errorf("failed to read timer file: %w", err)
+	}
+
+	var file struct {
+		state
+		Timer // Timer is the running timer as written by earlier versions
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return state{}, fmt.Errorf("failed to decode timer file: %w", err)
+	}
+	if file.Running == nil && file.IssueID != 0 {
+		file.Running = &file.Timer
+	}
+
+	return file.state, nil
+}
+
+// save writes the timers to disk, or removes the file if no timer is left.
+// save writes to a temporary file first, so a crash never leaves a truncated timer file behind.
+func (s *Store) save(st state) error {
+	if st.Running == nil && len(st.Stopped) == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove timer file: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to encode timer: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create timer directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, ".timer-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create timer file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write timer file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write timer file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write timer file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write timer file: %w", err)
+	}
+
+	return nil
+}
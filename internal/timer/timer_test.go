@@ -0,0 +1,117 @@
+package timer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStore_StartStop verifies that a started timer is persisted and kept after stopping until it is removed
+func TestStore_StartStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rmt", "timer.json")
+	started := time.Date(2025, 8, 11, 9, 0, 0, 0, time.UTC)
+
+	store := NewStore(path)
+	if _, err := store.Start(Timer{Profile: "work", IssueID: 123, Title: "Fix login", StartedAt: started}); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+
+	// A new store reads the timer from disk, as it would after a restart
+	current, err := NewStore(path).Current()
+	if err != nil {
+		t.Fatalf("Current returned error: %v", err)
+	}
+	if current == nil || current.Profile != "work" || current.IssueID != 123 || current.Title != "Fix login" || !current.StartedAt.Equal(started) {
+		t.Fatalf("Current() = %+v, want timer for issue 123 started at %v", current, started)
+	}
+
+	stopped, err := store.Stop()
+	if err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if stopped == nil || stopped.IssueID != 123 || stopped.StoppedAt.IsZero() {
+		t.Errorf("Stop() = %+v, want stopped timer for issue 123", stopped)
+	}
+
+	current, err = store.Current()
+	if err != nil || current != nil {
+		t.Errorf("Current() = %+v, %v, want nil, nil", current, err)
+	}
+
+	// The stopped timer survives a restart until its time has been logged
+	pending, err := NewStore(path).Stopped()
+	if err != nil {
+		t.Fatalf("Stopped returned error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].IssueID != 123 || !pending[0].StoppedAt.Equal(stopped.StoppedAt) {
+		t.Fatalf("Stopped() = %+v, want the timer for issue 123", pending)
+	}
+	if got := pending[0].Elapsed(stopped.StoppedAt.Add(time.Hour)); got != stopped.Elapsed(stopped.StoppedAt) {
+		t.Errorf("Elapsed after stopping = %v, want %v", got, stopped.Elapsed(stopped.StoppedAt))
+	}
+
+	if err := store.Remove(pending[0]); err != nil {
+		t.Fatalf("Remove returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected timer file to be removed, stat returned %v", err)
+	}
+}
+
+// TestStore_LegacyFile verifies that a running timer written by earlier versions is still read
+func TestStore_LegacyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timer.json")
+	if err := os.WriteFile(path, []byte(`{"profile":"work","issue_id":7,"title":"Legacy","started_at":"2025-08-11T09:00:00Z"}`), 0o600); err != nil {
+		t.Fatalf("failed to write timer file: %v", err)
+	}
+
+	current, err := NewStore(path).Current()
+	if err != nil {
+		t.Fatalf("Current returned error: %v", err)
+	}
+	if current == nil || current.IssueID != 7 || current.Title != "Legacy" {
+		t.Errorf("Current() = %+v, want timer for issue 7", current)
+	}
+}
+
+// TestStore_SingleTimer verifies that only one timer can run at a time
+func TestStore_SingleTimer(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "timer.json"))
+
+	if _, err := store.Start(Timer{IssueID: 1, Title: "First", StartedAt: time.Now()}); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if _, err := store.Start(Timer{IssueID: 2, Title: "Second", StartedAt: time.Now()}); !errors.Is(err, ErrRunning) {
+		t.Errorf("Start error = %v, want ErrRunning", err)
+	}
+
+	if _, err := store.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if _, err := store.Start(Timer{IssueID: 2, Title: "Second", StartedAt: time.Now()}); err != nil {
+		t.Errorf("Start after Stop returned error: %v", err)
+	}
+}
+
+// TestStore_StopWithoutTimer verifies that stopping without a running timer is not an error
+func TestStore_StopWithoutTimer(t *testing.T) {
+	stopped, err := NewStore(filepath.Join(t.TempDir(), "timer.json")).Stop()
+	if err != nil || stopped != nil {
+		t.Errorf("Stop() = %+v, %v, want nil, nil", stopped, err)
+	}
+}
+
+// TestTimer_Elapsed verifies the elapsed time and that a clock set back never yields a negative duration
+func TestTimer_Elapsed(t *testing.T) {
+	started := time.Date(2025, 8, 11, 9, 0, 0, 0, time.UTC)
+	timer := &Timer{StartedAt: started}
+
+	if got := timer.Elapsed(started.Add(90 * time.Minute)); got != 90*time.Minute {
+		t.Errorf("Elapsed = %v, want 1h30m", got)
+	}
+	if got := timer.Elapsed(started.Add(-time.Minute)); got != 0 {
+		t.Errorf("Elapsed = %v, want 0", got)
+	}
+}
@@ -1,9 +1,16 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/timer"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
 	"github.com/b1tray3r/rmt/internal/tui/messages"
 	"github.com/b1tray3r/rmt/internal/tui/themes"
@@ -18,6 +25,10 @@ const (
 	ListView
 	IssueView
 	TimeLogView
+	TimesheetView
+	TimeEntryListView
+	FilterBuilderView
+	OutboxView
 )
 
 type Application struct {
@@ -26,34 +37,447 @@ type Application struct {
 	currentView int
 	views       map[int]views.View
 
-	issueService *domain.RedmineIssueRepository
-	config       *config.Config
+	issueService  *domain.RedmineIssueRepository
+	newRepository RepositoryFactory
+	config        *config.Config
+
+	// cancel aborts the request started by the application that is still in flight.
+	cancel context.CancelFunc
+
+	// returnViews maps views that can be opened from several places to the view to go back to.
+	returnViews map[int]int
+
+	// profileErr is the reason the last profile switch failed, shown in the header until the next switch.
+	profileErr error
+	// switchingProfile is the profile whose token is being resolved in the background, empty if none is.
+	switchingProfile string
+	// skippedProfile is the last profile that failed to resolve, the next switch continues after it.
+	skippedProfile string
+
+	timers   *timer.Store
+	timer    *timer.Timer  // timer is the running timer shown in the header, nil if none is running
+	stopped  []timer.Timer // stopped are the stopped timers whose time has not been logged yet
+	timerErr error         // timerErr is the last failure of the timer, shown in the header until the next action
+	ticking  bool          // ticking reports whether timerTickMsg are scheduled to refresh the header
+
+	// submission summarizes a time entry submission that completed after the user left its view, until the next key press.
+	submission string
+
+	refreshing bool // refreshing reports whether a cacheRefreshMsg is scheduled to check whether Redmine is back
+
+	outbox   *outbox.Store
+	queued   int  // queued is the number of time entries of the active profile waiting in the outbox
+	flushing bool // flushing reports whether the outbox is being sent in the background
+}
+
+// RepositoryFactory creates the repository talking to the given Redmine instance.
+// The application uses it to reconnect when the user switches profiles.
+type RepositoryFactory func(cfg config.RedmineConfig) *domain.RedmineIssueRepository
+
+// timerTickMsg refreshes the elapsed time of the running timer in the header.
+type timerTickMsg struct{}
+
+// cacheRefreshInterval is the time between two checks whether Redmine is reachable again.
+const cacheRefreshInterval = 30 * time.Second
+
+// cacheRefreshMsg checks whether Redmine is reachable again while the application shows cached data.
+type cacheRefreshMsg struct{}
+
+// cacheRefreshedMsg reports whether Redmine was reachable and the cache has been refreshed.
+type cacheRefreshedMsg struct {
+	Online bool
+}
+
+// outboxFlushInterval is the time between two attempts to send the time entries waiting in the outbox.
+const outboxFlushInterval = time.Minute
+
+// outboxFlushMsg sends the time entries waiting in the outbox in the background.
+type outboxFlushMsg struct{}
+
+// outboxFlushedMsg reports the outcome of sending the outbox in the background.
+type outboxFlushedMsg struct {
+	Results []outbox.Result
+	Error   error
+}
+
+// profileResolvedMsg reports the outcome of resolving the token of the profile to switch to.
+type profileResolvedMsg struct {
+	Name    string
+	Profile config.RedmineConfig
+	Error   error
 }
 
-// NewApplication creates and returns a new Application instance.
-func NewApplication(issueService *domain.RedmineIssueRepository, cfg *config.Config) *Application {
-	searchView := views.NewSearchView(75, cfg)
+// timerErrorMsg reports a timer failure that happened outside of the update loop.
+type timerErrorMsg struct {
+	Error error
+}
+
+// NewApplication creates and returns a new Application instance for the active profile of cfg.
+// NewApplication resumes the timer persisted in timers, if one is still running, and the stopped timers not logged yet.
+// Time entries that cannot be sent wait in queue and are sent in the background once Redmine is reachable.
+func NewApplication(cfg *config.Config, newRepository RepositoryFactory, timers *timer.Store, queue *outbox.Store) *Application {
+	issueService := newRepository(cfg.Redmine)
+	searchView := views.NewSearchView(75, cfg, issueService)
 	searchView.InitializeFavorites()
 
-	return &Application{
+	current, err := timers.Current()
+
+	a := &Application{
 		width:  75,
 		height: 0,
 		views: map[int]views.View{
 			SearchView: searchView,
 		},
-		issueService: issueService,
-		config:       cfg,
+		issueService:  issueService,
+		newRepository: newRepository,
+		config:        cfg,
+		returnViews:   make(map[int]int),
+		timers:        timers,
+		timer:         current,
+		timerErr:      err,
+		outbox:        queue,
 	}
+	a.loadStopped()
+	a.countQueued()
+
+	return a
+}
+
+// switchProfile resolves the token of the next configured profile in the background.
+// switchProfile continues after the profile that failed last, so a broken profile does not block the rotation.
+// switchProfile returns nil while a switch is in progress or no other profile is left to try.
+func (a *Application) switchProfile() tea.Cmd {
+	if a.switchingProfile != "" {
+		return nil
+	}
+
+	after := a.config.Profile
+	if a.skippedProfile != "" {
+		after = a.skippedProfile
+	}
+	name := a.config.ProfileAfter(after)
+	if name == "" || name == a.config.Profile {
+		// Every other profile has failed, the next switch starts over
+		a.skippedProfile = ""
+		return nil
+	}
+
+	a.profileErr = nil
+	a.switchingProfile = name
+	cfg := a.config
+	return func() tea.Msg {
+		// The token command gets no input, the terminal belongs to the UI
+		profile, err := cfg.ResolveProfile(name)
+		return profileResolvedMsg{Name: name, Profile: profile, Error: err}
+	}
+}
+
+// useProfile connects to the resolved profile and starts over with an empty search.
+// useProfile aborts all requests sent to the previous Redmine instance.
+func (a *Application) useProfile(msg profileResolvedMsg) tea.Cmd {
+	a.switchingProfile = ""
+	if msg.Error != nil {
+		// The current profile stays active, the next switch skips the failed one
+		a.profileErr = msg.Error
+		a.skippedProfile = msg.Name
+		return nil
+	}
+
+	a.skippedProfile = ""
+	a.switchView(SearchView)
+	a.config.SetProfile(msg.Name, msg.Profile)
+	a.issueService = a.newRepository(a.config.Redmine)
+
+	searchView := views.NewSearchView(a.width, a.config, a.issueService)
+	searchView.InitializeFavorites()
+	searchView.SetSize(a.width, a.height)
+
+	a.views = map[int]views.View{
+		SearchView: searchView,
+	}
+	a.returnViews = make(map[int]int)
+	a.countQueued()
+
+	return tea.Batch(searchView.Init(), a.flushOutbox())
 }
 
 // Init initializes the Application and returns the initial command.
 func (a *Application) Init() tea.Cmd {
 	return tea.Batch(
 		a.views[a.currentView].Init(),
+		a.tickTimer(),
+		a.flushOutbox(),
+		a.scheduleFlush(),
 	)
 }
 
-func (a *Application) searchIssues(query string) tea.Cmd {
+// tickTimer schedules the next refresh of the header while a timer is running.
+// tickTimer returns nil if no timer is running or a refresh is already scheduled.
+func (a *Application) tickTimer() tea.Cmd {
+	if a.timer == nil || a.ticking {
+		return nil
+	}
+
+	a.ticking = true
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return timerTickMsg{}
+	})
+}
+
+// scheduleRefresh schedules the next check whether Redmine is reachable again while it is not.
+// scheduleRefresh returns nil if Redmine is reachable or a check is already scheduled.
+func (a *Application) scheduleRefresh() tea.Cmd {
+	if a.refreshing || !a.issueService.Offline() {
+		return nil
+	}
+
+	a.refreshing = true
+	return tea.Tick(cacheRefreshInterval, func(time.Time) tea.Msg {
+		return cacheRefreshMsg{}
+	})
+}
+
+// refreshCache returns a command refreshing the cache in the background if Redmine is reachable again.
+// The refresh is not bound to the current view, so switching views does not abort it.
+func (a *Application) refreshCache() tea.Cmd {
+	repo := a.issueService
+	return func() tea.Msg {
+		// Entries that could not be refreshed are tried again once they are used
+		online, _ := repo.Refresh(context.Background())
+		return cacheRefreshedMsg{Online: online}
+	}
+}
+
+// countQueued updates the number of time entries waiting in the outbox shown in the header.
+// countQueued keeps the previous number if the outbox cannot be read.
+func (a *Application) countQueued() {
+	if entries, err := a.outbox.List(a.config.Profile); err == nil {
+		a.queued = len(entries)
+	}
+}
+
+// scheduleFlush schedules the next attempt to send the time entries waiting in the outbox.
+func (a *Application) scheduleFlush() tea.Cmd {
+	return tea.Tick(outboxFlushInterval, func(time.Time) tea.Msg {
+		return outboxFlushMsg{}
+	})
+}
+
+// flushOutbox returns a command sending the time entries waiting in the outbox in the background.
+// flushOutbox returns nil if the outbox is empty or already being sent.
+func (a *Application) flushOutbox() tea.Cmd {
+	if a.flushing || a.queued == 0 {
+		return nil
+	}
+
+	a.flushing = true
+	store := a.outbox
+	repo := a.issueService
+	profile := a.config.Profile
+	return func() tea.Msg {
+		// The flush is not bound to the current view, so switching views does not abort it
+		results, err := store.Flush(context.Background(), repo, profile)
+		return outboxFlushedMsg{Results: results, Error: err}
+	}
+}
+
+// openOutbox shows the time entries waiting in the outbox and remembers the view to return to.
+func (a *Application) openOutbox() tea.Cmd {
+	if a.currentView == OutboxView {
+		return nil
+	}
+
+	a.openFrom(OutboxView)
+	ov := views.NewOutboxView(a.width, a.height, a.outbox, a.config.Profile, a.issueService)
+	a.views[OutboxView] = ov
+	return ov.Init()
+}
+
+// toggleTimer starts the timer for issue, or stops it if it is already running for issue.
+// toggleTimer stops a timer running for another issue first and opens the time entry form for it.
+func (a *Application) toggleTimer(issue *domain.Issue) tea.Cmd {
+	a.timerErr = nil
+	if !a.ownsTimer() {
+		return nil
+	}
+
+	stopped, err := a.timers.Stop()
+	if err != nil {
+		a.timerErr = err
+		return nil
+	}
+	a.timer = nil
+	a.loadStopped()
+
+	var cmds []tea.Cmd
+	if stopped != nil {
+		cmds = append(cmds, a.logTimer(stopped, issue))
+	}
+
+	if stopped == nil || stopped.IssueID != issue.ID() {
+		started, err := a.timers.Start(timer.Timer{
+			Profile:   a.config.Profile,
+			IssueID:   issue.ID(),
+			Title:     issue.FullTitle(),
+			StartedAt: time.Now(),
+		})
+		if err != nil {
+			a.timerErr = err
+		} else {
+			a.timer = started
+			cmds = append(cmds, a.tickTimer())
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// stopTimer stops the running timer and opens the time entry form for its issue.
+// stopTimer opens the form for the oldest stopped timer not logged yet if no timer is running.
+func (a *Application) stopTimer() tea.Cmd {
+	a.timerErr = nil
+	if !a.ownsTimer() {
+		return nil
+	}
+
+	stopped, err := a.timers.Stop()
+	if err != nil {
+		a.timerErr = err
+		return nil
+	}
+	a.timer = nil
+	a.loadStopped()
+
+	if stopped == nil {
+		pending := a.pendingTimers()
+		if len(pending) == 0 {
+			return nil
+		}
+		stopped = &pending[0]
+	}
+	return a.logTimer(stopped, nil)
+}
+
+// loadStopped reads the stopped timers whose time has not been logged yet.
+func (a *Application) loadStopped() {
+	stopped, err := a.timers.Stopped()
+	if err != nil {
+		a.timerErr = err
+		return
+	}
+	a.stopped = stopped
+}
+
+// pendingTimers returns the stopped timers of the active profile whose time has not been logged yet.
+func (a *Application) pendingTimers() []timer.Timer {
+	var pending []timer.Timer
+	for _, t := range a.stopped {
+		if t.Profile == a.config.Profile {
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
+
+// ownsTimer reports whether the running timer, if any, belongs to the active profile.
+// ownsTimer reports an error otherwise, as the issue ID would refer to an issue of another Redmine instance.
+func (a *Application) ownsTimer() bool {
+	if a.timer == nil || a.timer.Profile == a.config.Profile {
+		return true
+	}
+
+	a.timerErr = fmt.Errorf("timer for #%d runs in profile %q, switch profiles with alt+p to stop it", a.timer.IssueID, a.timer.Profile)
+	return false
+}
+
+// logTimer opens the time entry form prefilled with the time measured by the stopped timer.
+// logTimer loads the issue of the timer from Redmine unless it is the given issue.
+// The stopped timer is kept until the time entry has been saved, so failures and leaving the form lose no time.
+func (a *Application) logTimer(stopped *timer.Timer, issue *domain.Issue) tea.Cmd {
+	hours := views.QuarterHours(stopped.Elapsed(time.Now()))
+
+	if issue != nil && issue.ID() == stopped.IssueID {
+		return func() tea.Msg {
+			return messages.TimeEntryCreateMsg{Issue: issue, Hours: hours, Timer: stopped}
+		}
+	}
+
+	ctx := a.requestContext()
+	repo := a.issueService
+	return func() tea.Msg {
+		loaded, err := repo.GetIssue(ctx, stopped.IssueID)
+		if err != nil {
+			return timerErrorMsg{
+				Error: fmt.Errorf("timer for #%d stopped after %.2fh, but the issue could not be loaded, alt+s: try again: %w", stopped.IssueID, hours, err),
+			}
+		}
+		return messages.TimeEntryCreateMsg{Issue: loaded, Hours: hours, Timer: stopped}
+	}
+}
+
+// deliverSubmission passes the result of a time entry submission to the view that sent it.
+// The user may have moved on meanwhile, the header tells them how the submission ended then.
+func (a *Application) deliverSubmission(view *views.TimeEntryView, msg tea.Msg) tea.Cmd {
+	if view == nil {
+		return nil
+	}
+
+	cmd := view.Update(msg)
+	if current, ok := a.views[a.currentView].(*views.TimeEntryView); !ok || current != view {
+		a.submission = view.Outcome()
+	}
+	return cmd
+}
+
+// requestContext returns a new context for a request started by the application.
+// requestContext cancels the previous request, as only one request is in flight at a time.
+func (a *Application) requestContext() context.Context {
+	a.cancelRequest()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	return ctx
+}
+
+// cancelRequest aborts the request that is currently in flight, if any.
+func (a *Application) cancelRequest() {
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+}
+
+// switchView makes the given view the current one.
+// switchView aborts all requests belonging to the view that is left.
+func (a *Application) switchView(view int) {
+	if view == a.currentView {
+		return
+	}
+
+	a.cancelRequest()
+	if c, ok := a.views[a.currentView].(views.Canceler); ok {
+		c.Cancel()
+	}
+	a.currentView = view
+}
+
+// textFormat returns the text formatting of the active Redmine instance.
+func (a *Application) textFormat() redmine.TextFormat {
+	// The config has been validated, so the formatting is known
+	format, _ := redmine.ParseTextFormat(a.config.Redmine.TextFormatting)
+	return format
+}
+
+// resumeView returns the command restarting the requests the current view was waiting for when it was left.
+func (a *Application) resumeView() tea.Cmd {
+	if r, ok := a.views[a.currentView].(views.Resumer); ok {
+		return r.Resume()
+	}
+	return nil
+}
+
+func (a *Application) searchIssues(ctx context.Context, query string) tea.Cmd {
+	repo := a.issueService
 	return func() tea.Msg {
 		var results []*domain.Issue
 		var err error
@@ -61,10 +485,15 @@ func (a *Application) searchIssues(query string) tea.Cmd {
 		// Determine if this is a Redmine query string (contains = or &) or a regular search
 		if strings.Contains(query, "=") || strings.Contains(query, "&") {
 			// Use filter search for Redmine query strings (e.g., from favorites)
-			results, err = a.issueService.SearchWithFilter(query)
+			results, err = repo.SearchWithFilter(ctx, query)
 		} else {
 			// Use regular search for text queries
-			results, err = a.issueService.Search(query)
+			results, err = repo.Search(ctx, query)
+		}
+
+		// Results of a search that was cancelled meanwhile must not replace the current view.
+		if ctx.Err() != nil {
+			err = ctx.Err()
 		}
 
 		if err != nil {
@@ -74,8 +503,38 @@ func (a *Application) searchIssues(query string) tea.Cmd {
 	}
 }
 
+// openFrom switches to view and remembers the current view to go back to on ESC.
+func (a *Application) openFrom(view int) {
+	returnView := a.currentView
+	if returnView == LoadingView {
+		returnView = SearchView
+	}
+
+	a.returnViews[view] = returnView
+	a.switchView(view)
+}
+
+// openTimesheet shows the weekly timesheet and remembers the view to return to.
+func (a *Application) openTimesheet() tea.Cmd {
+	if a.currentView == TimesheetView {
+		return nil
+	}
+
+	a.openFrom(TimesheetView)
+	tv := views.NewTimesheetView(a.width, a.height, a.issueService)
+	a.views[TimesheetView] = tv
+	return tv.Init()
+}
+
 // Update handles incoming messages and updates the Application's state.
+// Update starts checking whether Redmine is back once a request found it unreachable.
 func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := a.update(msg)
+	return model, tea.Batch(cmd, a.scheduleRefresh())
+}
+
+// update updates the Application based on the incoming message.
+func (a *Application) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		a.width = 75
@@ -93,18 +552,39 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		a.submission = ""
+		// Favorites cannot be bound to these keys, keep config.ReservedHotkeys in sync
 		switch msg.String() {
 		case "ctrl+c":
 			return a, tea.Quit
 		case "alt+f":
-			a.currentView = SearchView
+			a.switchView(SearchView)
 
 			var cmd tea.Cmd
 			if a.currentView == SearchView {
 				cmd = a.views[SearchView].Update(msg)
 			}
 			return a, cmd
+		case "alt+w":
+			return a, a.openTimesheet()
+		case "alt+s":
+			return a, a.stopTimer()
+		case "alt+p":
+			return a, a.switchProfile()
+		case "alt+o":
+			return a, a.openOutbox()
 		case "esc":
+			// Views with an open input or dialog close it first
+			if e, ok := a.views[a.currentView].(views.Editor); ok && e.IsEditing() {
+				return a, a.views[a.currentView].Update(msg)
+			}
+
+			// Views that can be opened from several places return to where they were opened
+			if returnView, ok := a.returnViews[a.currentView]; ok {
+				a.switchView(returnView)
+				return a, a.resumeView()
+			}
+
 			// Handle ESC for navigation, but ignore it completely in SearchView
 			if a.currentView != SearchView {
 				// ESC should navigate back to previous view
@@ -120,15 +600,100 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					newIndex = SearchView
 				}
 
-				// Change to the target view
-				a.currentView = newIndex
-				return a, nil
+				// Change to the target view, cancelling whatever the current view is waiting for
+				a.switchView(newIndex)
+				return a, a.resumeView()
 			}
-			// If we're in SearchView, ignore ESC completely - don't pass it to the view
+			// If we're in SearchView, ignore ESC
 			return a, nil
 		}
+	case views.TimeEntrySubmissionSuccess:
+		return a, a.deliverSubmission(msg.View, msg)
+
+	case views.TimeEntrySubmissionError:
+		return a, a.deliverSubmission(msg.View, msg)
+
+	case profileResolvedMsg:
+		return a, a.useProfile(msg)
+
+	case timerTickMsg:
+		a.ticking = false
+		return a, a.tickTimer()
+
+	case cacheRefreshMsg:
+		return a, a.refreshCache()
+
+	case cacheRefreshedMsg:
+		// Checks go on via scheduleRefresh as long as Redmine stays unreachable
+		a.refreshing = false
+		if msg.Online {
+			return a, a.flushOutbox()
+		}
+		return a, nil
+
+	case outboxFlushMsg:
+		a.countQueued()
+		return a, tea.Batch(a.flushOutbox(), a.scheduleFlush())
+
+	case outboxFlushedMsg:
+		// Entries that failed stay queued and are tried again with the next flush
+		a.flushing = false
+		a.countQueued()
+		if ov, ok := a.views[OutboxView].(*views.OutboxView); ok && len(msg.Results) > 0 {
+			ov.Reload()
+		}
+		return a, nil
+
+	case messages.OutboxChangedMsg:
+		a.countQueued()
+		return a, nil
+
+	case messages.OutboxEditMsg:
+		tv, err := views.NewTimeEntryView(a.width, a.height, a.config.Redmine.Activities.Prefix, msg.Issue, a.issueService, a.issueService)
+		if err != nil {
+			return a, nil
+		}
+		tv.SetOutbox(a.outbox, a.config.Profile)
+		tv.EditQueued(msg.Entry)
+
+		a.openFrom(TimeLogView)
+		a.views[TimeLogView] = tv
+		return a, tv.Init()
+
+	case messages.ReturnToOutboxMsg:
+		a.switchView(OutboxView)
+		if ov, ok := a.views[OutboxView].(*views.OutboxView); ok {
+			ov.Reload()
+		}
+		return a, nil
+
+	case timerErrorMsg:
+		if !errors.Is(msg.Error, context.Canceled) {
+			a.timerErr = msg.Error
+		}
+		return a, nil
+
+	case views.SavedQueriesLoadedMsg:
+		// The queries belong to the search view, even if the user has moved on meanwhile
+		return a, a.views[SearchView].Update(msg)
+
+	case messages.FilterBuilderOpenMsg:
+		a.openFrom(FilterBuilderView)
+		fv := views.NewFilterBuilderView(a.width, a.height, msg.Query, a.issueService, a.config)
+		a.views[FilterBuilderView] = fv
+		return a, fv.Init()
+
+	case messages.FavoritesChangedMsg:
+		if sv, ok := a.views[SearchView].(*views.SearchView); ok {
+			sv.InitializeFavorites()
+		}
+		return a, nil
+
+	case messages.TimerToggleMsg:
+		return a, a.toggleTimer(msg.Issue)
+
 	case messages.SearchSubmittedMsg:
-		a.currentView = LoadingView
+		a.switchView(LoadingView)
 		lv := views.NewLoadingView(a.width, "Searching issues")
 		lv.SetSize(a.width, a.height)
 		a.views[LoadingView] = lv
@@ -136,12 +701,18 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Start the search operation
 		return a, tea.Batch(
 			lv.Init(),
-			a.searchIssues(msg.Query),
+			a.searchIssues(a.requestContext(), msg.Query),
 		)
 
 	case messages.TimeEntryCreateMsg:
-		a.currentView = TimeLogView
-		iv := views.NewIssueView(a.width, a.height, msg.Issue)
+		if a.currentView == TimesheetView {
+			// The timesheet shows the logged time once the user returns to it
+			a.openFrom(TimeLogView)
+		} else {
+			delete(a.returnViews, TimeLogView)
+			a.switchView(TimeLogView)
+		}
+		iv := views.NewIssueView(a.width, a.height, msg.Issue, a.issueService, a.config.Redmine.FollowUpFieldID, a.textFormat())
 		iv.SetSize(a.width, a.height)
 		a.views[IssueView] = iv
 
@@ -149,13 +720,57 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if err != nil {
 			return a, nil
 		}
+		if !msg.Date.IsZero() {
+			tv.SetDate(msg.Date)
+		}
+		if msg.Hours > 0 {
+			tv.SetHours(msg.Hours)
+		}
+		tv.SetOutbox(a.outbox, a.config.Profile)
+		tv.SetTimer(msg.Timer)
 		a.views[TimeLogView] = tv
 		return a, tv.Init()
 
+	case messages.TimerLoggedMsg:
+		if err := a.timers.Remove(msg.Timer); err != nil {
+			a.timerErr = err
+		}
+		a.loadStopped()
+		return a, nil
+
+	case messages.TimeEntriesOpenMsg:
+		a.openFrom(TimeEntryListView)
+		lv := views.NewTimeEntryListView(a.width, a.height, msg.Title, msg.Filter, a.issueService)
+		a.views[TimeEntryListView] = lv
+		return a, lv.Init()
+
+	case messages.TimeEntryEditMsg:
+		tv, err := views.NewTimeEntryView(a.width, a.height, a.config.Redmine.Activities.Prefix, msg.Issue, a.issueService, a.issueService)
+		if err != nil {
+			return a, nil
+		}
+		tv.EditEntry(msg.Entry, a.issueService)
+
+		a.openFrom(TimeLogView)
+		a.views[TimeLogView] = tv
+		return a, tv.Init()
+
+	case messages.ReturnToTimeEntriesMsg:
+		a.switchView(TimeEntryListView)
+		if lv, ok := a.views[TimeEntryListView].(*views.TimeEntryListView); ok {
+			return a, lv.Reload()
+		}
+		return a, nil
+
 	case messages.SearchCompletedMsg:
+		if errors.Is(msg.Error, context.Canceled) {
+			// The user left the loading view, the result is no longer of interest
+			return a, nil
+		}
+
 		if msg.Error != nil {
 			// Handle search error - switch back to search view and show error
-			a.currentView = SearchView
+			a.switchView(SearchView)
 			// Log error for debugging (in a real app, you'd show this to the user)
 			// For now, at least we return to search view so user can try again
 			return a, nil
@@ -163,13 +778,13 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if len(msg.Results) == 0 {
 			// No results found - stay in search view
-			a.currentView = SearchView
+			a.switchView(SearchView)
 			// TODO: Show "no results found" message to user
 			return a, nil
 		}
 
 		// Switch to list view with results
-		a.currentView = ListView
+		a.switchView(ListView)
 		lv := views.NewListView(a.width)
 		lv.SetItems(msg.Results)
 		lv.SetSize(a.width, a.height)
@@ -178,15 +793,20 @@ func (a *Application) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case messages.IssueSelectedMsg:
-		a.currentView = IssueView
-		iv := views.NewIssueView(a.width, a.height, msg.Issue)
+		a.switchView(IssueView)
+		iv := views.NewIssueView(a.width, a.height, msg.Issue, a.issueService, a.config.Redmine.FollowUpFieldID, a.textFormat())
 		iv.SetSize(a.width, a.height)
 		a.views[IssueView] = iv
 		return a, iv.Init()
 
 	case messages.ReturnToIssueMsg:
-		a.currentView = IssueView
-		return a, nil
+		if returnView, ok := a.returnViews[TimeLogView]; ok && a.currentView == TimeLogView {
+			// Time logged from the timesheet returns there
+			a.switchView(returnView)
+			return a, a.resumeView()
+		}
+		a.switchView(IssueView)
+		return a, a.resumeView()
 	}
 
 	cmd := a.views[a.currentView].Update(msg)
@@ -210,6 +830,51 @@ func (a *Application) View() string {
 		Foreground(themes.TokyoNight.Highlight).
 		Render("RMT - Redmine Management Tool")
 
+	if a.config.Profile != "" {
+		profile := lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Primary).
+			Render(fmt.Sprintf("[%s] (alt+p: switch)", a.config.Profile))
+		title = lippgloss.JoinHorizontal(lippgloss.Top, title, "  ", profile)
+	}
+	if a.switchingProfile != "" {
+		switching := lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Muted).
+			Render(fmt.Sprintf("switching to %s...", a.switchingProfile))
+		title = lippgloss.JoinHorizontal(lippgloss.Top, title, "  ", switching)
+	}
+	if a.profileErr != nil {
+		title = lippgloss.JoinVertical(
+			lippgloss.Left,
+			title,
+			lippgloss.NewStyle().Foreground(themes.TokyoNight.Error).Render(a.profileErr.Error()),
+		)
+	}
+
+	if a.issueService.Offline() {
+		offline := lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Warning).
+			Render("offline: showing cached data")
+		title = lippgloss.JoinHorizontal(lippgloss.Top, title, "  ", offline)
+	}
+
+	if a.queued > 0 {
+		queued := lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Warning).
+			Render(fmt.Sprintf("✉ %d queued (alt+o: outbox)", a.queued))
+		title = lippgloss.JoinHorizontal(lippgloss.Top, title, "  ", queued)
+	}
+
+	if a.submission != "" {
+		submission := lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Info).
+			Render(a.submission)
+		title = lippgloss.JoinVertical(lippgloss.Left, title, submission)
+	}
+
+	if status := a.renderTimer(); status != "" {
+		title = lippgloss.JoinHorizontal(lippgloss.Top, title, "  ", status)
+	}
+
 	// Application.View renders the main application UI with a title and the current view.
 	return lippgloss.JoinVertical(
 		lippgloss.Top,
@@ -220,3 +885,46 @@ func (a *Application) View() string {
 		),
 	)
 }
+
+// renderTimer renders the running timer or its last failure for the header.
+// renderTimer returns an empty string if there is nothing to show.
+func (a *Application) renderTimer() string {
+	if a.timerErr != nil {
+		return lippgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Render("⏱ " + a.timerErr.Error())
+	}
+	if a.timer == nil {
+		return a.renderStopped()
+	}
+
+	elapsed := a.timer.Elapsed(time.Now()).Truncate(time.Second)
+	clock := fmt.Sprintf("%02d:%02d:%02d", int(elapsed.Hours()), int(elapsed.Minutes())%60, int(elapsed.Seconds())%60)
+
+	title := []rune(a.timer.Title)
+	if len(title) > 30 {
+		title = append(title[:29], '…')
+	}
+
+	return lippgloss.NewStyle().
+		Foreground(themes.TokyoNight.Info).
+		Render(fmt.Sprintf("⏱ #%d %s %s (alt+s: stop)", a.timer.IssueID, string(title), clock))
+}
+
+// renderStopped renders the stopped timers of the active profile whose time has not been logged yet.
+func (a *Application) renderStopped() string {
+	pending := a.pendingTimers()
+	if len(pending) == 0 {
+		return ""
+	}
+
+	oldest := pending[0]
+	status := fmt.Sprintf("⏸ #%d %.2fh not logged", oldest.IssueID, views.QuarterHours(oldest.Elapsed(time.Now())))
+	if len(pending) > 1 {
+		status += fmt.Sprintf(" (+%d more)", len(pending)-1)
+	}
+
+	return lippgloss.NewStyle().
+		Foreground(themes.TokyoNight.Warning).
+		Render(status + " (alt+s: log)")
+}
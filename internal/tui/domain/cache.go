@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/cache"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// The buckets of the cache and the time their entries are used without asking Redmine.
+// Older entries are only used while Redmine is unreachable.
+const (
+	issueBucket     = "issues"
+	searchBucket    = "searches"
+	projectBucket   = "projects"
+	timeEntryBucket = "time_entries"
+	activityBucket  = "activities"
+
+	issueTTL     = 5 * time.Minute
+	searchTTL    = 5 * time.Minute
+	projectTTL   = 24 * time.Hour
+	timeEntryTTL = 5 * time.Minute
+	activityTTL  = 24 * time.Hour
+)
+
+// Limits of the cache, so it does not grow and reconnecting does not reload everything ever cached.
+const (
+	cacheRetention = 30 * 24 * time.Hour // cacheRetention is the time after which unused entries are evicted
+	refreshWindow  = 24 * time.Hour      // refreshWindow is the time entries are reloaded by Refresh after their last use
+	refreshLimit   = 50                  // refreshLimit is the number of entries per bucket reloaded by Refresh at most
+)
+
+// searchResult is a cached search hit, the link is the one Redmine returned for the hit.
+type searchResult struct {
+	Issue models.Issue `json:"issue"`
+	Link  string       `json:"link,omitempty"`
+}
+
+// SetCache makes the repository keep issues, projects and the user's time entries in store.
+// Cached data is served while it is fresh and, regardless of its age, while Redmine is unreachable.
+func (s *RedmineIssueRepository) SetCache(store *cache.Store) {
+	s.cache = store
+}
+
+// Offline reports whether the last request failed because Redmine was unreachable.
+func (s *RedmineIssueRepository) Offline() bool {
+	return s.offline.Load()
+}
+
+// fetchCached returns the value cached under key in bucket if it is younger than ttl, and loads it with fetch otherwise.
+// If Redmine is unreachable, fetchCached falls back to the cached value regardless of its age and returns when it was stored.
+// The returned time is zero if the value is current.
+func fetchCached[T any](s *RedmineIssueRepository, bucket, key string, ttl time.Duration, fetch func() (T, error)) (T, time.Time, error) {
+	var cached T
+	var storedAt time.Time
+	found := false
+	if s.cache != nil {
+		// A damaged entry is treated as missing and replaced by the fetched value
+		storedAt, found, _ = s.cache.Get(bucket, key, &cached)
+		if found && s.now().Sub(storedAt) < ttl {
+			return cached, time.Time{}, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		if redmine.IsUnreachable(err) {
+			s.offline.Store(true)
+			if found {
+				return cached, storedAt, nil
+			}
+		}
+		var zero T
+		return zero, time.Time{}, err
+	}
+
+	s.offline.Store(false)
+	s.store(bucket, key, value)
+	return value, time.Time{}, nil
+}
+
+// store caches value under key in bucket.
+// Failing to write the cache only costs the offline fallback, so errors are ignored.
+func (s *RedmineIssueRepository) store(bucket, key string, value any) {
+	if s.cache != nil {
+		_ = s.cache.Put(bucket, key, value)
+	}
+}
+
+// invalidate removes the entries of bucket, or only the one stored under key if a key is given.
+// invalidate is called after changes, so the next request shows them.
+func (s *RedmineIssueRepository) invalidate(bucket string, key ...string) {
+	if s.cache == nil {
+		return
+	}
+	if len(key) == 0 {
+		_ = s.cache.Clear(bucket)
+		return
+	}
+	for _, k := range key {
+		_ = s.cache.Delete(bucket, k)
+	}
+}
+
+// timeEntryKey returns the cache key of the time entries matching filter.
+// Only the user's own time entries are cached, other filters return false.
+func timeEntryKey(filter models.TimeEntryFilter) (string, bool) {
+	if filter.UserID != "me" {
+		return "", false
+	}
+	key, err := json.Marshal(filter)
+	return string(key), err == nil
+}
+
+// Refresh checks whether Redmine is reachable and reloads the stale cached issues and projects if it is.
+// Only the refreshLimit entries used most recently within refreshWindow are reloaded, entries that have not
+// been used for cacheRetention are evicted from all buckets.
+// Refresh reports whether Redmine was reachable, the error joins the entries that could not be reloaded.
+func (s *RedmineIssueRepository) Refresh(ctx context.Context) (bool, error) {
+	_, err := s.client.ListIssueStatuses(ctx)
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if redmine.IsUnreachable(err) {
+		return false, nil
+	}
+	s.offline.Store(false)
+
+	if s.cache == nil {
+		return true, nil
+	}
+
+	errs := []error{s.evict()}
+	for _, bucket := range []struct {
+		name string
+		ttl  time.Duration
+		load func(id int) (any, error)
+	}{
+		{issueBucket, issueTTL, func(id int) (any, error) { return s.client.GetIssue(ctx, id) }},
+		{projectBucket, projectTTL, func(id int) (any, error) { return s.client.GetProject(ctx, id) }},
+	} {
+		entries, err := s.cache.Entries(bucket.name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// The most recently used entries are reloaded first
+		slices.SortFunc(entries, func(a, b cache.Entry) int { return b.UsedAt.Compare(a.UsedAt) })
+
+		var stale []int
+		for _, entry := range entries {
+			if len(stale) == refreshLimit || s.now().Sub(entry.UsedAt) >= refreshWindow {
+				break
+			}
+			id, err := strconv.Atoi(entry.Key)
+			if err == nil && s.now().Sub(entry.StoredAt) >= bucket.ttl {
+				stale = append(stale, id)
+			}
+		}
+
+		results := make([]error, len(stale))
+		forEachBounded(len(stale), func(i int) {
+			key := strconv.Itoa(stale[i])
+			value, err := bucket.load(stale[i])
+			switch {
+			case redmine.IsNotFound(err):
+				// The issue or project has been deleted meanwhile
+				s.invalidate(bucket.name, key)
+			case err != nil:
+				results[i] = err
+			default:
+				// Reloading does not count as use, so entries nobody looks at still expire
+				_ = s.cache.Reload(bucket.name, key, value)
+			}
+		})
+		errs = append(errs, results...)
+	}
+
+	return true, errors.Join(errs...)
+}
+
+// evict removes the entries of all buckets that have not been used for cacheRetention.
+func (s *RedmineIssueRepository) evict() error {
+	var errs []error
+	for _, bucket := range []string{issueBucket, searchBucket, projectBucket, timeEntryBucket, activityBucket} {
+		entries, err := s.cache.Entries(bucket)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, entry := range entries {
+			if s.now().Sub(entry.UsedAt) >= cacheRetention {
+				errs = append(errs, s.cache.Delete(bucket, entry.Key))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/cache"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// newCachedRepository creates a repository caching in a temporary directory, whose clock is moved by the returned function.
+func newCachedRepository(t *testing.T, api *fakeRedmineAPI) (*RedmineIssueRepository, func(time.Duration)) {
+	repo := NewRedmineIssueRepository(api, 0)
+	repo.SetCache(cache.NewStore(t.TempDir()))
+
+	now := time.Now()
+	repo.now = func() time.Time { return now }
+	return repo, func(d time.Duration) { now = now.Add(d) }
+}
+
+// TestRedmineIssueRepository_CachedIssue verifies that fresh issues are served from the cache and stale ones while offline.
+func TestRedmineIssueRepository_CachedIssue(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	repo, advance := newCachedRepository(t, api)
+
+	if _, err := repo.GetIssue(context.Background(), 5); err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+	issue, err := repo.GetIssue(context.Background(), 5)
+	if err != nil || len(api.getIssues) != 1 || !issue.CachedAt().IsZero() {
+		t.Fatalf("expected the fresh issue to be served from the cache, got %d requests, %v", len(api.getIssues), err)
+	}
+
+	api.unreachable = true
+	advance(time.Hour)
+	issue, err = repo.GetIssue(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("expected the stale issue while offline, got %v", err)
+	}
+	if issue.ID() != 5 || issue.CachedAt().IsZero() || !repo.Offline() {
+		t.Errorf("expected issue 5 marked as cached and the repository offline, got %+v", issue)
+	}
+	if _, err := repo.GetIssue(context.Background(), 6); err == nil {
+		t.Error("expected an error for an issue that was never cached")
+	}
+
+	api.unreachable = false
+	issue, err = repo.GetIssue(context.Background(), 5)
+	if err != nil || !issue.CachedAt().IsZero() || repo.Offline() {
+		t.Errorf("expected a current issue once Redmine is back, got %+v, %v", issue, err)
+	}
+}
+
+// TestRedmineIssueRepository_CachedSearch verifies that changing an issue drops the cached searches.
+func TestRedmineIssueRepository_CachedSearch(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	api.hits = []models.SearchResult{{ID: 5, URL: "http://example.com/issues/5"}}
+	repo, _ := newCachedRepository(t, api)
+
+	for range 2 {
+		if _, err := repo.Search(context.Background(), "login"); err != nil {
+			t.Fatalf("Search returned error: %v", err)
+		}
+	}
+	if len(api.rawQueries) != 1 {
+		t.Fatalf("expected the second search to be served from the cache, got %d requests", len(api.rawQueries))
+	}
+
+	if err := repo.UpdateIssue(context.Background(), 5, models.UpdateIssueParams{Notes: "Done"}); err != nil {
+		t.Fatalf("UpdateIssue returned error: %v", err)
+	}
+	results, err := repo.Search(context.Background(), "login")
+	if err != nil || len(results) != 1 || len(api.rawQueries) != 2 {
+		t.Errorf("expected the search to be run again after the change, got %d requests, %v", len(api.rawQueries), err)
+	}
+}
+
+// TestRedmineIssueRepository_Refresh verifies that stale issues are reloaded once Redmine is reachable again.
+func TestRedmineIssueRepository_Refresh(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	repo, advance := newCachedRepository(t, api)
+	if _, err := repo.GetIssue(context.Background(), 5); err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+
+	api.unreachable = true
+	advance(time.Hour)
+	if online, err := repo.Refresh(context.Background()); online || err != nil {
+		t.Fatalf("Refresh() = %v, %v, want offline", online, err)
+	}
+
+	api.unreachable = false
+	if online, err := repo.Refresh(context.Background()); !online || err != nil {
+		t.Fatalf("Refresh() = %v, %v, want online", online, err)
+	}
+	if len(api.getIssues) != 2 {
+		t.Errorf("expected the stale issue to be reloaded, got requests for %v", api.getIssues)
+	}
+}
+
+// TestRedmineIssueRepository_RefreshUnused verifies that unused entries are not reloaded and evicted eventually.
+func TestRedmineIssueRepository_RefreshUnused(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	repo, advance := newCachedRepository(t, api)
+	if _, err := repo.GetIssue(context.Background(), 5); err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+
+	advance(2 * refreshWindow)
+	if online, err := repo.Refresh(context.Background()); !online || err != nil {
+		t.Fatalf("Refresh() = %v, %v, want online", online, err)
+	}
+	if len(api.getIssues) != 1 {
+		t.Errorf("expected the unused issue not to be reloaded, got requests for %v", api.getIssues)
+	}
+
+	advance(cacheRetention)
+	if _, err := repo.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if entries, err := repo.cache.Entries(issueBucket); len(entries) != 0 || err != nil {
+		t.Errorf("Entries() = %+v, %v, want the unused issue evicted", entries, err)
+	}
+}
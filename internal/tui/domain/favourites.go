@@ -1,11 +1,24 @@
 package domain
 
-import "github.com/charmbracelet/bubbles/list"
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// QueryLister defines an interface for listing the saved issue queries maintained in Redmine.
+type QueryLister interface {
+	ListQueries(ctx context.Context) ([]*Favorite, error)
+}
 
 type Favorite struct {
 	id     int
 	name   string
 	config string
+	hotkey string
+
+	savedQuery bool // savedQuery reports whether the favorite is a query saved in Redmine instead of the config file
+	public     bool // public reports whether the saved query is visible to other Redmine users
 }
 
 // FilterValue implements list.Item.
@@ -15,11 +28,24 @@ func (f *Favorite) FilterValue() string {
 
 var _ list.Item = (*Favorite)(nil)
 
-func NewFavorite(id int, name, config string) *Favorite {
+// NewFavorite creates a favorite running the query config, hotkey may be empty.
+func NewFavorite(id int, name, config, hotkey string) *Favorite {
 	return &Favorite{
 		id:     id,
 		name:   name,
 		config: config,
+		hotkey: hotkey,
+	}
+}
+
+// NewSavedQuery creates a favorite for the Redmine query with the given ID, running the query string config.
+func NewSavedQuery(id int, name, config string, public bool) *Favorite {
+	return &Favorite{
+		id:         id,
+		name:       name,
+		config:     config,
+		savedQuery: true,
+		public:     public,
 	}
 }
 
@@ -37,3 +63,18 @@ func (f *Favorite) Name() string {
 func (f *Favorite) Config() string {
 	return f.config
 }
+
+// Hotkey returns the key running the favorite, empty if it has none
+func (f *Favorite) Hotkey() string {
+	return f.hotkey
+}
+
+// IsSavedQuery reports whether the favorite is a query saved in Redmine, which cannot be edited locally
+func (f *Favorite) IsSavedQuery() bool {
+	return f.savedQuery
+}
+
+// IsPublic reports whether the saved query is visible to other Redmine users
+func (f *Favorite) IsPublic() bool {
+	return f.public
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// Fields of the issue query that offer their values for selection.
+const (
+	FilterProject      = "project_id"       // FilterProject selects projects
+	FilterTracker      = "tracker_id"       // FilterTracker selects trackers
+	FilterStatus       = "status_id"        // FilterStatus selects issue statuses
+	FilterAssignee     = "assigned_to_id"   // FilterAssignee selects members of the filtered project
+	FilterVersion      = "fixed_version_id" // FilterVersion selects versions of the filtered project
+	FilterCustomFields = "custom_fields"    // FilterCustomFields selects custom fields, the values are field names like "cf_5"
+)
+
+// ErrProjectRequired is returned for the options of fields that depend on the filtered project.
+var ErrProjectRequired = errors.New("filter a single project first")
+
+// FilterOption is a value that can be selected for a field of an issue query.
+type FilterOption struct {
+	Value string // Value is sent to Redmine, e.g. the ID of a tracker
+	Label string // Label is shown to the user
+}
+
+// FilterOptionLister defines an interface for listing the values an issue query field can take.
+// projectID narrows the options of fields belonging to a project, like versions and assignees.
+type FilterOptionLister interface {
+	ListFilterOptions(ctx context.Context, field, projectID string) ([]FilterOption, error)
+}
+
+var _ FilterOptionLister = (*RedmineIssueRepository)(nil)
+
+// ListFilterOptions returns the values field can take, in the order Redmine returns them.
+// field is one of the Filter constants or the name of a custom field like "cf_5", whose possible values are returned.
+func (s *RedmineIssueRepository) ListFilterOptions(ctx context.Context, field, projectID string) ([]FilterOption, error) {
+	switch field {
+	case FilterProject:
+		var options []FilterOption
+		for project, err := range redmine.IterateProjects(ctx, s.client, models.ProjectListParams{}) {
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, FilterOption{Value: strconv.Itoa(project.ID), Label: project.Name})
+		}
+		return options, nil
+
+	case FilterTracker:
+		trackers, err := s.client.ListTrackers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options := make([]FilterOption, 0, len(trackers))
+		for _, tracker := range trackers {
+			options = append(options, FilterOption{Value: strconv.Itoa(tracker.ID), Label: tracker.Name})
+		}
+		return options, nil
+
+	case FilterStatus:
+		statuses, err := s.client.ListIssueStatuses(ctx)
+		if err != nil {
+			return nil, err
+		}
+		options := make([]FilterOption, 0, len(statuses))
+		for _, status := range statuses {
+			label := status.Name
+			if status.IsClosed {
+				label += " (closed)"
+			}
+			options = append(options, FilterOption{Value: strconv.Itoa(status.ID), Label: label})
+		}
+		return options, nil
+
+	case FilterAssignee:
+		if projectID == "" {
+			return nil, ErrProjectRequired
+		}
+		var options []FilterOption
+		for membership, err := range redmine.IterateMemberships(ctx, s.client, projectID, models.MembershipListParams{}) {
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case membership.User != nil:
+				options = append(options, FilterOption{Value: strconv.Itoa(membership.User.ID), Label: membership.User.Name})
+			case membership.Group != nil:
+				options = append(options, FilterOption{Value: strconv.Itoa(membership.Group.ID), Label: membership.Group.Name + " (group)"})
+			}
+		}
+		return options, nil
+
+	case FilterVersion:
+		if projectID == "" {
+			return nil, ErrProjectRequired
+		}
+		versions, err := s.client.ListVersions(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		options := make([]FilterOption, 0, len(versions))
+		for _, version := range versions {
+			label := version.Name
+			if version.Status != "" && version.Status != "open" {
+				label += " (" + version.Status + ")"
+			}
+			options = append(options, FilterOption{Value: strconv.Itoa(version.ID), Label: label})
+		}
+		return options, nil
+
+	case FilterCustomFields:
+		fields, err := s.client.ListCustomFields(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var options []FilterOption
+		for _, customField := range fields {
+			if customField.CustomizedType == "issue" && customField.IsFilter {
+				options = append(options, FilterOption{Value: fmt.Sprintf("cf_%d", customField.ID), Label: customField.Name})
+			}
+		}
+		return options, nil
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(field, "cf_"))
+	if !strings.HasPrefix(field, "cf_") || err != nil {
+		return nil, fmt.Errorf("field %q has no options", field)
+	}
+
+	fields, err := s.client.ListCustomFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, customField := range fields {
+		if customField.ID != id {
+			continue
+		}
+		options := make([]FilterOption, 0, len(customField.PossibleValues))
+		for _, value := range customField.PossibleValues {
+			label := value.Label
+			if label == "" {
+				label = value.Value
+			}
+			options = append(options, FilterOption{Value: value.Value, Label: label})
+		}
+		return options, nil
+	}
+
+	return nil, fmt.Errorf("custom field %d does not exist", id)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRedmineIssueRepository_ListFilterOptions verifies the options of trackers and custom fields.
+func TestRedmineIssueRepository_ListFilterOptions(t *testing.T) {
+	api := newFakeRedmineAPI()
+	api.trackers = []models.Tracker{{ID: 1, Name: "Bug"}, {ID: 2, Name: "Feature"}}
+	api.customFields = []models.CustomField{
+		{ID: 88, Name: "Follow-up", CustomizedType: "issue", FieldFormat: "date", IsFilter: true},
+		{ID: 89, Name: "Hidden", CustomizedType: "issue", FieldFormat: "string"},
+		{ID: 90, Name: "Customer", CustomizedType: "project", IsFilter: true},
+	}
+	repo := NewRedmineIssueRepository(api, 0)
+
+	trackers, err := repo.ListFilterOptions(context.Background(), FilterTracker, "")
+	if err != nil {
+		t.Fatalf("ListFilterOptions returned error: %v", err)
+	}
+	want := []FilterOption{{Value: "1", Label: "Bug"}, {Value: "2", Label: "Feature"}}
+	if !reflect.DeepEqual(trackers, want) {
+		t.Errorf("trackers = %+v, want %+v", trackers, want)
+	}
+
+	fields, err := repo.ListFilterOptions(context.Background(), FilterCustomFields, "")
+	if err != nil {
+		t.Fatalf("ListFilterOptions returned error: %v", err)
+	}
+	want = []FilterOption{{Value: "cf_88", Label: "Follow-up"}}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("custom fields = %+v, want only the filterable issue field %+v", fields, want)
+	}
+}
+
+// TestRedmineIssueRepository_ListFilterOptions_ProjectRequired verifies that versions need a project.
+func TestRedmineIssueRepository_ListFilterOptions_ProjectRequired(t *testing.T) {
+	repo := NewRedmineIssueRepository(newFakeRedmineAPI(), 0)
+
+	if _, err := repo.ListFilterOptions(context.Background(), FilterVersion, ""); !errors.Is(err, ErrProjectRequired) {
+		t.Errorf("expected ErrProjectRequired, got %v", err)
+	}
+}
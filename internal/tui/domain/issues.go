@@ -1,10 +1,18 @@
 package domain
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/b1tray3r/rmt/internal/cache"
 	"github.com/b1tray3r/rmt/internal/redmine"
 	"github.com/b1tray3r/rmt/internal/redmine/models"
 	"github.com/charmbracelet/bubbles/list"
@@ -15,6 +23,14 @@ type Project struct {
 	name string
 }
 
+// NewProject creates a project with the given ID and name.
+func NewProject(id int, name string) *Project {
+	return &Project{
+		id:   id,
+		name: name,
+	}
+}
+
 func (p *Project) ID() int {
 	return p.id
 }
@@ -30,6 +46,19 @@ type Issue struct {
 	title       string
 	project     *Project
 	description string
+
+	status       string
+	assignee     string
+	doneRatio    int
+	customFields map[int]string
+
+	journals    []JournalEntry
+	relations   []string
+	children    []string
+	attachments []string
+	watchers    []string
+
+	cachedAt time.Time
 }
 
 // Ensure Issue implements the list.Item interface so it can be used in the list view
@@ -97,6 +126,58 @@ func (i *Issue) Project() *Project {
 	return i.project
 }
 
+// Status returns the name of the issue status, empty if it is unknown.
+func (i *Issue) Status() string {
+	return i.status
+}
+
+// AssignedTo returns the name of the assignee, empty for unassigned issues.
+func (i *Issue) AssignedTo() string {
+	return i.assignee
+}
+
+// DoneRatio returns the progress of the issue in percent.
+func (i *Issue) DoneRatio() int {
+	return i.doneRatio
+}
+
+// CustomFieldValue returns the value of the custom field with the given ID, empty if it is not set.
+func (i *Issue) CustomFieldValue(id int) string {
+	return i.customFields[id]
+}
+
+// Journals returns the history of the issue, oldest first.
+// Journals is only set for issues retrieved one by one with GetIssue.
+func (i *Issue) Journals() []JournalEntry {
+	return i.journals
+}
+
+// Relations describes the relations to other issues, e.g. "blocks #12".
+func (i *Issue) Relations() []string {
+	return i.relations
+}
+
+// Children describes the subtasks of the issue.
+func (i *Issue) Children() []string {
+	return i.children
+}
+
+// Attachments describes the files attached to the issue.
+func (i *Issue) Attachments() []string {
+	return i.attachments
+}
+
+// Watchers returns the names of the users watching the issue.
+func (i *Issue) Watchers() []string {
+	return i.watchers
+}
+
+// CachedAt returns when the issue was stored in the cache it was served from because Redmine was unreachable.
+// CachedAt returns the zero time for current issues.
+func (i *Issue) CachedAt() time.Time {
+	return i.cachedAt
+}
+
 // IssueBaseURLProvider defines an interface for retrieving the base URL for issues.
 type IssueBaseURLProvider interface {
 	GetBaseURL() string
@@ -104,21 +185,78 @@ type IssueBaseURLProvider interface {
 
 // IssueGetter defines an interface for retrieving a single issue by its ID.
 type IssueGetter interface {
-	GetIssue(id int) (*Issue, error)
+	GetIssue(ctx context.Context, id int) (*Issue, error)
+}
+
+// IssuesGetter defines an interface for retrieving several issues by their IDs at once.
+type IssuesGetter interface {
+	GetIssues(ctx context.Context, ids []int) ([]*Issue, error)
 }
 
 // IssueSearcher defines an interface for searching issues by a query string.
 type IssueSearcher interface {
-	Search(query string) ([]*Issue, error)
-	SearchWithFilter(query string) ([]*Issue, error) // New method for Redmine issue queries
+	Search(ctx context.Context, query string) ([]*Issue, error)
+	SearchWithFilter(ctx context.Context, query string) ([]*Issue, error) // New method for Redmine issue queries
+}
+
+// IssueUpdater defines an interface for changing an issue and adding notes to it.
+type IssueUpdater interface {
+	UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error
+}
+
+// IssueStatusLister defines an interface for listing the statuses an issue may be changed to.
+type IssueStatusLister interface {
+	ListAllowedStatuses(ctx context.Context, issueID int) ([]FilterOption, error)
 }
 
 type TimeEntryCreator interface {
-	CreateTimeEntry(params models.CreateTimeEntryParams) (*models.TimeEntry, error)
+	CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error)
 }
 
+// TimeEntryLister defines an interface for listing time entries.
+type TimeEntryLister interface {
+	ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error)
+}
+
+// TimeEntryGetter defines an interface for retrieving a single time entry by its ID.
+type TimeEntryGetter interface {
+	GetTimeEntry(ctx context.Context, id int) (*models.TimeEntry, error)
+}
+
+// TimeEntryUpdater defines an interface for changing an existing time entry.
+type TimeEntryUpdater interface {
+	UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error
+}
+
+// TimeEntryDeleter defines an interface for removing a time entry.
+type TimeEntryDeleter interface {
+	DeleteTimeEntry(ctx context.Context, id int) error
+}
+
+// TimeEntryRepository composes the one-purpose interfaces for time entry operations.
+type TimeEntryRepository interface {
+	TimeEntryCreator
+	TimeEntryLister
+	TimeEntryGetter
+	TimeEntryUpdater
+	TimeEntryDeleter
+}
+
+// Activity is a time entry activity offered by a project.
+type Activity struct {
+	ID        int
+	Name      string
+	IsDefault bool // IsDefault reports whether Redmine preselects the activity for new time entries
+}
+
+// ProjectActivityGetter defines an interface for listing the time entry activities of a project.
 type ProjectActivityGetter interface {
-	GetProjectActivities(projectID int, activityPatterns []string) (map[int]string, error)
+	GetProjectActivities(ctx context.Context, projectID int, activityPatterns []string) ([]Activity, error)
+}
+
+// RecentActivityGetter defines an interface for looking up the activity the user logged time with last.
+type RecentActivityGetter interface {
+	RecentActivity(ctx context.Context, issueID, projectID int) (int, error)
 }
 
 // IssueRepository composes the one-purpose interfaces for issue operations.
@@ -128,15 +266,61 @@ type IssueRepository interface {
 	IssueSearcher
 	TimeEntryCreator
 	ProjectActivityGetter
+	RecentActivityGetter
 }
 
+// DefaultMaxResults is the number of issues a search returns when no cap is configured.
+const DefaultMaxResults = 500
+
+// MaxTimeEntries is the number of time entries a listing loads at most, far more than a user logs in a year.
+// Time entries are summed up and checked for duplicates, so a listing above the cap fails instead of being cut off.
+const MaxTimeEntries = 10000
+
+// ErrTooManyTimeEntries is returned if more time entries match a filter than a listing loads.
+var ErrTooManyTimeEntries = errors.New("too many time entries")
+
+var (
+	_ IssueRepository     = (*RedmineIssueRepository)(nil)
+	_ TimeEntryRepository = (*RedmineIssueRepository)(nil)
+	_ QueryLister         = (*RedmineIssueRepository)(nil)
+	_ IssueUpdater        = (*RedmineIssueRepository)(nil)
+	_ IssueStatusLister   = (*RedmineIssueRepository)(nil)
+)
+
 type RedmineIssueRepository struct {
-	client redmine.RedmineAPI
+	client         redmine.RedmineAPI
+	maxResults     int
+	maxTimeEntries int
+
+	cache   *cache.Store // cache keeps responses for offline use, nil disables caching
+	offline atomic.Bool  // offline reports whether the last request failed because Redmine was unreachable
+	now     func() time.Time
+
+	labelsMu sync.Mutex
+	labels   map[string]map[string]string // labels caches the names of IDs shown in issue histories
+
+	activitiesMu sync.Mutex
+	activities   map[int]cachedActivities // activities caches the activities of the projects time was logged on
 }
 
-func NewRedmineIssueRepository(client redmine.RedmineAPI) *RedmineIssueRepository {
+// cachedActivities are the activities of a project along with the moment they were loaded.
+type cachedActivities struct {
+	activities []Activity
+	loadedAt   time.Time
+}
+
+// NewRedmineIssueRepository creates a repository backed by the given Redmine client.
+// NewRedmineIssueRepository caps search results at maxResults, falling back to DefaultMaxResults if maxResults is not positive.
+func NewRedmineIssueRepository(client redmine.RedmineAPI, maxResults int) *RedmineIssueRepository {
+	if maxResults <= 0 {
+		maxResults = DefaultMaxResults
+	}
+
 	return &RedmineIssueRepository{
-		client: client,
+		client:         client,
+		maxResults:     maxResults,
+		maxTimeEntries: MaxTimeEntries,
+		now:            time.Now,
 	}
 }
 
@@ -153,22 +337,106 @@ func (s *RedmineIssueRepository) cleanTitle(title string) string {
 	return title
 }
 
-func (s *RedmineIssueRepository) GetProjectActivities(projectID int, activityPatterns []string) (map[int]string, error) {
-	project, err := s.client.GetProject(projectID)
+// GetProjectActivities returns the activities of the project whose names match one of the patterns.
+// The activities are listed in the order configured in Redmine, the default activity of the instance is marked.
+// GetProjectActivities keeps the activities in memory, so only the first time entry of a project waits for Redmine.
+func (s *RedmineIssueRepository) GetProjectActivities(ctx context.Context, projectID int, activityPatterns []string) ([]Activity, error) {
+	activities, err := s.projectActivities(ctx, projectID)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make(map[int]string)
-	for _, activity := range project.TimeEntryActivities {
+	var result []Activity
+	for _, activity := range activities {
 		if s.matchesActivityPatterns(activity.Name, activityPatterns) {
-			result[activity.ID] = activity.Name
+			result = append(result, activity)
 		}
 	}
 
 	return result, nil
 }
 
+// projectActivities returns all activities of the project from memory, the cache or Redmine.
+// Activities served from the cache because Redmine is unreachable are not kept in memory, so they are loaded again once it is back.
+func (s *RedmineIssueRepository) projectActivities(ctx context.Context, projectID int) ([]Activity, error) {
+	s.activitiesMu.Lock()
+	cached, ok := s.activities[projectID]
+	s.activitiesMu.Unlock()
+	if ok && s.now().Sub(cached.loadedAt) < projectTTL {
+		return cached.activities, nil
+	}
+
+	project, cachedAt, err := fetchCached(s, projectBucket, strconv.Itoa(projectID), projectTTL, func() (*models.Project, error) {
+		return s.client.GetProject(ctx, projectID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects only name their activities, the default is known from the activities of the instance.
+	// Projects may override an activity under a new ID, so the default is matched by name as well.
+	// Without it the activities are still usable, just nothing is preselected.
+	defaults, _, err := fetchCached(s, activityBucket, "time_entry_activities", activityTTL, func() ([]models.TimeEntryActivity, error) {
+		return s.client.ListTimeEntryActivities(ctx)
+	})
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	isDefault := func(activity models.TimeEntryActivity) bool {
+		return slices.ContainsFunc(defaults, func(d models.TimeEntryActivity) bool {
+			return d.IsDefault && (d.ID == activity.ID || d.Name == activity.Name)
+		})
+	}
+
+	activities := make([]Activity, 0, len(project.TimeEntryActivities))
+	for _, activity := range project.TimeEntryActivities {
+		activities = append(activities, Activity{ID: activity.ID, Name: activity.Name, IsDefault: isDefault(activity)})
+	}
+
+	if cachedAt.IsZero() {
+		s.activitiesMu.Lock()
+		defer s.activitiesMu.Unlock()
+		if s.activities == nil {
+			s.activities = make(map[int]cachedActivities)
+		}
+		s.activities[projectID] = cachedActivities{activities: activities, loadedAt: s.now()}
+	}
+
+	return activities, nil
+}
+
+// RecentActivity returns the activity of the user's latest time entry on the issue or, failing that, on the project.
+// RecentActivity returns zero if the user has not logged time on either.
+func (s *RedmineIssueRepository) RecentActivity(ctx context.Context, issueID, projectID int) (int, error) {
+	var filters []models.TimeEntryFilter
+	if issueID != 0 {
+		filters = append(filters, models.TimeEntryFilter{UserID: "me", IssueID: issueID, Limit: 1})
+	}
+	if projectID != 0 {
+		filters = append(filters, models.TimeEntryFilter{UserID: "me", ProjectID: strconv.Itoa(projectID), Limit: 1})
+	}
+
+	for _, filter := range filters {
+		// Redmine lists the latest time entries first
+		key, _ := timeEntryKey(filter)
+		entries, _, err := fetchCached(s, timeEntryBucket, key, timeEntryTTL, func() ([]models.TimeEntry, error) {
+			results, err := s.client.ListTimeEntries(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			return results.TimeEntries, nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		if len(entries) > 0 && entries[0].Activity.ID != 0 {
+			return entries[0].Activity.ID, nil
+		}
+	}
+
+	return 0, nil
+}
+
 // matchesActivityPatterns checks if an activity name matches any of the given patterns.
 // matchesActivityPatterns returns true if no patterns are provided (matches all).
 func (s *RedmineIssueRepository) matchesActivityPatterns(activityName string, activityPatterns []string) bool {
@@ -185,17 +453,161 @@ func (s *RedmineIssueRepository) matchesActivityPatterns(activityName string, ac
 	return false
 }
 
-func (s *RedmineIssueRepository) CreateTimeEntry(params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
-	return s.client.CreateTimeEntry(params)
+// CreateTimeEntry creates a time entry and drops the cached time entries.
+// The cache is dropped on failures, too, as a request may have created the time entry before it failed.
+func (s *RedmineIssueRepository) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	entry, err := s.client.CreateTimeEntry(ctx, params)
+	s.invalidate(timeEntryBucket)
+	return entry, err
+}
+
+// ListTimeEntries returns all time entries matching the filter, following every page.
+// ListTimeEntries returns ErrTooManyTimeEntries rather than a partial list if more than MaxTimeEntries match.
+// The user's own time entries are cached.
+func (s *RedmineIssueRepository) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	key, ok := timeEntryKey(filter)
+	if !ok {
+		return s.listTimeEntries(ctx, filter)
+	}
+
+	entries, _, err := fetchCached(s, timeEntryBucket, key, timeEntryTTL, func() ([]models.TimeEntry, error) {
+		return s.listTimeEntries(ctx, filter)
+	})
+	return entries, err
+}
+
+// listTimeEntries loads the time entries matching the filter from Redmine.
+func (s *RedmineIssueRepository) listTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	for entry, err := range redmine.IterateTimeEntries(ctx, s.client, filter) {
+		if err != nil {
+			return nil, err
+		}
+
+		if len(entries) == s.maxTimeEntries {
+			return nil, fmt.Errorf("%w: more than %d match, narrow the date range", ErrTooManyTimeEntries, s.maxTimeEntries)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (s *RedmineIssueRepository) GetTimeEntry(ctx context.Context, id int) (*models.TimeEntry, error) {
+	return s.client.GetTimeEntry(ctx, id)
+}
+
+func (s *RedmineIssueRepository) UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error {
+	err := s.client.UpdateTimeEntry(ctx, id, params)
+	if err == nil {
+		s.invalidate(timeEntryBucket)
+	}
+	return err
+}
+
+func (s *RedmineIssueRepository) DeleteTimeEntry(ctx context.Context, id int) error {
+	err := s.client.DeleteTimeEntry(ctx, id)
+	if err == nil {
+		s.invalidate(timeEntryBucket)
+	}
+	return err
+}
+
+// ListQueries returns the public and personal issue queries saved in Redmine as favorites.
+// The query string of a project query names the project, as Redmine otherwise runs it across all projects.
+func (s *RedmineIssueRepository) ListQueries(ctx context.Context) ([]*Favorite, error) {
+	var favorites []*Favorite
+	for query, err := range redmine.IterateQueries(ctx, s.client, models.QueryListParams{}) {
+		if err != nil {
+			return nil, err
+		}
+
+		values := url.Values{}
+		values.Set("query_id", strconv.Itoa(query.ID))
+		if query.ProjectID != 0 {
+			values.Set("project_id", strconv.Itoa(query.ProjectID))
+		}
+		favorites = append(favorites, NewSavedQuery(query.ID, query.Name, values.Encode(), query.IsPublic))
+	}
+
+	return favorites, nil
 }
 
-func (s *RedmineIssueRepository) GetIssue(id int) (*Issue, error) {
-	issue, err := s.client.GetIssue(id)
+// GetIssue retrieves a single issue including its history, naming the statuses and assignees changed in it.
+func (s *RedmineIssueRepository) GetIssue(ctx context.Context, id int) (*Issue, error) {
+	issue, cachedAt, err := s.fetchIssue(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewIssue(
+	ni := s.newIssue(*issue)
+	ni.journals = newJournalEntries(*issue, s.journalLabels(ctx, *issue))
+	ni.cachedAt = cachedAt
+	return ni, nil
+}
+
+// fetchIssue loads a single issue from the cache or Redmine, see fetchCached.
+func (s *RedmineIssueRepository) fetchIssue(ctx context.Context, id int) (*models.Issue, time.Time, error) {
+	return fetchCached(s, issueBucket, strconv.Itoa(id), issueTTL, func() (*models.Issue, error) {
+		return s.client.GetIssue(ctx, id)
+	})
+}
+
+// UpdateIssue changes the issue, e.g. its status, and adds the notes of params as journal note.
+// UpdateIssue drops the cached issue and searches, as the change may move the issue in or out of the results.
+func (s *RedmineIssueRepository) UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error {
+	if err := s.client.UpdateIssue(ctx, id, params); err != nil {
+		return err
+	}
+	s.invalidate(issueBucket, strconv.Itoa(id))
+	s.invalidate(searchBucket)
+	return nil
+}
+
+// ListAllowedStatuses returns the statuses the workflow allows for the issue, except for its current status.
+func (s *RedmineIssueRepository) ListAllowedStatuses(ctx context.Context, issueID int) ([]FilterOption, error) {
+	issue, err := s.client.GetIssueWithAllowedStatuses(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var options []FilterOption
+	for _, status := range issue.AllowedStatuses {
+		if status.ID == issue.Status.ID {
+			continue
+		}
+		label := status.Name
+		if status.IsClosed {
+			label += " (closed)"
+		}
+		options = append(options, FilterOption{Value: strconv.Itoa(status.ID), Label: label})
+	}
+	return options, nil
+}
+
+// GetIssues retrieves the issues with the given IDs in the given order.
+// GetIssues skips issues that could not be retrieved and only fails if none of them could be.
+func (s *RedmineIssueRepository) GetIssues(ctx context.Context, ids []int) ([]*Issue, error) {
+	issues, err := s.getIssuesByID(ctx, ids)
+	if err != nil && (len(issues) == 0 || ctx.Err() != nil) {
+		return nil, err
+	}
+
+	var result []*Issue
+	for _, id := range ids {
+		issue, ok := issues[id]
+		if !ok {
+			continue
+		}
+		result = append(result, s.newIssue(issue))
+	}
+
+	return result, nil
+}
+
+// newIssue converts a Redmine issue into a domain issue linking to the Redmine web interface.
+func (s *RedmineIssueRepository) newIssue(issue models.Issue) *Issue {
+	ni := NewIssue(
 		issue.ID,
 		fmt.Sprintf("%s/issues/%d", s.GetBaseURL(), issue.ID),
 		issue.Author.Name,
@@ -205,141 +617,200 @@ func (s *RedmineIssueRepository) GetIssue(id int) (*Issue, error) {
 			id:   issue.Project.ID,
 			name: issue.Project.Name,
 		},
-	), nil
+	)
+
+	ni.status = issue.Status.Name
+	ni.doneRatio = issue.DoneRatio
+	if issue.AssignedTo != nil {
+		ni.assignee = issue.AssignedTo.Name
+	}
+	ni.customFields = make(map[int]string, len(issue.CustomFields))
+	for _, field := range issue.CustomFields {
+		ni.customFields[field.ID] = field.Text()
+	}
+	ni.relations, ni.children, ni.attachments, ni.watchers = issueLinks(issue)
+
+	return ni
 }
 
-func (s *RedmineIssueRepository) Search(query string) ([]*Issue, error) {
+func (s *RedmineIssueRepository) Search(ctx context.Context, query string) ([]*Issue, error) {
 	if strings.HasPrefix(query, "#") {
 		idStr := strings.TrimPrefix(query, "#")
 		if id, err := strconv.Atoi(idStr); err == nil {
-			issue, err := s.client.GetIssue(id)
+			issue, cachedAt, err := s.fetchIssue(ctx, id)
 			if err != nil {
 				return nil, err
 			}
 
-			ni := NewIssue(
-				issue.ID,
-				fmt.Sprintf("%s/issues/%d", s.GetBaseURL(), issue.ID),
-				issue.Author.Name,
-				s.cleanTitle(issue.Subject),
-				issue.Description,
-				&Project{
-					id:   issue.Project.ID,
-					name: issue.Project.Name,
-				},
-			)
+			ni := s.newIssue(*issue)
+			ni.cachedAt = cachedAt
 			return []*Issue{ni}, nil
 		}
 	}
 
-	// Regular search for non-ID queries
-	issues, err := s.client.Search(models.SearchParams{
-		Query: query,
+	results, cachedAt, err := fetchCached(s, searchBucket, "search:"+query, searchTTL, func() ([]searchResult, error) {
+		return s.search(ctx, query)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var result []*Issue
-	for _, issue := range issues.Results {
-		i, err := s.client.GetIssue(issue.ID)
+	issues := make([]*Issue, 0, len(results))
+	for _, result := range results {
+		ni := s.newIssue(result.Issue)
+		ni.link = result.Link
+		ni.cachedAt = cachedAt
+		issues = append(issues, ni)
+	}
+	return issues, nil
+}
+
+// search runs a full text search in Redmine and resolves the hits to issues.
+func (s *RedmineIssueRepository) search(ctx context.Context, query string) ([]searchResult, error) {
+	// Collect the hits in ranking order
+	var hits []models.SearchResult
+	for hit, err := range redmine.IterateSearch(ctx, s.client, models.SearchParams{Query: query}) {
 		if err != nil {
 			return nil, err
 		}
 
-		ni := NewIssue(
-			issue.ID,
-			issue.URL,
-			i.Author.Name,
-			s.cleanTitle(i.Subject),
-			i.Description,
-			&Project{
-				id:   i.Project.ID,
-				name: i.Project.Name,
-			},
-		)
-		result = append(result, ni)
+		hits = append(hits, hit)
+		if len(hits) >= s.maxResults {
+			break
+		}
 	}
 
-	return result, nil
-}
+	ids := make([]int, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
 
-// SearchWithFilter searches issues using Redmine issue query format (actual Redmine format)
-func (s *RedmineIssueRepository) SearchWithFilter(query string) ([]*Issue, error) {
-	// Use the raw query string directly with Redmine's issues API
-	results, err := s.client.SearchIssuesRaw(query)
-	if err != nil {
+	issues, err := s.getIssuesByID(ctx, ids)
+	if err != nil && (len(issues) == 0 || ctx.Err() != nil) {
 		return nil, err
 	}
 
-	var issueList []*Issue
-	for _, issue := range results.Issues {
-		ni := NewIssue(
-			issue.ID,
-			fmt.Sprintf("%s/issues/%d", s.GetBaseURL(), issue.ID),
-			issue.Author.Name,
-			s.cleanTitle(issue.Subject),
-			issue.Description,
-			&Project{
-				id:   issue.Project.ID,
-				name: issue.Project.Name,
-			},
-		)
-		issueList = append(issueList, ni)
+	// Hits that could not be resolved are skipped instead of failing the whole search
+	var results []searchResult
+	for _, hit := range hits {
+		i, ok := issues[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, searchResult{Issue: i, Link: hit.URL})
 	}
 
-	return issueList, nil
-} // parseRedmineQuery parses a Redmine query string into an IssueFilter
-func (s *RedmineIssueRepository) parseRedmineQuery(query string) models.IssueFilter {
-	filter := models.IssueFilter{
-		Limit:        100,
-		CustomFields: make(map[int]string),
-	}
-
-	// Split query by & to get individual parameters
-	parts := strings.Split(query, "&")
-	for _, part := range parts {
-		if strings.Contains(part, "=") {
-			keyValue := strings.SplitN(part, "=", 2)
-			key := keyValue[0]
-			value := keyValue[1]
-
-			// Handle different parameter types
-			switch {
-			case strings.HasPrefix(key, "cf_"):
-				// Custom field parameter: cf_10=*this week*
-				if fieldIDStr := strings.TrimPrefix(key, "cf_"); fieldIDStr != "" {
-					if fieldID, err := strconv.Atoi(fieldIDStr); err == nil {
-						filter.CustomFields[fieldID] = value
-					}
-				}
-			case key == "assigned_to_id":
-				filter.AssignedTo = value
-			case key == "status_id":
-				if statusID, err := strconv.Atoi(value); err == nil {
-					filter.StatusID = []int{statusID}
-				}
-			case key == "tracker_id":
-				if trackerID, err := strconv.Atoi(value); err == nil {
-					filter.TrackerID = []int{trackerID}
-				}
-			case key == "project_id":
-				if projectID, err := strconv.Atoi(value); err == nil {
-					filter.ProjectID = []int{projectID}
-				}
-			case key == "subject":
-				filter.Subject = value
-			case key == "limit":
-				if limit, err := strconv.Atoi(value); err == nil {
-					filter.Limit = limit
-				}
-			case key == "offset":
-				if offset, err := strconv.Atoi(value); err == nil {
-					filter.Offset = offset
-				}
+	return results, nil
+}
+
+// getIssuesByID resolves the given issue IDs with as few requests as possible.
+// getIssuesByID fetches the issues in batches via the issues endpoint and looks up the ones missing
+// from the batches one by one. All requests run on a bounded worker pool. The returned map contains
+// every issue that could be resolved, the error joins all failed lookups.
+func (s *RedmineIssueRepository) getIssuesByID(ctx context.Context, ids []int) (map[int]models.Issue, error) {
+	var (
+		mu     sync.Mutex
+		issues = make(map[int]models.Issue, len(ids))
+		errs   []error
+	)
+
+	collect := func(issue models.Issue, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		issues[issue.ID] = issue
+	}
+
+	batches := slices.Collect(slices.Chunk(ids, redmine.PageSize))
+	forEachBounded(len(batches), func(i int) {
+		for issue, err := range redmine.IterateIssues(ctx, s.client, issueIDQuery(batches[i])) {
+			collect(issue, err)
+		}
+	})
+
+	var missing []int
+	for _, id := range ids {
+		if _, ok := issues[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	forEachBounded(len(missing), func(i int) {
+		issue, err := s.client.GetIssue(ctx, missing[i])
+		if err != nil {
+			collect(models.Issue{}, fmt.Errorf("failed to get issue #%d: %w", missing[i], err))
+			return
+		}
+		collect(*issue, nil)
+	})
+
+	return issues, errors.Join(errs...)
+}
+
+// issueIDQuery builds a raw Redmine query string matching the given issue IDs regardless of their status.
+func issueIDQuery(ids []int) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.Itoa(id))
+	}
+
+	values := url.Values{}
+	values.Set("issue_id", strings.Join(parts, ","))
+	values.Set("status_id", "*")
+	return values.Encode()
+}
+
+// lookupWorkers bounds the number of concurrent requests used to resolve search hits.
+const lookupWorkers = 4
+
+// forEachBounded calls fn for every index below n using at most lookupWorkers goroutines at a time.
+// forEachBounded returns once all calls have finished.
+func forEachBounded(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, lookupWorkers)
+
+	for i := range n {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// SearchWithFilter searches issues using Redmine issue query format (actual Redmine format)
+func (s *RedmineIssueRepository) SearchWithFilter(ctx context.Context, query string) ([]*Issue, error) {
+	// Use the raw query string directly with Redmine's issues API, following all pages
+	found, cachedAt, err := fetchCached(s, searchBucket, "filter:"+query, searchTTL, func() ([]models.Issue, error) {
+		var found []models.Issue
+		for issue, err := range redmine.IterateIssues(ctx, s.client, query) {
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, issue)
+			if len(found) >= s.maxResults {
+				break
 			}
 		}
+		return found, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return filter
+	issueList := make([]*Issue, 0, len(found))
+	for _, issue := range found {
+		ni := s.newIssue(issue)
+		ni.cachedAt = cachedAt
+		issueList = append(issueList, ni)
+	}
+	return issueList, nil
 }
@@ -0,0 +1,427 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// errUnreachable is the error of a request that Redmine never answered.
+var errUnreachable = &url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("connection refused")}
+
+// fakeRedmineAPI implements redmine.RedmineAPI for testing.
+type fakeRedmineAPI struct {
+	mu sync.Mutex
+
+	hits      []models.SearchResult
+	issues    map[int]models.Issue
+	batchSkip map[int]bool // batchSkip lists issues that are not returned by the issues endpoint
+	failing   map[int]bool // failing lists issues whose GetIssue lookup fails
+
+	unreachable bool // unreachable makes GetIssue and ListIssueStatuses fail as if the network was down
+
+	rawQueries []string
+	getIssues  []int
+
+	savedQueries    []models.Query
+	trackers        []models.Tracker
+	customFields    []models.CustomField
+	allowedStatuses []models.IssueStatus
+	statuses        []models.IssueStatus
+	statusRequests  int
+	updates         []models.UpdateIssueParams
+
+	project          models.Project
+	projectRequests  int
+	activities       []models.TimeEntryActivity
+	timeEntries      []models.TimeEntry
+	timeEntryFilters []models.TimeEntryFilter
+}
+
+func (f *fakeRedmineAPI) GetBaseURL() string {
+	return "http://example.com"
+}
+
+func (f *fakeRedmineAPI) Search(ctx context.Context, params models.SearchParams) (*models.SearchResults, error) {
+	return &models.SearchResults{Results: f.hits, TotalCount: len(f.hits)}, nil
+}
+
+func (f *fakeRedmineAPI) GetIssue(ctx context.Context, id int) (*models.Issue, error) {
+	f.mu.Lock()
+	f.getIssues = append(f.getIssues, id)
+	f.mu.Unlock()
+
+	if f.failing[id] {
+		return nil, errors.New("lookup failed")
+	}
+	if f.unreachable {
+		return nil, errUnreachable
+	}
+	issue := f.issues[id]
+	return &issue, nil
+}
+
+func (f *fakeRedmineAPI) UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error {
+	f.updates = append(f.updates, params)
+	return nil
+}
+
+func (f *fakeRedmineAPI) GetIssueWithAllowedStatuses(ctx context.Context, issueID int) (*models.Issue, error) {
+	issue := f.issues[issueID]
+	issue.AllowedStatuses = f.allowedStatuses
+	return &issue, nil
+}
+
+func (f *fakeRedmineAPI) SearchIssues(ctx context.Context, filter models.IssueFilter) (*models.IssueResults, error) {
+	return &models.IssueResults{}, nil
+}
+
+func (f *fakeRedmineAPI) SearchIssuesRaw(ctx context.Context, queryString string) (*models.IssueResults, error) {
+	f.mu.Lock()
+	f.rawQueries = append(f.rawQueries, queryString)
+	f.mu.Unlock()
+
+	values, err := url.ParseQuery(queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	var results models.IssueResults
+	for _, part := range strings.Split(values.Get("issue_id"), ",") {
+		id, _ := strconv.Atoi(part)
+		if issue, ok := f.issues[id]; ok && !f.batchSkip[id] {
+			results.Issues = append(results.Issues, issue)
+		}
+	}
+	results.TotalCount = len(results.Issues)
+	return &results, nil
+}
+
+func (f *fakeRedmineAPI) GetProject(ctx context.Context, id int) (*models.Project, error) {
+	f.projectRequests++
+	if f.unreachable {
+		return nil, errUnreachable
+	}
+	project := f.project
+	return &project, nil
+}
+
+func (f *fakeRedmineAPI) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	return &models.TimeEntry{}, nil
+}
+
+func (f *fakeRedmineAPI) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) (*models.TimeEntryResults, error) {
+	f.timeEntryFilters = append(f.timeEntryFilters, filter)
+
+	var results models.TimeEntryResults
+	for _, entry := range f.timeEntries {
+		if filter.IssueID != 0 && entry.Issue.ID != filter.IssueID {
+			continue
+		}
+		if filter.ProjectID != "" && strconv.Itoa(entry.Project.ID) != filter.ProjectID {
+			continue
+		}
+		results.TimeEntries = append(results.TimeEntries, entry)
+	}
+	results.TotalCount = len(results.TimeEntries)
+	return &results, nil
+}
+
+func (f *fakeRedmineAPI) GetTimeEntry(ctx context.Context, id int) (*models.TimeEntry, error) {
+	return &models.TimeEntry{ID: id}, nil
+}
+
+func (f *fakeRedmineAPI) UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error {
+	return nil
+}
+
+func (f *fakeRedmineAPI) DeleteTimeEntry(ctx context.Context, id int) error {
+	return nil
+}
+
+func (f *fakeRedmineAPI) ListQueries(ctx context.Context, params models.QueryListParams) (*models.QueryResults, error) {
+	return &models.QueryResults{Queries: f.savedQueries, TotalCount: len(f.savedQueries)}, nil
+}
+
+func (f *fakeRedmineAPI) ListProjects(ctx context.Context, params models.ProjectListParams) (*models.ProjectResults, error) {
+	return &models.ProjectResults{}, nil
+}
+
+func (f *fakeRedmineAPI) ListTrackers(ctx context.Context) ([]models.Tracker, error) {
+	return f.trackers, nil
+}
+
+func (f *fakeRedmineAPI) ListIssueStatuses(ctx context.Context) ([]models.IssueStatus, error) {
+	f.statusRequests++
+	if f.unreachable {
+		return nil, errUnreachable
+	}
+	return f.statuses, nil
+}
+
+func (f *fakeRedmineAPI) ListTimeEntryActivities(ctx context.Context) ([]models.TimeEntryActivity, error) {
+	return f.activities, nil
+}
+
+func (f *fakeRedmineAPI) ListCustomFields(ctx context.Context) ([]models.CustomField, error) {
+	return f.customFields, nil
+}
+
+func (f *fakeRedmineAPI) ListVersions(ctx context.Context, projectID string) ([]models.Version, error) {
+	return nil, nil
+}
+
+func (f *fakeRedmineAPI) ListMemberships(ctx context.Context, projectID string, params models.MembershipListParams) (*models.MembershipResults, error) {
+	return &models.MembershipResults{}, nil
+}
+
+// newFakeRedmineAPI creates a fake API whose search returns the given issue IDs in order.
+func newFakeRedmineAPI(ids ...int) *fakeRedmineAPI {
+	f := &fakeRedmineAPI{
+		issues:    make(map[int]models.Issue),
+		batchSkip: make(map[int]bool),
+		failing:   make(map[int]bool),
+	}
+	for _, id := range ids {
+		f.hits = append(f.hits, models.SearchResult{ID: id, URL: "/issues/" + strconv.Itoa(id)})
+		f.issues[id] = models.Issue{ID: id, Subject: "Issue " + strconv.Itoa(id)}
+	}
+	return f
+}
+
+// TestRedmineIssueRepository_Search_BatchesLookups verifies that search hits are resolved with a single batch request in ranking order.
+func TestRedmineIssueRepository_Search_BatchesLookups(t *testing.T) {
+	api := newFakeRedmineAPI(42, 7, 13, 99)
+	repo := NewRedmineIssueRepository(api, 0)
+
+	issues, err := repo.Search(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+
+	want := []int{42, 7, 13, 99}
+	if len(issues) != len(want) {
+		t.Fatalf("Search returned %d issues, want %d", len(issues), len(want))
+	}
+	for i, issue := range issues {
+		if issue.ID() != want[i] {
+			t.Errorf("issues[%d].ID() = %d, want %d", i, issue.ID(), want[i])
+		}
+	}
+
+	if len(api.rawQueries) != 1 {
+		t.Errorf("expected 1 batch request, got %d", len(api.rawQueries))
+	}
+	if len(api.getIssues) != 0 {
+		t.Errorf("expected no single issue requests, got %v", api.getIssues)
+	}
+}
+
+// TestRedmineIssueRepository_Search_SkipsFailedLookups verifies that a failed lookup does not abort the search.
+func TestRedmineIssueRepository_Search_SkipsFailedLookups(t *testing.T) {
+	api := newFakeRedmineAPI(1, 2, 3)
+	api.batchSkip[2] = true
+	api.batchSkip[3] = true
+	api.failing[2] = true
+
+	repo := NewRedmineIssueRepository(api, 0)
+
+	issues, err := repo.Search(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+
+	if len(issues) != 2 || issues[0].ID() != 1 || issues[1].ID() != 3 {
+		t.Fatalf("Search returned unexpected issues: %v", issues)
+	}
+	if len(api.getIssues) != 2 {
+		t.Errorf("expected 2 single issue requests, got %v", api.getIssues)
+	}
+}
+
+// TestRedmineIssueRepository_Search_AllLookupsFailed verifies that the error is returned when no hit could be resolved.
+func TestRedmineIssueRepository_Search_AllLookupsFailed(t *testing.T) {
+	api := newFakeRedmineAPI(1)
+	api.batchSkip[1] = true
+	api.failing[1] = true
+
+	repo := NewRedmineIssueRepository(api, 0)
+
+	if _, err := repo.Search(context.Background(), "test"); err == nil {
+		t.Fatal("expected error when all lookups fail, got nil")
+	}
+}
+
+// TestRedmineIssueRepository_Search_MaxResults verifies that the number of search hits is capped.
+func TestRedmineIssueRepository_Search_MaxResults(t *testing.T) {
+	api := newFakeRedmineAPI(1, 2, 3, 4, 5)
+	repo := NewRedmineIssueRepository(api, 3)
+
+	issues, err := repo.Search(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("Search returned %d issues, want 3", len(issues))
+	}
+}
+
+// TestRedmineIssueRepository_ListTimeEntries_Cap verifies that time entries are not cut off at the search cap and that a listing above the time entry cap fails.
+func TestRedmineIssueRepository_ListTimeEntries_Cap(t *testing.T) {
+	api := newFakeRedmineAPI()
+	for i := 1; i <= 5; i++ {
+		api.timeEntries = append(api.timeEntries, models.TimeEntry{ID: i})
+	}
+	repo := NewRedmineIssueRepository(api, 3)
+
+	entries, err := repo.ListTimeEntries(context.Background(), models.TimeEntryFilter{})
+	if err != nil {
+		t.Fatalf("ListTimeEntries returned error: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Errorf("ListTimeEntries returned %d entries, want all 5", len(entries))
+	}
+
+	repo.maxTimeEntries = 5
+	if entries, err := repo.ListTimeEntries(context.Background(), models.TimeEntryFilter{}); err != nil || len(entries) != 5 {
+		t.Errorf("ListTimeEntries = %d entries, %v, want all 5 at the cap", len(entries), err)
+	}
+
+	repo.maxTimeEntries = 4
+	if entries, err := repo.ListTimeEntries(context.Background(), models.TimeEntryFilter{}); !errors.Is(err, ErrTooManyTimeEntries) || entries != nil {
+		t.Errorf("ListTimeEntries = %d entries, %v, want ErrTooManyTimeEntries", len(entries), err)
+	}
+}
+
+// TestRedmineIssueRepository_ListQueries verifies that saved queries become favorites running the query within its project.
+func TestRedmineIssueRepository_ListQueries(t *testing.T) {
+	api := newFakeRedmineAPI()
+	api.savedQueries = []models.Query{
+		{ID: 3, Name: "Open bugs", IsPublic: true},
+		{ID: 8, Name: "My sprint", ProjectID: 2},
+	}
+	repo := NewRedmineIssueRepository(api, 0)
+
+	favorites, err := repo.ListQueries(context.Background())
+	if err != nil {
+		t.Fatalf("ListQueries returned error: %v", err)
+	}
+	if len(favorites) != 2 {
+		t.Fatalf("ListQueries returned %d favorites, want 2", len(favorites))
+	}
+
+	if got := favorites[0].Config(); got != "query_id=3" {
+		t.Errorf("favorites[0].Config() = %q, want %q", got, "query_id=3")
+	}
+	if got := favorites[1].Config(); got != "project_id=2&query_id=8" {
+		t.Errorf("favorites[1].Config() = %q, want %q", got, "project_id=2&query_id=8")
+	}
+	if !favorites[0].IsSavedQuery() || !favorites[0].IsPublic() || favorites[1].IsPublic() {
+		t.Errorf("unexpected flags: public %v/%v", favorites[0].IsPublic(), favorites[1].IsPublic())
+	}
+}
+
+// TestRedmineIssueRepository_GetIssue verifies that status, assignee, progress and custom fields are taken over.
+func TestRedmineIssueRepository_GetIssue(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	issue := api.issues[5]
+	issue.Status.Name = "In Progress"
+	issue.DoneRatio = 40
+	issue.AssignedTo = &struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}{ID: 3, Name: "Jane"}
+	issue.CustomFields = []models.IssueCustomField{{ID: 9, Value: "2025-08-14"}, {ID: 10, Value: []any{"a", "b"}}}
+	api.issues[5] = issue
+	repo := NewRedmineIssueRepository(api, 0)
+
+	got, err := repo.GetIssue(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+	if got.Status() != "In Progress" || got.AssignedTo() != "Jane" || got.DoneRatio() != 40 {
+		t.Errorf("GetIssue = %q/%q/%d, want In Progress/Jane/40", got.Status(), got.AssignedTo(), got.DoneRatio())
+	}
+	if got.CustomFieldValue(9) != "2025-08-14" || got.CustomFieldValue(10) != "a, b" || got.CustomFieldValue(11) != "" {
+		t.Errorf("unexpected custom field values %q, %q", got.CustomFieldValue(9), got.CustomFieldValue(10))
+	}
+}
+
+// TestRedmineIssueRepository_ListAllowedStatuses verifies that the current status is not offered as transition.
+func TestRedmineIssueRepository_ListAllowedStatuses(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	issue := api.issues[5]
+	issue.Status.ID = 1
+	api.issues[5] = issue
+	api.allowedStatuses = []models.IssueStatus{{ID: 1, Name: "New"}, {ID: 2, Name: "In Progress"}, {ID: 5, Name: "Closed", IsClosed: true}}
+	repo := NewRedmineIssueRepository(api, 0)
+
+	options, err := repo.ListAllowedStatuses(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("ListAllowedStatuses returned error: %v", err)
+	}
+	want := []FilterOption{{Value: "2", Label: "In Progress"}, {Value: "5", Label: "Closed (closed)"}}
+	if len(options) != len(want) || options[0] != want[0] || options[1] != want[1] {
+		t.Errorf("ListAllowedStatuses = %+v, want %+v", options, want)
+	}
+}
+
+// TestRedmineIssueRepository_GetProjectActivities verifies that activities keep Redmine's order, are marked as default and kept in memory.
+func TestRedmineIssueRepository_GetProjectActivities(t *testing.T) {
+	api := newFakeRedmineAPI()
+	// The project overrides the default activity Development under a new ID
+	api.project.TimeEntryActivities = []models.TimeEntryActivity{{ID: 12, Name: "Design"}, {ID: 20, Name: "Development"}, {ID: 11, Name: "Dev Ops"}, {ID: 13, Name: "Support"}}
+	api.activities = []models.TimeEntryActivity{{ID: 12, Name: "Design"}, {ID: 9, Name: "Development", IsDefault: true}}
+	repo := NewRedmineIssueRepository(api, 0)
+
+	for range 2 {
+		activities, err := repo.GetProjectActivities(context.Background(), 7, []string{"De"})
+		if err != nil {
+			t.Fatalf("GetProjectActivities returned error: %v", err)
+		}
+		want := []Activity{{ID: 12, Name: "Design"}, {ID: 20, Name: "Development", IsDefault: true}, {ID: 11, Name: "Dev Ops"}}
+		if !slices.Equal(activities, want) {
+			t.Errorf("GetProjectActivities = %+v, want %+v", activities, want)
+		}
+	}
+	if api.projectRequests != 1 {
+		t.Errorf("expected the project to be requested once, got %d requests", api.projectRequests)
+	}
+}
+
+// TestRedmineIssueRepository_RecentActivity verifies that the activity last used on the issue is preferred over the one used on the project.
+func TestRedmineIssueRepository_RecentActivity(t *testing.T) {
+	api := newFakeRedmineAPI()
+	var onProject, onIssue models.TimeEntry
+	onProject.Project.ID = 7
+	onProject.Activity.ID = 9
+	onIssue.Issue.ID = 123
+	onIssue.Project.ID = 7
+	onIssue.Activity.ID = 10
+	api.timeEntries = []models.TimeEntry{onProject}
+	repo := NewRedmineIssueRepository(api, 0)
+
+	if id, err := repo.RecentActivity(context.Background(), 123, 7); err != nil || id != 9 {
+		t.Errorf("RecentActivity = %d, %v, want the activity used on the project", id, err)
+	}
+	if id, _ := repo.RecentActivity(context.Background(), 123, 8); id != 0 {
+		t.Errorf("RecentActivity = %d, want none for a project without time entries", id)
+	}
+
+	api.timeEntries = append(api.timeEntries, onIssue)
+	if id, _ := repo.RecentActivity(context.Background(), 123, 7); id != 10 {
+		t.Errorf("RecentActivity = %d, want the activity used on the issue", id)
+	}
+	for _, filter := range api.timeEntryFilters {
+		if filter.UserID != "me" || filter.Limit != 1 {
+			t.Errorf("unexpected filter %+v, want the user's latest time entry", filter)
+		}
+	}
+}
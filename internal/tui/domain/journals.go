@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// JournalEntry is an entry of the issue history, a note, changed fields or both.
+type JournalEntry struct {
+	Author    string    // Author made the change
+	CreatedOn time.Time // CreatedOn is the time of the change
+	Notes     string    // Notes is the comment, empty for pure field changes
+	Private   bool      // Private indicates that the notes are only visible to privileged users
+	Changes   []string  // Changes describe the changed fields, e.g. "status: New → In Progress"
+}
+
+// attributeNames are the names shown for the attributes of an issue in its history.
+var attributeNames = map[string]string{
+	"status_id":        "status",
+	"tracker_id":       "tracker",
+	"assigned_to_id":   "assignee",
+	"fixed_version_id": "version",
+	"priority_id":      "priority",
+	"category_id":      "category",
+	"project_id":       "project",
+	"parent_id":        "parent",
+	"done_ratio":       "done",
+	"due_date":         "due date",
+	"start_date":       "start date",
+	"estimated_hours":  "estimated time",
+	"is_private":       "private",
+}
+
+// labelFields are the attributes whose IDs are resolved to names, mapped to the field of the names.
+var labelFields = map[string]string{
+	"status_id":        FilterStatus,
+	"tracker_id":       FilterTracker,
+	"assigned_to_id":   FilterAssignee,
+	"fixed_version_id": FilterVersion,
+}
+
+// journalLabels resolves the IDs of the attributes changed in the history of issue to names.
+// journalLabels only asks Redmine for the fields that changed and keeps the names for later issues.
+// Names that cannot be loaded are left out, the history shows the IDs instead.
+func (s *RedmineIssueRepository) journalLabels(ctx context.Context, issue models.Issue) map[string]map[string]string {
+	projectID := strconv.Itoa(issue.Project.ID)
+
+	labels := make(map[string]map[string]string)
+	for _, journal := range issue.Journals {
+		for _, detail := range journal.Details {
+			field, ok := labelFields[detail.Name]
+			if detail.Property != "attr" || !ok || labels[detail.Name] != nil {
+				continue
+			}
+
+			key := field
+			if field == FilterAssignee || field == FilterVersion {
+				key += "@" + projectID
+			}
+			labels[detail.Name] = s.cachedLabels(ctx, key, func() (map[string]string, error) {
+				return s.loadLabels(ctx, field, projectID)
+			})
+		}
+	}
+	return labels
+}
+
+// cachedLabels returns the names stored under key, loading them if they are not known yet.
+func (s *RedmineIssueRepository) cachedLabels(ctx context.Context, key string, load func() (map[string]string, error)) map[string]string {
+	s.labelsMu.Lock()
+	cached, ok := s.labels[key]
+	s.labelsMu.Unlock()
+	if ok {
+		return cached
+	}
+
+	loaded, err := load()
+	if err != nil {
+		// Failures are not cached, the next issue tries again
+		return map[string]string{}
+	}
+
+	s.labelsMu.Lock()
+	defer s.labelsMu.Unlock()
+	if s.labels == nil {
+		s.labels = make(map[string]map[string]string)
+	}
+	s.labels[key] = loaded
+	return loaded
+}
+
+// loadLabels loads the names of the values of field, statuses without the closed marker of the filter options.
+func (s *RedmineIssueRepository) loadLabels(ctx context.Context, field, projectID string) (map[string]string, error) {
+	labels := make(map[string]string)
+	if field == FilterStatus {
+		statuses, err := s.client.ListIssueStatuses(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range statuses {
+			labels[strconv.Itoa(status.ID)] = status.Name
+		}
+		return labels, nil
+	}
+
+	options, err := s.ListFilterOptions(ctx, field, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		labels[option.Value] = option.Label
+	}
+	return labels, nil
+}
+
+// newJournalEntries converts the history of issue into journal entries, oldest first.
+func newJournalEntries(issue models.Issue, labels map[string]map[string]string) []JournalEntry {
+	customFields := make(map[string]string, len(issue.CustomFields))
+	for _, field := range issue.CustomFields {
+		customFields[strconv.Itoa(field.ID)] = field.Name
+	}
+	attachments := make(map[string]string, len(issue.Attachments))
+	for _, attachment := range issue.Attachments {
+		attachments[strconv.Itoa(attachment.ID)] = attachment.Filename
+	}
+
+	entries := make([]JournalEntry, 0, len(issue.Journals))
+	for _, journal := range issue.Journals {
+		entry := JournalEntry{
+			Author:    journal.User.Name,
+			CreatedOn: journal.CreatedOn,
+			Notes:     strings.TrimSpace(journal.Notes),
+			Private:   journal.PrivateNotes,
+		}
+		for _, detail := range journal.Details {
+			entry.Changes = append(entry.Changes, describeChange(detail, labels, customFields, attachments))
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// describeChange describes a single change of the issue history, e.g. "status: New → In Progress".
+func describeChange(detail models.JournalDetail, labels map[string]map[string]string, customFields, attachments map[string]string) string {
+	switch detail.Property {
+	case "attr":
+		name := detail.Name
+		if label, ok := attributeNames[name]; ok {
+			name = label
+		}
+		if name == "description" {
+			return "description updated"
+		}
+
+		oldValue, newValue := detail.OldValue, detail.NewValue
+		if names := labels[detail.Name]; names != nil {
+			oldValue = orValue(names[oldValue], oldValue)
+			newValue = orValue(names[newValue], newValue)
+		}
+		if detail.Name == "done_ratio" {
+			oldValue, newValue = percent(oldValue), percent(newValue)
+		}
+		return fmt.Sprintf("%s: %s → %s", name, orValue(oldValue, "none"), orValue(newValue, "none"))
+
+	case "cf":
+		name := orValue(customFields[detail.Name], "custom field "+detail.Name)
+		return fmt.Sprintf("%s: %s → %s", name, orValue(detail.OldValue, "none"), orValue(detail.NewValue, "none"))
+
+	case "attachment":
+		if detail.NewValue == "" {
+			return "attachment removed: " + orValue(detail.OldValue, attachments[detail.Name])
+		}
+		return "attachment added: " + detail.NewValue
+
+	case "relation":
+		if detail.NewValue == "" {
+			return fmt.Sprintf("relation removed: %s #%s", detail.Name, detail.OldValue)
+		}
+		return fmt.Sprintf("relation added: %s #%s", detail.Name, detail.NewValue)
+	}
+
+	return fmt.Sprintf("%s %s: %s → %s", detail.Property, detail.Name, orValue(detail.OldValue, "none"), orValue(detail.NewValue, "none"))
+}
+
+// orValue returns value, or fallback if value is empty.
+func orValue(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// percent appends a percent sign to non-empty values.
+func percent(value string) string {
+	if value == "" {
+		return ""
+	}
+	return value + "%"
+}
+
+// issueLinks describes the relations, subtasks, attachments and watchers of issue for the issue view.
+func issueLinks(issue models.Issue) (relations, children, attachments, watchers []string) {
+	for _, relation := range issue.Relations {
+		other, kind := relation.IssueToID, relation.RelationType
+		if other == issue.ID {
+			// The relation is stored on the other issue, so it reads the other way round
+			other, kind = relation.IssueID, inverseRelations[kind]
+		}
+		relations = append(relations, fmt.Sprintf("%s #%d", orValue(kind, relation.RelationType), other))
+	}
+	for _, child := range issue.Children {
+		children = append(children, fmt.Sprintf("%s #%d: %s", child.Tracker.Name, child.ID, child.Subject))
+	}
+	for _, attachment := range issue.Attachments {
+		attachments = append(attachments, fmt.Sprintf("%s (%s, %s)", attachment.Filename, formatSize(attachment.Filesize), attachment.Author.Name))
+	}
+	for _, watcher := range issue.Watchers {
+		watchers = append(watchers, watcher.Name)
+	}
+	return relations, children, attachments, watchers
+}
+
+// inverseRelations maps relation types to the way they read from the related issue.
+var inverseRelations = map[string]string{
+	"relates":    "relates",
+	"duplicates": "duplicated by",
+	"blocks":     "blocked by",
+	"precedes":   "follows",
+	"copied_to":  "copied from",
+}
+
+// formatSize formats a file size in bytes for display.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
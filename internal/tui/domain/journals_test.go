@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// TestRedmineIssueRepository_GetIssueJournals verifies that the history names changed statuses and keeps unknown IDs.
+func TestRedmineIssueRepository_GetIssueJournals(t *testing.T) {
+	api := newFakeRedmineAPI(5)
+	api.statuses = []models.IssueStatus{{ID: 1, Name: "New"}, {ID: 2, Name: "In Progress"}}
+
+	issue := api.issues[5]
+	issue.CustomFields = []models.IssueCustomField{{ID: 9, Name: "Follow-up"}}
+	issue.Journals = []models.Journal{
+		{
+			Notes:     "  Looking into it  ",
+			CreatedOn: time.Date(2025, 8, 12, 14, 30, 0, 0, time.UTC),
+			Details: []models.JournalDetail{
+				{Property: "attr", Name: "status_id", OldValue: "1", NewValue: "2"},
+				{Property: "attr", Name: "priority_id", OldValue: "4", NewValue: "5"},
+				{Property: "attr", Name: "done_ratio", OldValue: "0", NewValue: "30"},
+				{Property: "cf", Name: "9", NewValue: "2025-08-14"},
+				{Property: "attachment", Name: "17", NewValue: "screenshot.png"},
+				{Property: "relation", Name: "blocks", NewValue: "12"},
+			},
+		},
+	}
+	issue.Journals[0].User.Name = "Jane"
+	api.issues[5] = issue
+	repo := NewRedmineIssueRepository(api, 0)
+
+	got, err := repo.GetIssue(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+
+	want := []JournalEntry{{
+		Author:    "Jane",
+		CreatedOn: time.Date(2025, 8, 12, 14, 30, 0, 0, time.UTC),
+		Notes:     "Looking into it",
+		Changes: []string{
+			"status: New → In Progress",
+			"priority: 4 → 5",
+			"done: 0% → 30%",
+			"Follow-up: none → 2025-08-14",
+			"attachment added: screenshot.png",
+			"relation added: blocks #12",
+		},
+	}}
+	if !reflect.DeepEqual(got.Journals(), want) {
+		t.Errorf("Journals() = %+v, want %+v", got.Journals(), want)
+	}
+
+	if _, err := repo.GetIssue(context.Background(), 5); err != nil {
+		t.Fatalf("GetIssue returned error: %v", err)
+	}
+	if api.statusRequests != 1 {
+		t.Errorf("expected the statuses to be loaded once, got %d requests", api.statusRequests)
+	}
+}
+
+// TestIssueLinks verifies that relations stored on the other issue read the other way round.
+func TestIssueLinks(t *testing.T) {
+	issue := models.Issue{
+		ID: 5,
+		Relations: []models.IssueRelation{
+			{IssueID: 5, IssueToID: 12, RelationType: "blocks"},
+			{IssueID: 3, IssueToID: 5, RelationType: "precedes"},
+		},
+		Attachments: []models.Attachment{{Filename: "log.txt", Filesize: 2048}},
+	}
+	issue.Attachments[0].Author.Name = "Jane"
+
+	relations, _, attachments, _ := issueLinks(issue)
+	if want := []string{"blocks #12", "follows #3"}; !reflect.DeepEqual(relations, want) {
+		t.Errorf("relations = %v, want %v", relations, want)
+	}
+	if want := []string{"log.txt (2.0 KB, Jane)"}; !reflect.DeepEqual(attachments, want) {
+		t.Errorf("attachments = %v, want %v", attachments, want)
+	}
+}
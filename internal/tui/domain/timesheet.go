@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// DaysPerWeek is the number of day columns of a Timesheet, starting on Monday.
+const DaysPerWeek = 7
+
+// WeekStart returns midnight of the Monday of the week containing t.
+func WeekStart(t time.Time) time.Time {
+	weekday := int(t.Weekday())
+	if weekday == 0 { // Sunday
+		weekday = 7
+	}
+
+	monday := t.AddDate(0, 0, -(weekday - 1))
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
+}
+
+// TimesheetRow holds the hours booked on a single issue, or on a project directly, for each day of a week.
+type TimesheetRow struct {
+	IssueID     int                             // IssueID is the issue the hours are booked on, 0 for project bookings
+	ProjectID   int                             // ProjectID is the project of the time entries
+	ProjectName string                          // ProjectName is the name of the project
+	Issue       *Issue                          // Issue is the resolved issue, nil if it is unknown
+	Hours       [DaysPerWeek]float64            // Hours holds the booked hours per weekday
+	Entries     [DaysPerWeek][]models.TimeEntry // Entries holds the time entries per weekday
+}
+
+// Label returns the text identifying the row, preferring the issue title over the project name.
+func (r *TimesheetRow) Label() string {
+	switch {
+	case r.Issue != nil:
+		return fmt.Sprintf("#%d %s", r.IssueID, r.Issue.FullTitle())
+	case r.IssueID != 0:
+		return fmt.Sprintf("#%d", r.IssueID)
+	default:
+		return r.ProjectName
+	}
+}
+
+// Total returns the hours booked on the row during the whole week.
+func (r *TimesheetRow) Total() float64 {
+	var total float64
+	for _, hours := range r.Hours {
+		total += hours
+	}
+	return total
+}
+
+// Timesheet aggregates the time entries of a week by issue and weekday.
+type Timesheet struct {
+	Week time.Time       // Week is midnight of the Monday the timesheet starts on
+	Rows []*TimesheetRow // Rows are sorted by project name and issue ID
+}
+
+// NewTimesheet creates a timesheet for the week starting on week from the given time entries.
+// NewTimesheet ignores entries that were not spent during that week.
+func NewTimesheet(week time.Time, entries []models.TimeEntry) *Timesheet {
+	week = WeekStart(week)
+	sheet := &Timesheet{Week: week}
+
+	type rowKey struct{ issueID, projectID int }
+	rows := make(map[rowKey]*TimesheetRow)
+
+	for _, entry := range entries {
+		spentOn, err := time.Parse("2006-01-02", entry.SpentOn)
+		if err != nil {
+			continue
+		}
+
+		// Both dates are compared in UTC so that daylight saving time does not shift the day
+		monday := time.Date(week.Year(), week.Month(), week.Day(), 0, 0, 0, 0, time.UTC)
+		day := int(spentOn.Sub(monday).Hours() / 24)
+		if day < 0 || day >= DaysPerWeek {
+			continue
+		}
+
+		// Project bookings are kept apart per project, issue bookings per issue
+		key := rowKey{issueID: entry.Issue.ID}
+		if entry.Issue.ID == 0 {
+			key.projectID = entry.Project.ID
+		}
+
+		row, ok := rows[key]
+		if !ok {
+			row = &TimesheetRow{
+				IssueID:     entry.Issue.ID,
+				ProjectID:   entry.Project.ID,
+				ProjectName: entry.Project.Name,
+			}
+			rows[key] = row
+			sheet.Rows = append(sheet.Rows, row)
+		}
+
+		row.Hours[day] += entry.Hours
+		row.Entries[day] = append(row.Entries[day], entry)
+	}
+
+	slices.SortStableFunc(sheet.Rows, func(a, b *TimesheetRow) int {
+		if a.ProjectName != b.ProjectName {
+			if a.ProjectName < b.ProjectName {
+				return -1
+			}
+			return 1
+		}
+		return a.IssueID - b.IssueID
+	})
+
+	return sheet
+}
+
+// Day returns the date of the given weekday column, 0 being Monday.
+func (t *Timesheet) Day(day int) time.Time {
+	return t.Week.AddDate(0, 0, day)
+}
+
+// IssueIDs returns the IDs of all issues with hours booked in the timesheet.
+func (t *Timesheet) IssueIDs() []int {
+	var ids []int
+	for _, row := range t.Rows {
+		if row.IssueID != 0 {
+			ids = append(ids, row.IssueID)
+		}
+	}
+	return ids
+}
+
+// SetIssues attaches the resolved issues to their rows.
+func (t *Timesheet) SetIssues(issues []*Issue) {
+	byID := make(map[int]*Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID()] = issue
+	}
+
+	for _, row := range t.Rows {
+		if issue, ok := byID[row.IssueID]; ok {
+			row.Issue = issue
+		}
+	}
+}
+
+// DayTotal returns the hours booked on the given weekday across all rows.
+func (t *Timesheet) DayTotal(day int) float64 {
+	var total float64
+	for _, row := range t.Rows {
+		total += row.Hours[day]
+	}
+	return total
+}
+
+// Total returns the hours booked during the whole week.
+func (t *Timesheet) Total() float64 {
+	var total float64
+	for _, row := range t.Rows {
+		total += row.Total()
+	}
+	return total
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+)
+
+// newTestTimeEntry creates a time entry for testing.
+func newTestTimeEntry(issueID, projectID int, projectName, spentOn string, hours float64) models.TimeEntry {
+	var entry models.TimeEntry
+	entry.Issue.ID = issueID
+	entry.Project.ID = projectID
+	entry.Project.Name = projectName
+	entry.SpentOn = spentOn
+	entry.Hours = hours
+	return entry
+}
+
+// TestWeekStart verifies that WeekStart returns the Monday of the week for every weekday.
+func TestWeekStart(t *testing.T) {
+	want := time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)
+	for day := 11; day <= 17; day++ {
+		got := WeekStart(time.Date(2025, 8, day, 15, 30, 0, 0, time.UTC))
+		if !got.Equal(want) {
+			t.Errorf("WeekStart(2025-08-%d) = %v, want %v", day, got, want)
+		}
+	}
+}
+
+// TestNewTimesheet verifies that time entries are aggregated by issue and weekday.
+func TestNewTimesheet(t *testing.T) {
+	week := time.Date(2025, 8, 13, 0, 0, 0, 0, time.UTC)
+	entries := []models.TimeEntry{
+		newTestTimeEntry(123, 1, "Beta", "2025-08-11", 1.5),
+		newTestTimeEntry(123, 1, "Beta", "2025-08-11", 0.5),
+		newTestTimeEntry(123, 1, "Beta", "2025-08-15", 2),
+		newTestTimeEntry(7, 2, "Alpha", "2025-08-17", 1),
+		newTestTimeEntry(0, 2, "Alpha", "2025-08-12", 0.25),
+		newTestTimeEntry(7, 2, "Alpha", "2025-08-18", 4), // next week
+		newTestTimeEntry(7, 2, "Alpha", "invalid", 4),
+	}
+
+	sheet := NewTimesheet(week, entries)
+
+	if !sheet.Week.Equal(time.Date(2025, 8, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Week = %v, want Monday 2025-08-11", sheet.Week)
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("len(Rows) = %d, want 3", len(sheet.Rows))
+	}
+
+	// Rows are sorted by project name, project bookings first
+	if sheet.Rows[0].IssueID != 0 || sheet.Rows[0].Label() != "Alpha" {
+		t.Errorf("Rows[0] = %q, want project row 'Alpha'", sheet.Rows[0].Label())
+	}
+	if sheet.Rows[1].IssueID != 7 || sheet.Rows[2].IssueID != 123 {
+		t.Errorf("unexpected row order: %d, %d", sheet.Rows[1].IssueID, sheet.Rows[2].IssueID)
+	}
+
+	row := sheet.Rows[2]
+	if row.Hours[0] != 2 || row.Hours[4] != 2 || row.Total() != 4 {
+		t.Errorf("row #123 hours = %v, want 2 on Monday and Friday", row.Hours)
+	}
+	if len(row.Entries[0]) != 2 {
+		t.Errorf("row #123 has %d entries on Monday, want 2", len(row.Entries[0]))
+	}
+
+	if sheet.DayTotal(0) != 2 || sheet.DayTotal(1) != 0.25 || sheet.DayTotal(6) != 1 {
+		t.Errorf("unexpected day totals: %v, %v, %v", sheet.DayTotal(0), sheet.DayTotal(1), sheet.DayTotal(6))
+	}
+	if sheet.Total() != 5.25 {
+		t.Errorf("Total() = %v, want 5.25", sheet.Total())
+	}
+
+	ids := sheet.IssueIDs()
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 123 {
+		t.Errorf("IssueIDs() = %v, want [7 123]", ids)
+	}
+
+	sheet.SetIssues([]*Issue{NewIssue(123, "", "", "Fix login", "", nil)})
+	if got := sheet.Rows[2].Label(); got != "#123 Fix login" {
+		t.Errorf("Label() = %q, want %q", got, "#123 Fix login")
+	}
+	if got := sheet.Rows[1].Label(); got != "#7" {
+		t.Errorf("Label() = %q, want %q", got, "#7")
+	}
+}
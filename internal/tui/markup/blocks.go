@@ -0,0 +1,354 @@
+package markup
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// blockKind is the kind of a block of text.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockListItem
+	blockCode
+	blockQuote
+	blockTable
+	blockRule
+)
+
+// block is a paragraph, heading, list item, code block, quote, table or rule of a text.
+type block struct {
+	kind     blockKind
+	level    int      // level is the level of a heading or the depth of a list item, starting at 1
+	marker   string   // marker is the bullet or number of a list item
+	lines    []string // lines are the lines of the block, without the markup of the block
+	children []block  // children are the blocks of a quote
+	rows     [][]string
+	header   []bool // header reports for every row of a table whether it is a header row
+}
+
+// text returns the lines of the block joined to a single line.
+func (b block) text() string {
+	parts := make([]string, 0, len(b.lines))
+	for _, line := range b.lines {
+		parts = append(parts, strings.TrimSpace(line))
+	}
+	return strings.Join(parts, " ")
+}
+
+// listCounter numbers the items of ordered lists by depth.
+type listCounter []int
+
+// marker returns the marker of the next item at depth, start is the number of the first item of a list.
+func (c *listCounter) marker(depth int, ordered bool, start int) string {
+	for len(*c) < depth {
+		*c = append(*c, 0)
+	}
+	*c = (*c)[:depth]
+	if !ordered {
+		(*c)[depth-1] = 0
+		if depth%2 == 0 {
+			return "◦"
+		}
+		return "•"
+	}
+
+	if (*c)[depth-1] == 0 {
+		(*c)[depth-1] = start
+	} else {
+		(*c)[depth-1]++
+	}
+	return strconv.Itoa((*c)[depth-1]) + "."
+}
+
+// parsePre collects an HTML <pre> block starting at lines[i] and returns it with the index of the next line.
+// Both formattings accept <pre> blocks, optionally wrapping a <code> element.
+func parsePre(lines []string, i int) (block, int) {
+	var code []string
+	for ; i < len(lines); i++ {
+		code = append(code, lines[i])
+		if strings.Contains(lines[i], "</pre>") {
+			i++
+			break
+		}
+	}
+
+	text := preTags.ReplaceAllString(strings.Join(code, "\n"), "")
+	text = strings.Trim(html.UnescapeString(text), "\n")
+	return block{kind: blockCode, lines: strings.Split(text, "\n")}, i
+}
+
+var preTags = regexp.MustCompile(`</?(pre|code)[^>]*>`)
+
+// isPre reports whether line starts a <pre> block.
+func isPre(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "<pre")
+}
+
+var (
+	// textileBlock matches the signature of a Textile block, e.g. "h2. " or "bq(quote). "
+	textileBlock = regexp.MustCompile(`^(h[1-6]|bc|bq|p)((?:\([^)]*\)|\{[^}]*\}|\[[^\]]*\]|[<>=])*)(\.\.?) (.*)$`)
+	textileList  = regexp.MustCompile(`^([*#]+) (.*)$`)
+	textileCell  = regexp.MustCompile(`^((?:_|\\\d+|/\d+|[<>=^~]|\{[^}]*\}|\([^)]*\))+)\. ?`)
+	textileRule  = regexp.MustCompile(`^(-{3,}|\*{3,})$`)
+)
+
+// startsTextileBlock reports whether line starts a new block instead of continuing a paragraph.
+func startsTextileBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || textileBlock.MatchString(line) || textileList.MatchString(line) ||
+		strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">") || isPre(line) || textileRule.MatchString(trimmed)
+}
+
+// parseTextile splits Textile lines into blocks.
+func parseTextile(lines []string) []block {
+	var blocks []block
+	var counter listCounter
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			counter = nil
+			i++
+			continue
+		}
+		if !textileList.MatchString(line) {
+			counter = nil
+		}
+
+		switch {
+		case isPre(line):
+			var blk block
+			blk, i = parsePre(lines, i)
+			blocks = append(blocks, blk)
+
+		case textileBlock.MatchString(line):
+			m := textileBlock.FindStringSubmatch(line)
+			kind, extended := m[1], m[3] == ".."
+			body := []string{m[4]}
+			for i++; i < len(lines); i++ {
+				if extended && kind == "bc" {
+					// Extended code blocks run until the next block signature, blank lines included
+					if textileBlock.MatchString(lines[i]) {
+						break
+					}
+				} else if startsTextileBlock(lines[i]) {
+					break
+				}
+				body = append(body, lines[i])
+			}
+
+			switch kind {
+			case "bc":
+				blocks = append(blocks, block{kind: blockCode, lines: trimBlankLines(body)})
+			case "bq":
+				blocks = append(blocks, block{kind: blockQuote, children: []block{{kind: blockParagraph, lines: body}}})
+			case "p":
+				blocks = append(blocks, block{kind: blockParagraph, lines: body})
+			default:
+				level, _ := strconv.Atoi(kind[1:])
+				blocks = append(blocks, block{kind: blockHeading, level: level, lines: body})
+			}
+
+		case textileList.MatchString(line):
+			m := textileList.FindStringSubmatch(line)
+			depth := len(m[1])
+			item := block{kind: blockListItem, level: depth, marker: counter.marker(depth, m[1][depth-1] == '#', 1), lines: []string{m[2]}}
+			for i++; i < len(lines) && !startsTextileBlock(lines[i]); i++ {
+				item.lines = append(item.lines, lines[i])
+			}
+			blocks = append(blocks, item)
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			blocks = append(blocks, block{kind: blockQuote, children: parseTextile(quoted)})
+
+		case strings.HasPrefix(trimmed, "|"):
+			table := block{kind: blockTable}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				row, header := parseTextileRow(strings.TrimSpace(lines[i]))
+				table.rows = append(table.rows, row)
+				table.header = append(table.header, header)
+			}
+			blocks = append(blocks, table)
+
+		case textileRule.MatchString(trimmed):
+			blocks = append(blocks, block{kind: blockRule})
+			i++
+
+		default:
+			paragraph := block{kind: blockParagraph, lines: []string{line}}
+			for i++; i < len(lines) && !startsTextileBlock(lines[i]); i++ {
+				paragraph.lines = append(paragraph.lines, lines[i])
+			}
+			blocks = append(blocks, paragraph)
+		}
+	}
+	return blocks
+}
+
+// parseTextileRow splits a Textile table row into its cells and reports whether it has header cells.
+func parseTextileRow(line string) ([]string, bool) {
+	var cells []string
+	header := false
+	for _, cell := range splitRow(line) {
+		if m := textileCell.FindStringSubmatch(cell); m != nil {
+			header = header || strings.Contains(m[1], "_")
+			cell = cell[len(m[0]):]
+		}
+		cells = append(cells, strings.TrimSpace(cell))
+	}
+	return cells, header
+}
+
+// splitRow splits a table row like "| a | b |" into its cells.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// trimBlankLines removes blank lines at the start and end of lines.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+var (
+	markdownFence     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	markdownHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	markdownRule      = regexp.MustCompile(`^ {0,3}((\* *){3,}|(- *){3,}|(_ *){3,})$`)
+	markdownList      = regexp.MustCompile(`^(\s*)([-*+]|(\d{1,9})[.)])\s+(.*)$`)
+	markdownQuote     = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	markdownSeparator = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	markdownSetext    = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+)
+
+// startsMarkdownBlock reports whether line starts a new block instead of continuing a paragraph.
+func startsMarkdownBlock(line string) bool {
+	return strings.TrimSpace(line) == "" || markdownFence.MatchString(line) || markdownHeading.MatchString(line) ||
+		markdownRule.MatchString(line) || markdownList.MatchString(line) || markdownQuote.MatchString(line) || isPre(line)
+}
+
+// isMarkdownTable reports whether lines[i] is the header row of a table, which is followed by a separator row.
+func isMarkdownTable(lines []string, i int) bool {
+	return strings.Contains(lines[i], "|") && i+1 < len(lines) &&
+		strings.Contains(lines[i+1], "-") && markdownSeparator.MatchString(lines[i+1])
+}
+
+// parseMarkdown splits CommonMark lines into blocks.
+func parseMarkdown(lines []string) []block {
+	var blocks []block
+	var counter listCounter
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			i++
+			continue
+		}
+
+		list := markdownList.FindStringSubmatch(line)
+		if list == nil || markdownRule.MatchString(line) {
+			counter = nil
+		}
+
+		switch {
+		case markdownFence.MatchString(line):
+			fence := markdownFence.FindStringSubmatch(line)[1]
+			code := block{kind: blockCode}
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					i++
+					break
+				}
+				code.lines = append(code.lines, lines[i])
+			}
+			blocks = append(blocks, code)
+
+		case isPre(line):
+			var blk block
+			blk, i = parsePre(lines, i)
+			blocks = append(blocks, blk)
+
+		case strings.HasPrefix(line, "    ") && (len(blocks) == 0 || blocks[len(blocks)-1].kind != blockListItem):
+			code := block{kind: blockCode}
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.TrimSpace(lines[i]) == ""); i++ {
+				code.lines = append(code.lines, strings.TrimPrefix(lines[i], "    "))
+			}
+			code.lines = trimBlankLines(code.lines)
+			blocks = append(blocks, code)
+
+		case markdownHeading.MatchString(line):
+			m := markdownHeading.FindStringSubmatch(line)
+			blocks = append(blocks, block{kind: blockHeading, level: len(m[1]), lines: []string{m[2]}})
+			i++
+
+		case markdownRule.MatchString(line):
+			blocks = append(blocks, block{kind: blockRule})
+			i++
+
+		case list != nil:
+			depth := min(len(list[1])/2+1, len(counter)+1)
+			start, _ := strconv.Atoi(list[3])
+			item := block{kind: blockListItem, level: depth, marker: counter.marker(depth, list[3] != "", start), lines: []string{list[4]}}
+			for i++; i < len(lines) && !startsMarkdownBlock(lines[i]); i++ {
+				item.lines = append(item.lines, lines[i])
+			}
+			blocks = append(blocks, item)
+
+		case markdownQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && markdownQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, markdownQuote.FindStringSubmatch(lines[i])[1])
+			}
+			blocks = append(blocks, block{kind: blockQuote, children: parseMarkdown(quoted)})
+
+		case isMarkdownTable(lines, i):
+			table := block{kind: blockTable, rows: [][]string{splitRow(line)}, header: []bool{true}}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+				table.rows = append(table.rows, splitRow(lines[i]))
+				table.header = append(table.header, false)
+			}
+			blocks = append(blocks, table)
+
+		default:
+			paragraph := block{kind: blockParagraph, lines: []string{line}}
+			for i++; i < len(lines) && !startsMarkdownBlock(lines[i]) && !isMarkdownTable(lines, i) && !markdownSetext.MatchString(lines[i]); i++ {
+				paragraph.lines = append(paragraph.lines, lines[i])
+			}
+
+			// A line of "=" or "-" below a paragraph turns it into a heading
+			if i < len(lines) && markdownSetext.MatchString(lines[i]) {
+				level := 1
+				if strings.Contains(lines[i], "-") {
+					level = 2
+				}
+				blocks = append(blocks, block{kind: blockHeading, level: level, lines: paragraph.lines})
+				i++
+				continue
+			}
+			blocks = append(blocks, paragraph)
+		}
+	}
+	return blocks
+}
@@ -0,0 +1,193 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// emphasis is an inline markup enclosing text between two delimiters, e.g. *bold*.
+type emphasis struct {
+	delimiter string
+	style     lipgloss.Style
+}
+
+var (
+	bold      = lipgloss.NewStyle().Bold(true)
+	italic    = lipgloss.NewStyle().Italic(true)
+	underline = lipgloss.NewStyle().Underline(true)
+	strike    = lipgloss.NewStyle().Strikethrough(true)
+
+	// textileEmphasis are the inline markups of Textile, longer delimiters first.
+	textileEmphasis = []emphasis{
+		{"**", bold}, {"__", italic}, {"??", italic},
+		{"*", bold}, {"_", italic}, {"+", underline}, {"-", strike},
+	}
+
+	// markdownEmphasis are the inline markups of CommonMark, longer delimiters first.
+	markdownEmphasis = []emphasis{
+		{"**", bold}, {"__", bold}, {"~~", strike},
+		{"*", italic}, {"_", italic},
+	}
+)
+
+var (
+	textileLink    = regexp.MustCompile(`^"([^"]+)":(\S+)`)
+	textileImage   = regexp.MustCompile(`^!(?:\{[^}]*\}|[<>])?([^!\s]+?)(?:\(([^)]*)\))?!(?::\S+)?`)
+	markdownLink   = regexp.MustCompile(`^(!?)\[([^\]]*)\]\(([^)\s]*)(?:\s+"[^"]*")?\)`)
+	markdownAuto   = regexp.MustCompile(`^<((?:https?|ftp)://[^>\s]+)>`)
+	bareURL        = regexp.MustCompile(`^(?:https?|ftp)://[^\s<>"]+`)
+	issueRef       = regexp.MustCompile(`^#\d+`)
+	urlPunctuation = ".,;:!?)'"
+)
+
+// inline renders the inline markup of a single line.
+func (r *Renderer) inline(text string, format redmine.TextFormat) string {
+	var b strings.Builder
+	plain := 0
+	for i := 0; i < len(text); {
+		if out, n := r.inlineAt(text, i, format); n > 0 {
+			b.WriteString(text[plain:i])
+			b.WriteString(out)
+			i += n
+			plain = i
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	b.WriteString(text[plain:])
+	return b.String()
+}
+
+// inlineAt renders the markup starting at text[i] and returns it with its length in text, 0 if there is none.
+func (r *Renderer) inlineAt(text string, i int, format redmine.TextFormat) (string, int) {
+	rest := text[i:]
+	atBoundary := i == 0 || !isWordRune(lastRune(text[:i]))
+
+	switch {
+	case format == redmine.TextFormatMarkdown && rest[0] == '\\' && len(rest) > 1 && unicode.IsPunct(rune(rest[1])):
+		return rest[1:2], 2
+
+	case format == redmine.TextFormatMarkdown && rest[0] == '`':
+		ticks := len(rest) - len(strings.TrimLeft(rest, "`"))
+		if end := strings.Index(rest[ticks:], rest[:ticks]); end > 0 {
+			return r.code.Render(strings.TrimSpace(rest[ticks : ticks+end])), 2*ticks + end
+		}
+
+	case format == redmine.TextFormatTextile && rest[0] == '@' && atBoundary:
+		if end := strings.IndexByte(rest[1:], '@'); end > 0 {
+			return r.code.Render(rest[1 : 1+end]), end + 2
+		}
+
+	case format == redmine.TextFormatMarkdown && (rest[0] == '[' || strings.HasPrefix(rest, "![")):
+		if m := markdownLink.FindStringSubmatch(rest); m != nil {
+			if m[1] == "!" {
+				return r.muted.Render("[image: " + orText(m[2], m[3]) + "]"), len(m[0])
+			}
+			return r.renderLink(r.inline(m[2], format), m[3]), len(m[0])
+		}
+
+	case format == redmine.TextFormatMarkdown && rest[0] == '<':
+		if m := markdownAuto.FindStringSubmatch(rest); m != nil {
+			return r.link.Render(m[1]), len(m[0])
+		}
+
+	case format == redmine.TextFormatTextile && rest[0] == '"' && atBoundary:
+		if m := textileLink.FindStringSubmatch(rest); m != nil {
+			url := strings.TrimRight(m[2], urlPunctuation)
+			return r.renderLink(r.inline(m[1], format), url), len(m[0]) - len(m[2]) + len(url)
+		}
+
+	case format == redmine.TextFormatTextile && rest[0] == '!' && atBoundary:
+		if m := textileImage.FindStringSubmatch(rest); m != nil {
+			return r.muted.Render("[image: " + orText(m[2], m[1]) + "]"), len(m[0])
+		}
+
+	case rest[0] == '#' && atBoundary && (i == 0 || text[i-1] != '&'):
+		if ref := issueRef.FindString(rest); ref != "" && !isWordRune(firstRune(rest[len(ref):])) {
+			return r.link.Render(ref), len(ref)
+		}
+
+	case (rest[0] == 'h' || rest[0] == 'f') && atBoundary:
+		if url := strings.TrimRight(bareURL.FindString(rest), urlPunctuation); url != "" {
+			return r.link.Render(url), len(url)
+		}
+	}
+
+	if !atBoundary {
+		return "", 0
+	}
+	emphases := textileEmphasis
+	if format == redmine.TextFormatMarkdown {
+		emphases = markdownEmphasis
+	}
+	for _, e := range emphases {
+		if content, n := enclosed(rest, e.delimiter); n > 0 {
+			return e.style.Render(r.inline(content, format)), n
+		}
+	}
+	return "", 0
+}
+
+// enclosed returns the text between delimiter at the start of text and the matching closing delimiter with the length of the markup.
+// The opening delimiter must be followed and the closing one preceded by a non-space, and the closing one must end a word.
+func enclosed(text, delimiter string) (string, int) {
+	d := len(delimiter)
+	if !strings.HasPrefix(text, delimiter) || len(text) <= 2*d || unicode.IsSpace(firstRune(text[d:])) {
+		return "", 0
+	}
+	// A run of delimiter characters longer than delimiter is another markup, e.g. ** instead of *
+	if strings.HasPrefix(text[d:], delimiter[:1]) {
+		return "", 0
+	}
+
+	for j := d + 1; j+d <= len(text); j++ {
+		if text[j:j+d] != delimiter || unicode.IsSpace(lastRune(text[:j])) {
+			continue
+		}
+		after := firstRune(text[j+d:])
+		if isWordRune(after) || strings.HasPrefix(text[j+d:], delimiter[:1]) {
+			continue
+		}
+		return text[d:j], j + d
+	}
+	return "", 0
+}
+
+// renderLink renders a link, naming the target unless it is relative or shown as the text.
+func (r *Renderer) renderLink(text, url string) string {
+	if url == "" || text == url || strings.HasPrefix(url, "/") || strings.HasPrefix(url, "#") {
+		return r.link.Render(orText(text, url))
+	}
+	return r.link.Render(orText(text, url)) + r.muted.Render(" ("+url+")")
+}
+
+// orText returns text, or fallback if text is empty.
+func orText(text, fallback string) string {
+	if text == "" {
+		return fallback
+	}
+	return text
+}
+
+// isWordRune reports whether r is a letter or digit, utf8.RuneError for no rune at all.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// firstRune returns the first rune of s, utf8.RuneError if s is empty.
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// lastRune returns the last rune of s, utf8.RuneError if s is empty.
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
@@ -0,0 +1,230 @@
+// Package markup renders the Textile and CommonMark texts of Redmine issues as styled terminal output.
+package markup
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// textileSigns match markup only Textile uses.
+	textileSigns = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^(h[1-6]|bc|bq|p)\.{1,2} `),
+		regexp.MustCompile(`(?m)^\|_\.`),
+		regexp.MustCompile(`"[^"\n]+":(https?://|/)\S`),
+		regexp.MustCompile(`(^|\s)@[^@\s][^@\n]*@`),
+		regexp.MustCompile(`(?m)^#+ .*\n#+ `),
+	}
+
+	// markdownSigns match markup only CommonMark uses.
+	markdownSigns = []*regexp.Regexp{
+		regexp.MustCompile("(?m)^ {0,3}(```|~~~)"),
+		regexp.MustCompile(`(?m)^#{1,6} \S.*(\n[^#]|\z)`),
+		regexp.MustCompile(`!?\[[^\]\n]+\]\([^)\s]+\)`),
+		regexp.MustCompile("(^|\\s)`[^`\n]+`"),
+		regexp.MustCompile(`(?m)^\s*[-+] \S`),
+		regexp.MustCompile(`(?m)^\|?\s*:?-{3,}:?\s*\|`),
+	}
+)
+
+// Detect guesses the formatting of text from the markup it uses.
+// Detect returns redmine.TextFormatTextile if the text uses no markup specific to CommonMark.
+func Detect(text string) redmine.TextFormat {
+	textile, markdown := 0, 0
+	for _, sign := range textileSigns {
+		if sign.MatchString(text) {
+			textile++
+		}
+	}
+	for _, sign := range markdownSigns {
+		if sign.MatchString(text) {
+			markdown++
+		}
+	}
+
+	if markdown > textile {
+		return redmine.TextFormatMarkdown
+	}
+	return redmine.TextFormatTextile
+}
+
+// Renderer renders texts of a Redmine instance with the colors of a theme.
+type Renderer struct {
+	format redmine.TextFormat
+
+	heading    lipgloss.Style
+	subheading lipgloss.Style
+	code       lipgloss.Style
+	link       lipgloss.Style
+	muted      lipgloss.Style
+	marker     lipgloss.Style
+}
+
+// NewRenderer creates a renderer for texts in format, redmine.TextFormatAuto detects the formatting of every text.
+func NewRenderer(format redmine.TextFormat, theme themes.Theme) *Renderer {
+	return &Renderer{
+		format:     format,
+		heading:    lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true),
+		subheading: lipgloss.NewStyle().Foreground(theme.Secondary).Bold(true),
+		code:       lipgloss.NewStyle().Foreground(theme.Secondary),
+		link:       lipgloss.NewStyle().Foreground(theme.Link).Underline(true),
+		muted:      lipgloss.NewStyle().Foreground(theme.Muted),
+		marker:     lipgloss.NewStyle().Foreground(theme.Highlight),
+	}
+}
+
+// Render renders text, wrapping its lines to width.
+// Code blocks keep their line breaks and indentation, long code lines are broken at width.
+func (r *Renderer) Render(text string, width int) string {
+	format := r.format
+	if format == redmine.TextFormatAuto {
+		format = Detect(text)
+	}
+
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\t", "    ")
+	var blocks []block
+	if format == redmine.TextFormatMarkdown {
+		blocks = parseMarkdown(strings.Split(text, "\n"))
+	} else {
+		blocks = parseTextile(strings.Split(text, "\n"))
+	}
+	return r.renderBlocks(blocks, format, max(width, 10))
+}
+
+// renderBlocks renders blocks separated by blank lines, the items of a list without.
+func (r *Renderer) renderBlocks(blocks []block, format redmine.TextFormat, width int) string {
+	var b strings.Builder
+	for i, blk := range blocks {
+		if i > 0 {
+			b.WriteString("\n")
+			if blk.kind != blockListItem || blocks[i-1].kind != blockListItem {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(r.renderBlock(blk, format, width))
+	}
+	return b.String()
+}
+
+// renderBlock renders a single block.
+func (r *Renderer) renderBlock(blk block, format redmine.TextFormat, width int) string {
+	switch blk.kind {
+	case blockHeading:
+		style := r.heading
+		if blk.level > 2 {
+			style = r.subheading
+		}
+		return style.Render(wrap(r.inline(blk.text(), format), width))
+
+	case blockListItem:
+		indent := strings.Repeat("  ", blk.level-1)
+		marker := blk.marker
+		hanging := len(indent) + ansi.StringWidth(marker) + 1
+		lines := strings.Split(wrap(r.inline(blk.text(), format), width-hanging), "\n")
+		for i := range lines {
+			if i == 0 {
+				lines[i] = indent + r.marker.Render(marker) + " " + lines[i]
+			} else {
+				lines[i] = strings.Repeat(" ", hanging) + lines[i]
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case blockCode:
+		lines := make([]string, 0, len(blk.lines))
+		for _, line := range blk.lines {
+			for _, part := range strings.Split(ansi.Hardwrap(line, width-2, true), "\n") {
+				lines = append(lines, "  "+r.code.Render(part))
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case blockQuote:
+		lines := strings.Split(r.renderBlocks(blk.children, format, width-2), "\n")
+		for i := range lines {
+			lines[i] = r.muted.Render("│") + " " + lines[i]
+		}
+		return strings.Join(lines, "\n")
+
+	case blockTable:
+		return r.renderTable(blk, format, width)
+
+	case blockRule:
+		return r.muted.Render(strings.Repeat("─", width))
+	}
+
+	// Line breaks within a paragraph are kept, as Redmine renders them as breaks too
+	lines := make([]string, 0, len(blk.lines))
+	for _, line := range blk.lines {
+		lines = append(lines, wrap(r.inline(strings.TrimSpace(line), format), width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderTable renders the rows of a table in aligned columns.
+// Tables wider than width are rendered row by row instead, wrapping every row.
+func (r *Renderer) renderTable(blk block, format redmine.TextFormat, width int) string {
+	rows := make([][]string, len(blk.rows))
+	var widths []int
+	for i, row := range blk.rows {
+		rows[i] = make([]string, len(row))
+		for j, cell := range row {
+			rows[i][j] = r.inline(cell, format)
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], ansi.StringWidth(rows[i][j]))
+		}
+	}
+
+	total := 3 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+
+	separator := r.muted.Render(" │ ")
+	bold := lipgloss.NewStyle().Bold(true)
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		if total > width {
+			line := wrap(strings.Join(row, separator), width)
+			if blk.header[i] {
+				line = bold.Render(line)
+			}
+			lines = append(lines, line)
+			continue
+		}
+
+		cells := make([]string, len(widths))
+		for j := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			if blk.header[i] {
+				cell = bold.Render(cell)
+			}
+			cells[j] = cell + strings.Repeat(" ", widths[j]-ansi.StringWidth(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, separator), " "))
+
+		if blk.header[i] && (i+1 == len(rows) || !blk.header[i+1]) {
+			rules := make([]string, len(widths))
+			for j, w := range widths {
+				rules[j] = strings.Repeat("─", w)
+			}
+			lines = append(lines, r.muted.Render(strings.Join(rules, "─┼─")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap wraps text at word boundaries to width, breaking words longer than width.
+func wrap(text string, width int) string {
+	return ansi.Wrap(text, max(width, 1), "")
+}
@@ -0,0 +1,95 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// render renders text without colors, so the tests compare the plain text.
+func render(format redmine.TextFormat, text string, width int) string {
+	return ansi.Strip(NewRenderer(format, themes.TokyoNight).Render(text, width))
+}
+
+// TestDetect verifies that the formatting is guessed from the markup of the text.
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text string
+		want redmine.TextFormat
+	}{
+		{"h2. Steps\n\n# open the page\n# log in", redmine.TextFormatTextile},
+		{"See \"the docs\":https://example.com and @make test@", redmine.TextFormatTextile},
+		{"## Steps\n\n- open the page\n- log in", redmine.TextFormatMarkdown},
+		{"Run `make test`, see [the docs](https://example.com)\n\n```\ncode\n```", redmine.TextFormatMarkdown},
+		{"Plain text without markup", redmine.TextFormatTextile},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.text); got != tt.want {
+			t.Errorf("Detect(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestRenderer_Textile verifies headings, inline markup, lists, code blocks and tables of Textile texts.
+func TestRenderer_Textile(t *testing.T) {
+	text := "h2. Steps\n\n" +
+		"The *login* fails for _some_ users, see #123 and \"the docs\":/projects/web/wiki.\n\n" +
+		"# open the page\n## enter @user@\n# log in\n\n" +
+		"<pre><code class=\"ruby\">\nif a &lt; b\n  fail\nend\n</code></pre>\n\n" +
+		"|_. Name |_. Value |\n| a | 1 |\n| long name | 22 |"
+
+	want := "Steps\n\n" +
+		"The login fails for some users, see #123 and the docs.\n\n" +
+		"1. open the page\n  1. enter user\n2. log in\n\n" +
+		"  if a < b\n    fail\n  end\n\n" +
+		"Name      │ Value\n──────────┼──────\na         │ 1\nlong name │ 22"
+
+	if got := render(redmine.TextFormatTextile, text, 80); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestRenderer_Markdown verifies headings, inline markup, lists, code blocks, quotes and tables of CommonMark texts.
+func TestRenderer_Markdown(t *testing.T) {
+	text := "Steps\n=====\n\n" +
+		"The **login** fails for *some* users, see [the docs](https://example.com) and `snake_case`.\n\n" +
+		"1. open the page\n   - enter user\n2. log in\n\n" +
+		"```go\nfunc main() {\n\tpanic(\"*x*\")\n}\n```\n\n" +
+		"> quoted\n\n" +
+		"| Name | Value |\n|------|------:|\n| a | 1 |"
+
+	want := "Steps\n\n" +
+		"The login fails for some users, see the docs (https://example.com) and snake_case.\n\n" +
+		"1. open the page\n  ◦ enter user\n2. log in\n\n" +
+		"  func main() {\n      panic(\"*x*\")\n  }\n\n" +
+		"│ quoted\n\n" +
+		"Name │ Value\n─────┼──────\na    │ 1"
+
+	if got := render(redmine.TextFormatMarkdown, text, 100); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestRenderer_Wrap verifies that paragraphs and list items wrap to the width and long code lines are broken.
+func TestRenderer_Wrap(t *testing.T) {
+	text := "The login page fails for users with long names\n\n" +
+		"* an item that is too long for a single line\n\n" +
+		"bc. averyveryverylongidentifier"
+
+	want := "The login page fails for\nusers with long names\n\n" +
+		"• an item that is too\n  long for a single line\n\n" +
+		"  averyveryverylongidenti\n  fier"
+
+	got := render(redmine.TextFormatTextile, text, 25)
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if ansi.StringWidth(line) > 25 {
+			t.Errorf("line %q is wider than 25 columns", line)
+		}
+	}
+}
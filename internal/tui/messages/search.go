@@ -11,3 +11,12 @@ type SearchCompletedMsg struct {
 	Results []*domain.Issue
 	Error   error
 }
+
+// FilterBuilderOpenMsg is sent when the user wants to build an issue query from the filters of Query.
+// An empty Query starts without filters.
+type FilterBuilderOpenMsg struct {
+	Query string
+}
+
+// FavoritesChangedMsg is sent when the favorites in the config file have been changed outside of the search view.
+type FavoritesChangedMsg struct{}
@@ -1,7 +1,51 @@
 package messages
 
-import "github.com/b1tray3r/rmt/internal/tui/domain"
+import (
+	"time"
 
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/timer"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// TimeEntryCreateMsg is sent when the user wants to log time on an issue.
+// Date preselects the day of the time entry, the zero value stands for today.
+// Hours preselects the time spent, e.g. measured by a timer, the zero value keeps the default.
+// Timer is the stopped timer Hours were measured by, nil if the time entry does not log a timer.
 type TimeEntryCreateMsg struct {
 	Issue *domain.Issue
+	Date  time.Time
+	Hours float64
+	Timer *timer.Timer
+}
+
+// TimeEntriesOpenMsg is sent when the user wants to see their time entries matching Filter.
+// Title describes the selection, e.g. the issue or the day the time entries belong to.
+type TimeEntriesOpenMsg struct {
+	Title  string
+	Filter models.TimeEntryFilter
+}
+
+// TimeEntryEditMsg is sent when the user wants to change an existing time entry.
+type TimeEntryEditMsg struct {
+	Issue *domain.Issue
+	Entry models.TimeEntry
 }
+
+// ReturnToTimeEntriesMsg indicates the user wants to return to the list of time entries.
+// Parent applications should handle this message to navigate back and reload the list.
+type ReturnToTimeEntriesMsg struct{}
+
+// OutboxChangedMsg is sent when time entries have been queued in the outbox or have left it.
+type OutboxChangedMsg struct{}
+
+// OutboxEditMsg is sent when the user wants to change a time entry waiting in the outbox.
+type OutboxEditMsg struct {
+	Issue *domain.Issue
+	Entry outbox.Entry
+}
+
+// ReturnToOutboxMsg indicates the user wants to return to the outbox.
+// Parent applications should handle this message to navigate back and reload the outbox.
+type ReturnToOutboxMsg struct{}
@@ -0,0 +1,18 @@
+package messages
+
+import (
+	"github.com/b1tray3r/rmt/internal/timer"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+)
+
+// TimerToggleMsg is sent when the user wants to start or stop the timer for an issue.
+// A timer running for another issue is stopped before the timer for Issue is started.
+type TimerToggleMsg struct {
+	Issue *domain.Issue
+}
+
+// TimerLoggedMsg is sent once the time measured by a stopped timer has been saved as time entry,
+// or the user discarded it. The timer is forgotten then.
+type TimerLoggedMsg struct {
+	Timer timer.Timer
+}
@@ -68,6 +68,12 @@ func (dp *DatePicker) Blur() {
 	dp.focused = false
 }
 
+// SetDate selects the given date and shows its month
+func (dp *DatePicker) SetDate(date time.Time) {
+	dp.selectedDate = date
+	dp.viewDate = date
+}
+
 // SelectedDate returns the currently selected date
 func (dp *DatePicker) SelectedDate() time.Time {
 	return dp.selectedDate
@@ -0,0 +1,897 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Kinds of rows of the FilterBuilderView, deciding how their value is picked.
+const (
+	filterRowList        = iota // filterRowList picks values loaded from Redmine
+	filterRowDate               // filterRowDate picks a date range
+	filterRowText               // filterRowText asks for a text the field has to contain
+	filterRowCustomField        // filterRowCustomField picks a custom field first and its value afterwards
+)
+
+// Modes of the FilterBuilderView.
+const (
+	filterModeRows   = iota // filterModeRows navigates the rows
+	filterModePicker        // filterModePicker picks a value for the selected row
+	filterModeInput         // filterModeInput asks for a value the picked choice needs
+	filterModeName          // filterModeName asks for the name of the favorite to save
+)
+
+// datePattern matches the dates accepted by Redmine date filters.
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// filterChoice is an entry of the picker of the FilterBuilderView.
+type filterChoice struct {
+	label    string
+	operator redmine.Operator
+	value    string
+	prompt   string // prompt asks for the value in a text input before the choice applies, e.g. a number of days
+}
+
+// filterRow is a field of the issue query offered by the FilterBuilderView.
+type filterRow struct {
+	label   string
+	field   string         // field is the query field, empty for the custom field row
+	kind    int            // kind decides how the value is picked
+	choices []filterChoice // choices are offered before the values loaded from Redmine
+}
+
+// dateChoices are the ranges offered for date fields.
+var dateChoices = []filterChoice{
+	{label: "today", operator: redmine.OpToday},
+	{label: "yesterday", operator: redmine.OpYesterday},
+	{label: "this week", operator: redmine.OpThisWeek},
+	{label: "last week", operator: redmine.OpLastWeek},
+	{label: "this month", operator: redmine.OpThisMonth},
+	{label: "last month", operator: redmine.OpLastMonth},
+	{label: "this year", operator: redmine.OpThisYear},
+	{label: "in the last days…", operator: redmine.OpLastNumDays, prompt: "Number of days"},
+	{label: "between…", operator: redmine.OpBetween, prompt: "From..to, e.g. 2025-08-01..2025-08-31"},
+	{label: "any date", operator: redmine.OpAny},
+	{label: "no date", operator: redmine.OpNone},
+}
+
+// customValueChoices are offered for custom fields in addition to their possible values and the date ranges.
+var customValueChoices = []filterChoice{
+	{label: "is…", operator: redmine.OpEquals, prompt: "Value"},
+	{label: "contains…", operator: redmine.OpContains, prompt: "Text"},
+	{label: "any value", operator: redmine.OpAny},
+	{label: "no value", operator: redmine.OpNone},
+}
+
+// filterRows returns the rows offered by the FilterBuilderView.
+func filterRows() []filterRow {
+	return []filterRow{
+		{label: "Project", field: domain.FilterProject, kind: filterRowList},
+		{label: "Tracker", field: domain.FilterTracker, kind: filterRowList},
+		{label: "Status", field: domain.FilterStatus, kind: filterRowList, choices: []filterChoice{
+			{label: "open", operator: redmine.OpOpen},
+			{label: "closed", operator: redmine.OpClosed},
+			{label: "any status", operator: redmine.OpAny},
+		}},
+		{label: "Assignee", field: domain.FilterAssignee, kind: filterRowList, choices: []filterChoice{
+			{label: "me", operator: redmine.OpEquals, value: "me"},
+			{label: "nobody", operator: redmine.OpNone},
+		}},
+		{label: "Version", field: domain.FilterVersion, kind: filterRowList, choices: []filterChoice{
+			{label: "no version", operator: redmine.OpNone},
+		}},
+		{label: "Custom field", kind: filterRowCustomField},
+		{label: "Subject", field: "subject", kind: filterRowText},
+		{label: "Created", field: "created_on", kind: filterRowDate},
+		{label: "Updated", field: "updated_on", kind: filterRowDate},
+		{label: "Start date", field: "start_date", kind: filterRowDate},
+		{label: "Due date", field: "due_date", kind: filterRowDate},
+	}
+}
+
+// FilterOptionsLoadedMsg is sent when the values of a field have been loaded for the FilterBuilderView.
+type FilterOptionsLoadedMsg struct {
+	Field     string
+	ProjectID string
+	Options   []domain.FilterOption
+	Error     error
+}
+
+// FilterBuilderView builds a Redmine issue query from values picked per field.
+// FilterBuilderView runs the query as search or saves it as favorite.
+type FilterBuilderView struct {
+	width, height int
+
+	source  domain.FilterOptionLister
+	config  *config.Config
+	ctx     context.Context
+	cancel  context.CancelFunc
+	spinner spinner.Model
+
+	query       *redmine.IssueQuery
+	rows        []filterRow
+	cursor      int
+	customField string // customField is the custom field of the custom field row, empty until one is picked
+
+	options map[string][]domain.FilterOption // options caches the loaded values by field and project
+	labels  map[string]map[string]string     // labels maps the loaded values of a field to their labels
+
+	mode          int
+	pickerField   string         // pickerField is the field the picker chooses a value for
+	choices       []filterChoice // choices are all entries of the picker
+	pickerCursor  int
+	marked        map[string]bool // marked are the values selected with space for a filter matching several values
+	pickerLoading bool
+	search        textinput.Model // search narrows the entries of the picker
+	input         textinput.Model // input asks for the value of a choice or the name of the favorite
+	inputChoice   filterChoice
+
+	status string
+	err    error
+}
+
+// NewFilterBuilderView creates a filter builder starting with the filters of the raw query string.
+// The options of the fields are loaded from source, favorites are saved to cfg.
+func NewFilterBuilderView(width, height int, raw string, source domain.FilterOptionLister, cfg *config.Config) *FilterBuilderView {
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
+
+	v := &FilterBuilderView{
+		width:   width,
+		height:  height,
+		source:  source,
+		config:  cfg,
+		spinner: s,
+		rows:    filterRows(),
+		options: make(map[string][]domain.FilterOption),
+		labels:  make(map[string]map[string]string),
+		search:  newFavoriteInput("Type to filter", width),
+		input:   newFavoriteInput("", width),
+	}
+
+	query, err := redmine.ParseIssueQuery(raw)
+	if err != nil {
+		v.err = err
+		query = &redmine.IssueQuery{}
+	}
+	if query.Params == nil {
+		query.Params = make(map[string][]string)
+	}
+	v.query = query
+
+	for _, filter := range query.Filters {
+		if strings.HasPrefix(filter.Field, "cf_") {
+			v.customField = filter.Field
+			break
+		}
+	}
+
+	return v
+}
+
+// Init loads the labels of the values the query starts with.
+func (v *FilterBuilderView) Init() tea.Cmd {
+	ctx := v.requestContext()
+
+	var cmds []tea.Cmd
+	for _, row := range v.rows {
+		if row.kind != filterRowList {
+			continue
+		}
+		if filter, ok := v.query.Filter(row.field); ok && len(filter.Values) > 0 {
+			cmds = append(cmds, v.loadOptions(ctx, row.field))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Cancel aborts loading the values of the fields.
+func (v *FilterBuilderView) Cancel() {
+	if v.cancel != nil {
+		v.cancel()
+		v.ctx, v.cancel = nil, nil
+	}
+}
+
+// IsEditing reports whether the picker or an input is open, the view then needs the esc key to close it.
+func (v *FilterBuilderView) IsEditing() bool {
+	return v.mode != filterModeRows
+}
+
+// Query returns the query string built so far.
+func (v *FilterBuilderView) Query() string {
+	return v.query.String()
+}
+
+// projectID returns the project the query is restricted to, empty if it is not restricted to a single one.
+func (v *FilterBuilderView) projectID() string {
+	filter, ok := v.query.Filter(domain.FilterProject)
+	if !ok || filter.Operator != redmine.OpEquals || len(filter.Values) != 1 {
+		return ""
+	}
+	return filter.Values[0]
+}
+
+// optionsKey returns the key of the options of field in the cache.
+func optionsKey(field, projectID string) string {
+	return field + "@" + projectID
+}
+
+// loadOptions returns a command loading the values of field, nil if they are cached already.
+func (v *FilterBuilderView) loadOptions(ctx context.Context, field string) tea.Cmd {
+	projectID := ""
+	if field == domain.FilterAssignee || field == domain.FilterVersion {
+		projectID = v.projectID()
+	}
+	if _, ok := v.options[optionsKey(field, projectID)]; ok {
+		return nil
+	}
+
+	source := v.source
+	return func() tea.Msg {
+		options, err := source.ListFilterOptions(ctx, field, projectID)
+		return FilterOptionsLoadedMsg{Field: field, ProjectID: projectID, Options: options, Error: err}
+	}
+}
+
+// Update handles the navigation of the rows, the picker and the inputs.
+func (v *FilterBuilderView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case FilterOptionsLoadedMsg:
+		return v.optionsLoaded(msg)
+
+	case spinner.TickMsg:
+		if !v.pickerLoading {
+			return nil
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
+
+	case tea.KeyMsg:
+		switch v.mode {
+		case filterModePicker:
+			return v.updatePicker(msg)
+		case filterModeInput, filterModeName:
+			return v.updateInput(msg)
+		}
+		return v.updateRows(msg)
+	}
+
+	return nil
+}
+
+// optionsLoaded caches the loaded values and shows them in the picker if it waits for them.
+func (v *FilterBuilderView) optionsLoaded(msg FilterOptionsLoadedMsg) tea.Cmd {
+	waiting := v.mode == filterModePicker && v.pickerLoading && v.pickerField == msg.Field
+
+	if msg.Error != nil {
+		if waiting {
+			v.pickerLoading = false
+			switch {
+			case msg.Field == domain.FilterCustomFields:
+				// Only administrators may list custom fields, everybody else names the field by its ID
+				v.closePicker()
+				return v.openInput(filterChoice{label: "Custom field", prompt: "Custom field ID, e.g. 88"})
+			case errors.Is(msg.Error, domain.ErrProjectRequired):
+				v.err = fmt.Errorf("%w to choose from its members and versions", msg.Error)
+			default:
+				v.err = msg.Error
+			}
+		}
+		return nil
+	}
+
+	v.options[optionsKey(msg.Field, msg.ProjectID)] = msg.Options
+	if v.labels[msg.Field] == nil {
+		v.labels[msg.Field] = make(map[string]string)
+	}
+	for _, option := range msg.Options {
+		v.labels[msg.Field][option.Value] = option.Label
+	}
+
+	if waiting {
+		v.pickerLoading = false
+		v.choices = append(v.choices, optionChoices(msg.Options)...)
+	}
+	return nil
+}
+
+// optionChoices turns the loaded values into picker entries matching the value.
+func optionChoices(options []domain.FilterOption) []filterChoice {
+	choices := make([]filterChoice, 0, len(options))
+	for _, option := range options {
+		choices = append(choices, filterChoice{label: option.Label, operator: redmine.OpEquals, value: option.Value})
+	}
+	return choices
+}
+
+// selectedRow returns the row under the cursor.
+func (v *FilterBuilderView) selectedRow() filterRow {
+	return v.rows[v.cursor]
+}
+
+// rowField returns the query field of row, empty for the custom field row without a field.
+func (v *FilterBuilderView) rowField(row filterRow) string {
+	if row.kind == filterRowCustomField {
+		return v.customField
+	}
+	return row.field
+}
+
+// updateRows handles the keys while navigating the rows.
+func (v *FilterBuilderView) updateRows(msg tea.KeyMsg) tea.Cmd {
+	v.status = ""
+
+	switch msg.String() {
+	case "up", "k":
+		v.cursor = max(v.cursor-1, 0)
+	case "down", "j":
+		v.cursor = min(v.cursor+1, len(v.rows)-1)
+	case "enter", " ":
+		v.err = nil
+		return v.editRow()
+	case "x", "delete", "backspace":
+		v.err = nil
+		row := v.selectedRow()
+		if field := v.rowField(row); field != "" {
+			v.query.RemoveFilter(field)
+		}
+		if row.kind == filterRowCustomField {
+			v.customField = ""
+		}
+	case "n":
+		v.negateRow()
+	case "c":
+		if v.selectedRow().kind == filterRowCustomField {
+			v.err = nil
+			if v.customField != "" {
+				v.query.RemoveFilter(v.customField)
+				v.customField = ""
+			}
+			return v.editRow()
+		}
+	case "r":
+		return v.run()
+	case "s":
+		if v.query.String() == "" {
+			v.err = errors.New("add a filter before saving the query as favorite")
+			return nil
+		}
+		v.err = nil
+		v.input.Reset()
+		v.input.Placeholder = "Name of the favorite"
+		v.mode = filterModeName
+		return v.input.Focus()
+	}
+
+	return nil
+}
+
+// negateRow switches the filter of the selected row between matching and excluding its values.
+func (v *FilterBuilderView) negateRow() {
+	field := v.rowField(v.selectedRow())
+	filter, ok := v.query.Filter(field)
+	if !ok {
+		return
+	}
+
+	switch filter.Operator {
+	case redmine.OpEquals:
+		filter.Operator = redmine.OpNotEquals
+	case redmine.OpNotEquals:
+		filter.Operator = redmine.OpEquals
+	case redmine.OpAny:
+		filter.Operator = redmine.OpNone
+	case redmine.OpNone:
+		filter.Operator = redmine.OpAny
+	default:
+		v.err = fmt.Errorf("the %s filter cannot be negated", v.selectedRow().label)
+		return
+	}
+	v.query.SetFilter(filter)
+}
+
+// editRow opens the picker or the input for the selected row.
+func (v *FilterBuilderView) editRow() tea.Cmd {
+	row := v.selectedRow()
+
+	switch row.kind {
+	case filterRowText:
+		return v.openInput(filterChoice{label: row.label, operator: redmine.OpContains, prompt: "Text the subject contains"})
+	case filterRowDate:
+		return v.openPicker(row.field, dateChoices, false)
+	case filterRowCustomField:
+		if v.customField == "" {
+			return v.openPicker(domain.FilterCustomFields, nil, true)
+		}
+		return v.openPicker(v.customField, slices.Concat(customValueChoices, dateChoices), true)
+	}
+
+	return v.openPicker(row.field, row.choices, true)
+}
+
+// openPicker shows the choices for field, followed by the values loaded from Redmine if load is set.
+func (v *FilterBuilderView) openPicker(field string, choices []filterChoice, load bool) tea.Cmd {
+	v.mode = filterModePicker
+	v.pickerField = field
+	v.choices = slices.Clone(choices)
+	v.pickerCursor = 0
+	v.pickerLoading = false
+	v.marked = make(map[string]bool)
+	v.search.Reset()
+
+	// The values of the current filter stay selected, so adding one keeps the others
+	if filter, ok := v.query.Filter(field); ok && filter.Operator == redmine.OpEquals && len(filter.Values) > 1 {
+		for _, value := range filter.Values {
+			v.marked[value] = true
+		}
+	}
+
+	cmds := []tea.Cmd{v.search.Focus()}
+	if load {
+		projectID := ""
+		if field == domain.FilterAssignee || field == domain.FilterVersion {
+			projectID = v.projectID()
+		}
+		if options, ok := v.options[optionsKey(field, projectID)]; ok {
+			v.choices = append(v.choices, optionChoices(options)...)
+		} else {
+			v.pickerLoading = true
+			cmds = append(cmds, v.spinner.Tick, v.loadOptions(v.requestContext(), field))
+		}
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// requestContext returns the context of the requests of the view, which is cancelled when the view is left.
+func (v *FilterBuilderView) requestContext() context.Context {
+	if v.ctx == nil {
+		v.ctx, v.cancel = context.WithCancel(context.Background())
+	}
+	return v.ctx
+}
+
+// closePicker returns to the rows.
+func (v *FilterBuilderView) closePicker() {
+	v.mode = filterModeRows
+	v.pickerLoading = false
+	v.search.Blur()
+}
+
+// visibleChoices returns the choices matching the text typed into the picker.
+func (v *FilterBuilderView) visibleChoices() []filterChoice {
+	text := strings.ToLower(strings.TrimSpace(v.search.Value()))
+	if text == "" {
+		return v.choices
+	}
+
+	var visible []filterChoice
+	for _, choice := range v.choices {
+		if strings.Contains(strings.ToLower(choice.label), text) {
+			visible = append(visible, choice)
+		}
+	}
+	return visible
+}
+
+// updatePicker handles the keys while the picker is open.
+func (v *FilterBuilderView) updatePicker(msg tea.KeyMsg) tea.Cmd {
+	visible := v.visibleChoices()
+
+	switch msg.String() {
+	case "esc":
+		v.closePicker()
+		return nil
+	case "up", "ctrl+p":
+		v.pickerCursor = max(v.pickerCursor-1, 0)
+		return nil
+	case "down", "ctrl+n":
+		v.pickerCursor = min(v.pickerCursor+1, max(len(visible)-1, 0))
+		return nil
+	case " ":
+		// Space marks values for filters matching several of them, e.g. two trackers
+		if v.pickerCursor < len(visible) {
+			choice := visible[v.pickerCursor]
+			if choice.operator == redmine.OpEquals && choice.value != "" && v.pickerField != domain.FilterCustomFields {
+				v.marked[choice.value] = !v.marked[choice.value]
+				return nil
+			}
+		}
+	case "enter":
+		return v.applyPicker(visible)
+	}
+
+	var cmd tea.Cmd
+	v.search, cmd = v.search.Update(msg)
+	v.pickerCursor = min(v.pickerCursor, max(len(v.visibleChoices())-1, 0))
+	return cmd
+}
+
+// applyPicker applies the marked values, or the choice under the cursor if none are marked.
+func (v *FilterBuilderView) applyPicker(visible []filterChoice) tea.Cmd {
+	var values []string
+	for _, choice := range v.choices {
+		if choice.operator == redmine.OpEquals && v.marked[choice.value] {
+			values = append(values, choice.value)
+		}
+	}
+	if len(values) > 0 {
+		v.closePicker()
+		v.query.SetFilter(redmine.QueryFilter{Field: v.pickerField, Operator: redmine.OpEquals, Values: values})
+		return nil
+	}
+
+	if v.pickerCursor >= len(visible) {
+		return nil
+	}
+	choice := visible[v.pickerCursor]
+	v.closePicker()
+
+	if v.pickerField == domain.FilterCustomFields {
+		// The custom field is picked, its value comes next
+		v.customField = choice.value
+		return v.editRow()
+	}
+	if choice.prompt != "" {
+		return v.openInput(choice)
+	}
+
+	filter := redmine.QueryFilter{Field: v.pickerField, Operator: choice.operator}
+	if choice.value != "" {
+		filter.Values = []string{choice.value}
+	}
+	v.query.SetFilter(filter)
+
+	// Assignees and versions belong to the project, they are dropped if another project is picked
+	if v.pickerField == domain.FilterProject {
+		v.dropProjectValues()
+	}
+	return nil
+}
+
+// dropProjectValues removes assignees and versions picked by ID, as they may not exist in the new project.
+func (v *FilterBuilderView) dropProjectValues() {
+	for _, field := range []string{domain.FilterAssignee, domain.FilterVersion} {
+		filter, ok := v.query.Filter(field)
+		if !ok || (filter.Operator != redmine.OpEquals && filter.Operator != redmine.OpNotEquals) {
+			continue
+		}
+		if slices.ContainsFunc(filter.Values, func(value string) bool { return value != "me" }) {
+			v.query.RemoveFilter(field)
+		}
+	}
+}
+
+// openInput asks for the value of choice.
+func (v *FilterBuilderView) openInput(choice filterChoice) tea.Cmd {
+	v.mode = filterModeInput
+	v.inputChoice = choice
+	v.input.Reset()
+	v.input.Placeholder = choice.prompt
+	return v.input.Focus()
+}
+
+// updateInput handles the keys while an input is open.
+func (v *FilterBuilderView) updateInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		v.err = nil
+		v.mode = filterModeRows
+		v.input.Blur()
+		return nil
+	case "enter":
+		value := strings.TrimSpace(v.input.Value())
+		var cmd tea.Cmd
+		var err error
+		if v.mode == filterModeName {
+			cmd, err = v.saveFavorite(value)
+		} else {
+			cmd, err = v.applyInput(value)
+		}
+		if err != nil {
+			v.err = err
+			return nil
+		}
+		v.err = nil
+		if v.mode == filterModeInput || v.mode == filterModeName {
+			v.mode = filterModeRows
+		}
+		v.input.Blur()
+		return cmd
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return cmd
+}
+
+// applyInput validates the value typed for the choice of the input and sets the filter.
+func (v *FilterBuilderView) applyInput(value string) (tea.Cmd, error) {
+	choice := v.inputChoice
+	row := v.selectedRow()
+
+	if row.kind == filterRowCustomField && v.customField == "" {
+		id, err := strconv.Atoi(strings.TrimPrefix(value, "cf_"))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid custom field ID %q", value)
+		}
+		v.customField = fmt.Sprintf("cf_%d", id)
+		v.mode = filterModeRows
+		return v.editRow(), nil
+	}
+
+	var values []string
+	switch choice.operator {
+	case redmine.OpLastNumDays:
+		if days, err := strconv.Atoi(value); err != nil || days <= 0 {
+			return nil, fmt.Errorf("invalid number of days %q", value)
+		}
+		values = []string{value}
+	case redmine.OpBetween:
+		from, to, ok := strings.Cut(value, "..")
+		if !ok || !datePattern.MatchString(from) || !datePattern.MatchString(to) {
+			return nil, fmt.Errorf("invalid range %q, expected YYYY-MM-DD..YYYY-MM-DD", value)
+		}
+		values = []string{from, to}
+	default:
+		if value == "" {
+			return nil, errors.New("the value must not be empty")
+		}
+		values = []string{value}
+	}
+
+	v.query.SetFilter(redmine.QueryFilter{Field: v.rowField(row), Operator: choice.operator, Values: values})
+	return nil, nil
+}
+
+// saveFavorite appends the query as favorite with the given name to the config file.
+func (v *FilterBuilderView) saveFavorite(name string) (tea.Cmd, error) {
+	if name == "" {
+		return nil, errors.New("the name must not be empty")
+	}
+
+	favorites := append(slices.Clone(v.config.ActiveFavorites()), config.FavoriteConfig{Name: name, Query: v.query.String()})
+	if err := v.config.SaveFavorites(favorites); err != nil {
+		return nil, err
+	}
+
+	v.status = fmt.Sprintf("Saved favorite %q", name)
+	return func() tea.Msg {
+		return messages.FavoritesChangedMsg{}
+	}, nil
+}
+
+// run returns the command searching the issues matching the query.
+func (v *FilterBuilderView) run() tea.Cmd {
+	query := v.query.String()
+	if query == "" {
+		v.err = errors.New("add a filter before running the query")
+		return nil
+	}
+
+	v.err = nil
+	return func() tea.Msg {
+		return messages.SearchSubmittedMsg{Query: query}
+	}
+}
+
+// SetSize sets the dimensions of the FilterBuilderView.
+func (v *FilterBuilderView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.search.Width = width - 12
+	v.input.Width = width - 12
+}
+
+// Render renders the rows with the picker or input of the selected row below.
+func (v *FilterBuilderView) Render() string {
+	sections := []string{titleStyle.Render("FILTER ISSUES"), v.renderRows()}
+
+	if other := v.renderOther(); other != "" {
+		sections = append(sections, "", other)
+	}
+
+	switch v.mode {
+	case filterModePicker:
+		sections = append(sections, "", v.renderPicker())
+	case filterModeInput, filterModeName:
+		label := v.inputChoice.label
+		if v.mode == filterModeName {
+			label = "Favorite"
+		}
+		sections = append(sections, "", fieldLabelStyle.Render(label)+" "+v.input.View())
+	}
+
+	if v.err != nil {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Bold(true).
+			Padding(0, 1).
+			Render("⚠ "+v.err.Error()))
+	}
+	if v.status != "" {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Success).
+			Padding(0, 1).
+			Render(v.status))
+	}
+
+	var help string
+	switch v.mode {
+	case filterModePicker:
+		help = "type: filter • ↑/↓: select • space: mark several • enter: apply • esc: cancel"
+	case filterModeInput, filterModeName:
+		help = "enter: apply • esc: cancel"
+	default:
+		help = "↑/↓: select • enter: edit • x: clear • n: negate • c: change custom field • r: run • s: save as favorite • esc: back"
+	}
+	sections = append(sections, "", helpStyle.Render(help))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderRows renders one line per row with the summary of its filter.
+func (v *FilterBuilderView) renderRows() string {
+	var lines []string
+	for i, row := range v.rows {
+		prefix := "  "
+		style := fieldValueStyle
+		if i == v.cursor && v.mode == filterModeRows {
+			prefix = "> "
+			style = focusedStyle
+		}
+
+		label := row.label
+		if row.kind == filterRowCustomField && v.customField != "" {
+			label = v.fieldLabel(v.customField)
+		}
+
+		lines = append(lines, prefix+fieldLabelStyle.Width(18).Render(truncate(label, 16))+style.Render(v.summary(row)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fieldLabel returns the name of a custom field if it has been loaded, its query field otherwise.
+func (v *FilterBuilderView) fieldLabel(field string) string {
+	if label, ok := v.labels[domain.FilterCustomFields][field]; ok {
+		return label
+	}
+	return field
+}
+
+// summary describes the filter of row in words.
+func (v *FilterBuilderView) summary(row filterRow) string {
+	field := v.rowField(row)
+	filter, ok := v.query.Filter(field)
+	if field == "" || !ok {
+		return "any"
+	}
+
+	values := make([]string, 0, len(filter.Values))
+	for _, value := range filter.Values {
+		if label, ok := v.labels[field][value]; ok {
+			value = label
+		}
+		values = append(values, value)
+	}
+
+	switch filter.Operator {
+	case redmine.OpEquals:
+		return strings.Join(values, ", ")
+	case redmine.OpNotEquals:
+		return "not " + strings.Join(values, ", ")
+	case redmine.OpContains:
+		return fmt.Sprintf("contains %q", strings.Join(values, " "))
+	case redmine.OpLastNumDays:
+		return fmt.Sprintf("in the last %s days", strings.Join(values, ""))
+	case redmine.OpBetween:
+		if len(values) == 2 {
+			return fmt.Sprintf("between %s and %s", values[0], values[1])
+		}
+	}
+
+	for _, choice := range slices.Concat(row.choices, dateChoices, customValueChoices) {
+		if choice.operator == filter.Operator && choice.prompt == "" && choice.value == "" {
+			return choice.label
+		}
+	}
+	return strings.TrimSpace(filter.Operator.Label() + " " + strings.Join(values, ", "))
+}
+
+// renderOther lists the filters and parameters of the query that have no row, they are kept as they are.
+func (v *FilterBuilderView) renderOther() string {
+	var other []string
+	for _, filter := range v.query.Filters {
+		covered := filter.Field == v.customField
+		for _, row := range v.rows {
+			covered = covered || row.field == filter.Field
+		}
+		if !covered {
+			other = append(other, strings.TrimSpace(fmt.Sprintf("%s %s %s", filter.Field, filter.Operator.Label(), strings.Join(filter.Values, ", "))))
+		}
+	}
+	if len(v.query.Sort) > 0 {
+		keys := make([]string, 0, len(v.query.Sort))
+		for _, key := range v.query.Sort {
+			if key.Descending {
+				keys = append(keys, key.Field+" ↓")
+			} else {
+				keys = append(keys, key.Field+" ↑")
+			}
+		}
+		other = append(other, "sorted by "+strings.Join(keys, ", "))
+	}
+	if v.query.GroupBy != "" {
+		other = append(other, "grouped by "+v.query.GroupBy)
+	}
+	if len(v.query.Columns) > 0 {
+		other = append(other, "columns "+strings.Join(v.query.Columns, ", "))
+	}
+	for _, key := range slices.Sorted(func(yield func(string) bool) {
+		for key := range v.query.Params {
+			if !yield(key) {
+				return
+			}
+		}
+	}) {
+		other = append(other, key+"="+strings.Join(v.query.Params[key], ","))
+	}
+
+	if len(other) == 0 {
+		return ""
+	}
+	return helpStyle.Render("Also keeps: " + strings.Join(other, " • "))
+}
+
+// renderPicker renders the entries of the picker matching the typed text.
+func (v *FilterBuilderView) renderPicker() string {
+	lines := []string{fieldLabelStyle.Render("Choose") + " " + v.search.View()}
+
+	if v.pickerLoading {
+		lines = append(lines, loadingStyle.Render(v.spinner.View()+" Loading values from Redmine..."))
+	}
+
+	visible := v.visibleChoices()
+	height := max(v.height-len(v.rows)-12, 5)
+	start := max(0, min(v.pickerCursor-height/2, len(visible)-height))
+	for i := start; i < len(visible) && i < start+height; i++ {
+		choice := visible[i]
+
+		mark := "  "
+		if choice.operator == redmine.OpEquals && v.marked[choice.value] {
+			mark = "✓ "
+		}
+
+		prefix := "  "
+		style := fieldValueStyle
+		if i == v.pickerCursor {
+			prefix = "> "
+			style = focusedStyle
+		}
+		lines = append(lines, prefix+mark+style.Render(truncate(choice.label, max(v.width-12, 20))))
+	}
+
+	if len(visible) == 0 && !v.pickerLoading {
+		lines = append(lines, helpStyle.Render("No matching values"))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,188 @@
+package views
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeFilterOptions implements domain.FilterOptionLister for testing
+type fakeFilterOptions struct {
+	requests []string
+}
+
+// ListFilterOptions returns two options for every field and records the request
+func (f *fakeFilterOptions) ListFilterOptions(ctx context.Context, field, projectID string) ([]domain.FilterOption, error) {
+	f.requests = append(f.requests, field+"@"+projectID)
+	switch field {
+	case domain.FilterTracker:
+		return []domain.FilterOption{{Value: "1", Label: "Bug"}, {Value: "2", Label: "Feature"}}, nil
+	case domain.FilterVersion:
+		if projectID == "" {
+			return nil, domain.ErrProjectRequired
+		}
+	}
+	return []domain.FilterOption{{Value: "7", Label: "Website"}, {Value: "8", Label: "Shop"}}, nil
+}
+
+// newTestFilterBuilderView creates a filter builder whose inputs do not blink, so commands return immediately
+func newTestFilterBuilderView(raw string, source *fakeFilterOptions, cfg *config.Config) *FilterBuilderView {
+	view := NewFilterBuilderView(100, 40, raw, source, cfg)
+	view.search.Cursor.SetMode(cursor.CursorStatic)
+	view.input.Cursor.SetMode(cursor.CursorStatic)
+	return view
+}
+
+// runFilterCmd executes cmd and feeds the loaded options back into the view
+func runFilterCmd(v *FilterBuilderView, cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runFilterCmd(v, c)
+		}
+	case FilterOptionsLoadedMsg:
+		v.Update(msg)
+	default:
+		return msg
+	}
+	return nil
+}
+
+// pressKeys sends the keys to the view, running the returned commands
+func pressKeys(v *FilterBuilderView, keys ...tea.KeyMsg) tea.Msg {
+	var last tea.Msg
+	for _, key := range keys {
+		last = runFilterCmd(v, v.Update(key))
+	}
+	return last
+}
+
+var (
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keySpace = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+// keyRunes returns the key press typing text
+func keyRunes(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+// TestFilterBuilderView_BuildQuery verifies that picked values and date ranges result in a valid query
+func TestFilterBuilderView_BuildQuery(t *testing.T) {
+	source := &fakeFilterOptions{}
+	view := newTestFilterBuilderView("f[]=status_id&op[status_id]=o&sort=id:desc", source, &config.Config{})
+	runFilterCmd(view, view.Init())
+
+	// Tracker: mark both trackers
+	pressKeys(view, keyDown, keyEnter)
+	if !view.IsEditing() || len(view.choices) != 2 {
+		t.Fatalf("expected the picker with 2 trackers, got %+v", view.choices)
+	}
+	pressKeys(view, keySpace, keyDown, keySpace, keyEnter)
+
+	// Updated: in the last 7 days
+	for range 7 {
+		pressKeys(view, keyDown)
+	}
+	pressKeys(view, keyEnter, keyRunes("last days"), keyEnter, keyRunes("7"), keyEnter)
+	if view.IsEditing() {
+		t.Fatalf("expected the input to be closed, error: %v", view.err)
+	}
+
+	msg, ok := pressKeys(view, keyRunes("r")).(messages.SearchSubmittedMsg)
+	if !ok {
+		t.Fatal("expected SearchSubmittedMsg after pressing r")
+	}
+
+	query, err := redmine.ParseIssueQuery(msg.Query)
+	if err != nil {
+		t.Fatalf("ParseIssueQuery(%q) returned error: %v", msg.Query, err)
+	}
+	want := []redmine.QueryFilter{
+		{Field: "status_id", Operator: redmine.OpOpen},
+		{Field: "tracker_id", Operator: redmine.OpEquals, Values: []string{"1", "2"}},
+		{Field: "updated_on", Operator: redmine.OpLastNumDays, Values: []string{"7"}},
+	}
+	if !reflect.DeepEqual(query.Filters, want) {
+		t.Errorf("Filters = %+v, want %+v", query.Filters, want)
+	}
+	if want := []redmine.SortKey{{Field: "id", Descending: true}}; !reflect.DeepEqual(query.Sort, want) {
+		t.Errorf("expected the sort order to be kept, got %+v", query.Sort)
+	}
+	if got := view.summary(view.rows[1]); got != "Bug, Feature" {
+		t.Errorf("tracker summary = %q, want the labels of the trackers", got)
+	}
+}
+
+// TestFilterBuilderView_VersionNeedsProject verifies that versions are loaded for the picked project only
+func TestFilterBuilderView_VersionNeedsProject(t *testing.T) {
+	source := &fakeFilterOptions{}
+	view := newTestFilterBuilderView("", source, &config.Config{})
+
+	// Version without a project
+	for range 4 {
+		pressKeys(view, keyDown)
+	}
+	pressKeys(view, keyEnter)
+	if view.err == nil {
+		t.Error("expected an error asking for a project")
+	}
+	pressKeys(view, keyEsc)
+
+	// Project: Website
+	view.cursor = 0
+	pressKeys(view, keyEnter, keyEnter)
+	if got := view.projectID(); got != "7" {
+		t.Fatalf("projectID() = %q, want 7", got)
+	}
+
+	view.cursor = 4
+	pressKeys(view, keyEnter)
+	if len(view.choices) != 3 {
+		t.Errorf("expected 'no version' and 2 versions, got %+v", view.choices)
+	}
+	if last := source.requests[len(source.requests)-1]; last != domain.FilterVersion+"@7" {
+		t.Errorf("last request = %q, want versions of project 7", last)
+	}
+}
+
+// TestFilterBuilderView_SaveFavorite verifies that the query is appended to the favorites in the config file
+func TestFilterBuilderView_SaveFavorite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "redmine:\n  url: https://example.com\n  token: secret\nfavorites:\n  - name: Mine\n    query: assigned_to_id=me\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	view := newTestFilterBuilderView("f[]=status_id&op[status_id]=c", &fakeFilterOptions{}, cfg)
+	msg := pressKeys(view, keyRunes("s"), keyRunes("Closed"), keyEnter)
+	if _, ok := msg.(messages.FavoritesChangedMsg); !ok {
+		t.Fatalf("expected FavoritesChangedMsg, got %#v (error: %v)", msg, view.err)
+	}
+
+	reloaded, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if len(reloaded.Favorites) != 2 || reloaded.Favorites[1].Name != "Closed" || reloaded.Favorites[1].Query != "f[]=status_id&op[status_id]=c" {
+		t.Errorf("saved favorites = %+v, want the query appended", reloaded.Favorites)
+	}
+}
@@ -2,7 +2,10 @@ package views
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/b1tray3r/rmt/internal/tui/themes"
 	tea "github.com/charmbracelet/bubbletea"
@@ -72,6 +75,37 @@ func (hs *HoursSelector) Blur() {
 	hs.focused = false
 }
 
+// SetHours selects the given hours, adding them as an option if they are not offered yet
+func (hs *HoursSelector) SetHours(hours float64) {
+	for i, option := range hs.options {
+		if option == hours {
+			hs.selectedIndex = i
+			return
+		}
+	}
+
+	index := len(hs.options)
+	for i, option := range hs.options {
+		if option > hours {
+			index = i
+			break
+		}
+	}
+
+	hs.options = slices.Insert(hs.options, index, hours)
+	hs.selectedIndex = index
+}
+
+// QuarterHours converts a duration into hours rounded to the nearest quarter hour offered by the selector
+// QuarterHours never returns less than the smallest option, so even a short timer yields a loggable value
+func QuarterHours(d time.Duration) float64 {
+	hours := math.Round(d.Hours()*4) / 4
+	if hours < 0.25 {
+		return 0.25
+	}
+	return hours
+}
+
 // SelectedHours returns the currently selected hours
 func (hs *HoursSelector) SelectedHours() float64 {
 	return hs.options[hs.selectedIndex]
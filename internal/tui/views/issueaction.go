@@ -0,0 +1,428 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IssueActionSource provides the data shown and changed by the actions of the IssueView.
+type IssueActionSource interface {
+	domain.IssueGetter
+	domain.IssueUpdater
+	domain.IssueStatusLister
+	domain.FilterOptionLister
+}
+
+// IssueActionOptionsLoadedMsg is sent when the values offered by an issue action have been loaded.
+type IssueActionOptionsLoadedMsg struct {
+	IssueID int
+	Options []domain.FilterOption
+	Error   error
+}
+
+// IssueUpdatedMsg is sent when a change of an issue has been saved.
+// Issue is the reloaded issue, nil if reloading it failed after saving.
+type IssueUpdatedMsg struct {
+	Issue *domain.Issue
+	Error error
+}
+
+// issueActionKind is the part of an issue an action changes.
+type issueActionKind int
+
+const (
+	issueActionStatus issueActionKind = iota
+	issueActionAssign
+	issueActionDoneRatio
+	issueActionFollowUp
+	issueActionNote
+)
+
+// issueActionStep is the step of an action from picking the new value to saving it.
+type issueActionStep int
+
+const (
+	issueStepPick issueActionStep = iota
+	issueStepDate
+	issueStepConfirm
+	issueStepSubmitting
+)
+
+// issueAction is a change of an issue in progress.
+type issueAction struct {
+	kind    issueActionKind
+	step    issueActionStep
+	options []domain.FilterOption
+	cursor  int
+	loading bool
+	value   domain.FilterOption // value is the picked value, its label is shown in the confirmation
+	err     error
+}
+
+// doneRatioOptions returns the progress values Redmine offers, in steps of 10 percent.
+func doneRatioOptions() []domain.FilterOption {
+	var options []domain.FilterOption
+	for ratio := 0; ratio <= 100; ratio += 10 {
+		options = append(options, domain.FilterOption{Value: strconv.Itoa(ratio), Label: fmt.Sprintf("%d%%", ratio)})
+	}
+	return options
+}
+
+// startAction opens the form of the action for the shown issue.
+func (v *IssueView) startAction(kind issueActionKind) tea.Cmd {
+	if v.source == nil {
+		return nil
+	}
+
+	v.flash = ""
+	v.action = &issueAction{kind: kind}
+	switch kind {
+	case issueActionStatus, issueActionAssign:
+		v.action.loading = true
+		return tea.Batch(v.spinner.Tick, v.loadActionOptions(kind))
+	case issueActionDoneRatio:
+		v.action.options = doneRatioOptions()
+		v.action.cursor = min(v.Issue.DoneRatio()/10, len(v.action.options)-1)
+	case issueActionFollowUp:
+		v.action.step = issueStepDate
+		v.datePicker.SetDate(time.Now())
+		if date, err := time.ParseInLocation(time.DateOnly, v.Issue.CustomFieldValue(v.followUpFieldID), time.Local); err == nil {
+			v.datePicker.SetDate(date)
+		}
+		v.datePicker.Focus()
+	case issueActionNote:
+		return v.confirmAction(domain.FilterOption{})
+	}
+	return nil
+}
+
+// loadActionOptions returns a command loading the statuses or assignees offered for the issue.
+func (v *IssueView) loadActionOptions(kind issueActionKind) tea.Cmd {
+	ctx := v.requestContext()
+	source := v.source
+	issueID := v.Issue.ID()
+
+	projectID := ""
+	if project := v.Issue.Project(); project != nil {
+		projectID = strconv.Itoa(project.ID())
+	}
+
+	return func() tea.Msg {
+		var options []domain.FilterOption
+		var err error
+		if kind == issueActionStatus {
+			options, err = source.ListAllowedStatuses(ctx, issueID)
+		} else {
+			options, err = source.ListFilterOptions(ctx, domain.FilterAssignee, projectID)
+		}
+		return IssueActionOptionsLoadedMsg{IssueID: issueID, Options: options, Error: err}
+	}
+}
+
+// requestContext returns the context of the requests of the view, which is cancelled when the view is left.
+func (v *IssueView) requestContext() context.Context {
+	if v.ctx == nil {
+		v.ctx, v.cancel = context.WithCancel(context.Background())
+	}
+	return v.ctx
+}
+
+// Cancel aborts loading the history or values, or saving a change.
+func (v *IssueView) Cancel() {
+	v.cancelAction()
+	v.cancelHistory()
+}
+
+// cancelAction aborts loading values or saving a change.
+func (v *IssueView) cancelAction() {
+	if v.cancel != nil {
+		v.cancel()
+		v.ctx, v.cancel = nil, nil
+	}
+}
+
+// IsEditing reports whether an action form is open, which closes on ESC instead of leaving the view.
+func (v *IssueView) IsEditing() bool {
+	return v.action != nil
+}
+
+// closeAction closes the action form, aborting its request if one is in flight.
+func (v *IssueView) closeAction() {
+	v.cancelAction()
+	v.action = nil
+	v.note.Blur()
+	v.datePicker.Blur()
+}
+
+// optionsLoaded shows the loaded values in the picker of the action.
+func (v *IssueView) optionsLoaded(msg IssueActionOptionsLoadedMsg) {
+	if v.action == nil || !v.action.loading || msg.IssueID != v.Issue.ID() {
+		return
+	}
+
+	v.action.loading = false
+	switch {
+	case errors.Is(msg.Error, context.Canceled):
+		v.closeAction()
+	case msg.Error != nil:
+		v.action.err = msg.Error
+	case len(msg.Options) == 0:
+		v.action.err = errors.New("there is nothing to choose from")
+	default:
+		v.action.options = msg.Options
+	}
+}
+
+// issueUpdated closes the action form once the change has been saved, or shows why it failed.
+func (v *IssueView) issueUpdated(msg IssueUpdatedMsg) {
+	if v.action == nil || v.action.step != issueStepSubmitting {
+		return
+	}
+
+	if msg.Error != nil {
+		if errors.Is(msg.Error, context.Canceled) {
+			v.closeAction()
+			return
+		}
+		v.action.step = issueStepConfirm
+		v.action.err = msg.Error
+		return
+	}
+
+	v.flash = v.actionTitle() + " saved"
+	if msg.Issue != nil {
+		// The reloaded issue comes with its history, including the change just saved
+		v.cancelHistory()
+		v.Issue = msg.Issue
+		v.history = issueHistory{loaded: true}
+		v.setContent()
+	} else {
+		v.flash += ", reopen the issue to see the change"
+	}
+	v.closeAction()
+}
+
+// updateAction handles the keys while an action form is open.
+func (v *IssueView) updateAction(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "esc" {
+		v.closeAction()
+		return nil
+	}
+
+	switch v.action.step {
+	case issueStepPick:
+		if v.action.loading {
+			return nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			v.action.cursor = max(v.action.cursor-1, 0)
+		case "down", "j":
+			v.action.cursor = min(v.action.cursor+1, max(len(v.action.options)-1, 0))
+		case "enter":
+			if v.action.cursor < len(v.action.options) {
+				return v.confirmAction(v.action.options[v.action.cursor])
+			}
+		}
+
+	case issueStepDate:
+		switch msg.String() {
+		case "enter":
+			date := v.datePicker.SelectedDate().Format(time.DateOnly)
+			return v.confirmAction(domain.FilterOption{Value: date, Label: date})
+		case "x", "delete":
+			return v.confirmAction(domain.FilterOption{Label: "none"})
+		default:
+			v.datePicker.Update(msg)
+		}
+
+	case issueStepConfirm:
+		if msg.String() == "enter" {
+			return v.submitAction()
+		}
+		var cmd tea.Cmd
+		v.note, cmd = v.note.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+// confirmAction shows the picked value for confirmation, along with an input for a note.
+func (v *IssueView) confirmAction(value domain.FilterOption) tea.Cmd {
+	v.action.value = value
+	v.action.step = issueStepConfirm
+	v.action.err = nil
+	v.datePicker.Blur()
+	v.note.Reset()
+	if v.action.kind == issueActionNote {
+		v.note.Placeholder = "Note"
+	} else {
+		v.note.Placeholder = "Note (optional)"
+	}
+	return v.note.Focus()
+}
+
+// actionParams returns the changes of the confirmed action.
+func (v *IssueView) actionParams() (models.UpdateIssueParams, error) {
+	params := models.UpdateIssueParams{Notes: strings.TrimSpace(v.note.Value())}
+
+	var err error
+	switch v.action.kind {
+	case issueActionStatus:
+		params.StatusID, err = strconv.Atoi(v.action.value.Value)
+	case issueActionAssign:
+		params.AssignedToID, err = strconv.Atoi(v.action.value.Value)
+	case issueActionDoneRatio:
+		var ratio int
+		ratio, err = strconv.Atoi(v.action.value.Value)
+		params.DoneRatio = &ratio
+	case issueActionFollowUp:
+		params.CustomFields = []models.CustomFieldValue{{ID: v.followUpFieldID, Value: v.action.value.Value}}
+	case issueActionNote:
+		if params.Notes == "" {
+			err = errors.New("the note is empty")
+		}
+	}
+	return params, err
+}
+
+// submitAction returns a command saving the confirmed action and reloading the issue.
+func (v *IssueView) submitAction() tea.Cmd {
+	params, err := v.actionParams()
+	if err != nil {
+		v.action.err = err
+		return nil
+	}
+
+	v.action.step = issueStepSubmitting
+	v.action.err = nil
+	v.note.Blur()
+
+	ctx := v.requestContext()
+	source := v.source
+	id := v.Issue.ID()
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		if err := source.UpdateIssue(ctx, id, params); err != nil {
+			return IssueUpdatedMsg{Error: err}
+		}
+
+		// The change is saved even if the issue cannot be reloaded, so this is not an error
+		issue, err := source.GetIssue(ctx, id)
+		if err != nil {
+			return IssueUpdatedMsg{}
+		}
+		return IssueUpdatedMsg{Issue: issue}
+	})
+}
+
+// updateSpinner advances the spinner while values are loaded or a change is saved.
+func (v *IssueView) updateSpinner(msg spinner.TickMsg) tea.Cmd {
+	if v.action == nil || (!v.action.loading && v.action.step != issueStepSubmitting) {
+		return nil
+	}
+	var cmd tea.Cmd
+	v.spinner, cmd = v.spinner.Update(msg)
+	return cmd
+}
+
+// actionTitle names the change of the open action.
+func (v *IssueView) actionTitle() string {
+	switch v.action.kind {
+	case issueActionStatus:
+		return "Status"
+	case issueActionAssign:
+		return "Assignee"
+	case issueActionDoneRatio:
+		return "Done"
+	case issueActionFollowUp:
+		return "Follow-up"
+	}
+	return "Note"
+}
+
+// actionCurrent returns the current value of what the open action changes.
+func (v *IssueView) actionCurrent() string {
+	switch v.action.kind {
+	case issueActionStatus:
+		return v.Issue.Status()
+	case issueActionAssign:
+		return orNone(v.Issue.AssignedTo(), "nobody")
+	case issueActionDoneRatio:
+		return fmt.Sprintf("%d%%", v.Issue.DoneRatio())
+	case issueActionFollowUp:
+		return orNone(v.Issue.CustomFieldValue(v.followUpFieldID), "none")
+	}
+	return ""
+}
+
+// orNone returns value, or fallback if value is empty.
+func orNone(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// renderAction renders the open action form in place of the description.
+func (v *IssueView) renderAction() string {
+	lines := []string{titleStyle.Render(strings.ToUpper(v.actionTitle()) + fmt.Sprintf(" OF #%d", v.Issue.ID()))}
+
+	var help string
+	switch v.action.step {
+	case issueStepPick:
+		lines = append(lines, fieldLabelStyle.Render("Current:")+" "+fieldValueStyle.Render(v.actionCurrent()))
+		if v.action.loading {
+			lines = append(lines, loadingStyle.Render(v.spinner.View()+" Loading values from Redmine..."))
+		}
+		height := max(v.height-16, 5)
+		start := max(0, min(v.action.cursor-height/2, len(v.action.options)-height))
+		for i := start; i < len(v.action.options) && i < start+height; i++ {
+			prefix := "  "
+			style := fieldValueStyle
+			if i == v.action.cursor {
+				prefix = "> "
+				style = focusedStyle
+			}
+			lines = append(lines, prefix+style.Render(truncate(v.action.options[i].Label, max(v.width-12, 20))))
+		}
+		help = "↑/↓: select • enter: choose • esc: cancel"
+
+	case issueStepDate:
+		lines = append(lines, fieldLabelStyle.Render("Current:")+" "+fieldValueStyle.Render(v.actionCurrent()), v.datePicker.Render())
+		help = "←/→/↑/↓: day • shift+←/→: month • enter: choose • x: clear • esc: cancel"
+
+	case issueStepConfirm, issueStepSubmitting:
+		if v.action.kind != issueActionNote {
+			change := fmt.Sprintf("%s → %s", v.actionCurrent(), v.action.value.Label)
+			lines = append(lines, fieldLabelStyle.Render(v.actionTitle()+":")+" "+focusedStyle.Render(change))
+		}
+		lines = append(lines, fieldLabelStyle.Render("Note:")+" "+v.note.View())
+		if v.action.step == issueStepSubmitting {
+			lines = append(lines, loadingStyle.Render(v.spinner.View()+" Saving..."))
+		}
+		help = "enter: save • esc: cancel"
+	}
+
+	if v.action.err != nil {
+		lines = append(lines, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Bold(true).
+			Padding(0, 1).
+			Render("⚠ "+submissionErrorMessage(v.action.err)))
+	}
+
+	lines = append(lines, "", helpStyle.Render(help))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,168 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IssueLoadedMsg is sent when the issue shown by the IssueView has been loaded with its history.
+type IssueLoadedMsg struct {
+	IssueID int
+	Issue   *domain.Issue
+	Error   error
+}
+
+// issueHistory is the loading state of the history of the shown issue.
+// Issues from search results come without history, so the view loads the full issue when it is shown.
+type issueHistory struct {
+	loaded  bool
+	loading bool
+	err     error
+	cancel  context.CancelFunc
+}
+
+// loadHistory returns a command loading the issue with its history, nil if it is loaded or being loaded.
+func (v *IssueView) loadHistory() tea.Cmd {
+	if v.source == nil || v.history.loaded || v.history.loading {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.history.loading = true
+	v.history.err = nil
+	v.history.cancel = cancel
+	v.setContent()
+
+	source := v.source
+	id := v.Issue.ID()
+	return func() tea.Msg {
+		issue, err := source.GetIssue(ctx, id)
+		return IssueLoadedMsg{IssueID: id, Issue: issue, Error: err}
+	}
+}
+
+// cancelHistory aborts loading the history, which is loaded again when the view is resumed.
+func (v *IssueView) cancelHistory() {
+	if v.history.cancel != nil {
+		v.history.cancel()
+		v.history.cancel = nil
+	}
+	v.history.loading = false
+}
+
+// Resume loads the history again if leaving the view aborted loading it.
+func (v *IssueView) Resume() tea.Cmd {
+	return v.loadHistory()
+}
+
+// historyLoaded shows the loaded issue with its history.
+func (v *IssueView) historyLoaded(msg IssueLoadedMsg) {
+	if msg.IssueID != v.Issue.ID() || !v.history.loading || v.history.loaded {
+		return
+	}
+
+	v.history.loading = false
+	v.history.cancel = nil
+	switch {
+	case errors.Is(msg.Error, context.Canceled):
+		return
+	case msg.Error != nil:
+		v.history.err = msg.Error
+	default:
+		v.Issue = msg.Issue
+		v.history.loaded = true
+	}
+	v.setContent()
+}
+
+// setContent fills the viewport with the description, the links and the history of the issue.
+// The content is padded to the height of the viewport, so the help stays at the bottom of the screen.
+func (v *IssueView) setContent() {
+	content := v.renderer.Render(v.Issue.FullDescription(), v.viewport.Width)
+	if v.source != nil {
+		content = strings.TrimRight(content, "\n") + "\n\n" + v.renderLinks() + v.renderHistory()
+	}
+
+	lineCount := strings.Count(content, "\n") + 1
+	if lineCount < v.viewport.Height {
+		content += strings.Repeat("\n", v.viewport.Height-lineCount)
+	}
+	v.viewport.SetContent(content)
+}
+
+// renderLinks renders the relations, subtasks, attachments and watchers of the issue, leaving out empty sections.
+func (v *IssueView) renderLinks() string {
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Relations", v.Issue.Relations()},
+		{"Subtasks", v.Issue.Children()},
+		{"Attachments", v.Issue.Attachments()},
+	}
+
+	var b strings.Builder
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		b.WriteString(v.renderHeading(section.title) + "\n")
+		for _, item := range section.items {
+			b.WriteString("  • " + item + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if watchers := v.Issue.Watchers(); len(watchers) > 0 {
+		b.WriteString(v.renderHeading("Watchers") + "\n  " + strings.Join(watchers, ", ") + "\n\n")
+	}
+	return b.String()
+}
+
+// renderHistory renders the journal entries of the issue as a timeline, oldest first.
+func (v *IssueView) renderHistory() string {
+	muted := lipgloss.NewStyle().Foreground(themes.TokyoNight.Secondary)
+	heading := v.renderHeading("History") + "\n"
+
+	switch {
+	case v.history.err != nil:
+		return heading + lipgloss.NewStyle().Foreground(themes.TokyoNight.Error).Render("  Failed to load the history: "+v.history.err.Error())
+	case !v.history.loaded:
+		return heading + muted.Render("  Loading history...")
+	case len(v.Issue.Journals()) == 0:
+		return heading + muted.Render("  No changes yet")
+	}
+
+	author := lipgloss.NewStyle().Foreground(themes.TokyoNight.Primary).Bold(true)
+
+	entries := make([]string, 0, len(v.Issue.Journals()))
+	for _, journal := range v.Issue.Journals() {
+		header := author.Render(orNone(journal.Author, "Anonymous")) +
+			muted.Render(" • "+journal.CreatedOn.Local().Format("2006-01-02 15:04"))
+		if journal.Private {
+			header += muted.Italic(true).Render(" • private")
+		}
+
+		lines := []string{header}
+		for _, change := range journal.Changes {
+			lines = append(lines, muted.Render("  "+change))
+		}
+		if journal.Notes != "" {
+			for _, line := range strings.Split(v.renderer.Render(journal.Notes, v.viewport.Width-2), "\n") {
+				lines = append(lines, "  "+line)
+			}
+		}
+		entries = append(entries, strings.Join(lines, "\n"))
+	}
+	return heading + strings.Join(entries, "\n\n")
+}
+
+// renderHeading renders the title of a section below the description.
+func (v *IssueView) renderHeading(title string) string {
+	return lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight).Bold(true).Render("── " + title)
+}
@@ -1,54 +1,75 @@
 package views
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/markup"
 	"github.com/b1tray3r/rmt/internal/tui/messages"
 	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// IssueView shows an issue with its history and changes its status, assignee, progress and follow-up date after confirmation.
 type IssueView struct {
 	width, height int
 	Issue         *domain.Issue
 	viewport      viewport.Model
+	renderer      *markup.Renderer
+
+	source          IssueActionSource
+	followUpFieldID int
+	ctx             context.Context
+	cancel          context.CancelFunc
+	history         issueHistory
+
+	action     *issueAction
+	note       textinput.Model
+	datePicker *DatePicker
+	spinner    spinner.Model
+	flash      string
 }
 
-func NewIssueView(width, height int, issue *domain.Issue) *IssueView {
-	// Count the number of newline characters in the issue's full description.
-	desc := issue.FullDescription()
-	lineCount := 1
-	for _, c := range desc {
-		if c == '\n' {
-			lineCount++
-		}
-	}
-
-	maxheight := height - 12
-	if lineCount <= maxheight {
-		if lineCount < maxheight {
-			for i := lineCount; i < maxheight; i++ {
-				desc += "\n"
-			}
-		}
-	}
-
-	vp := viewport.New(width-4, maxheight)
-	vp.SetContent(desc)
-
-	return &IssueView{
-		width:    width,
-		Issue:    issue,
-		viewport: vp,
+// NewIssueView creates a view showing issue, rendering its description and notes in format.
+// NewIssueView offers changing the issue if source is set, the follow-up date only if followUpFieldID is set.
+func NewIssueView(width, height int, issue *domain.Issue, source IssueActionSource, followUpFieldID int, format redmine.TextFormat) *IssueView {
+	vp := viewport.New(width-4, height-13)
+
+	note := textinput.New()
+	note.CharLimit = 1000
+	note.Width = max(width-20, 20)
+
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
+
+	view := &IssueView{
+		width:           width,
+		height:          height,
+		Issue:           issue,
+		viewport:        vp,
+		renderer:        markup.NewRenderer(format, themes.TokyoNight),
+		source:          source,
+		followUpFieldID: followUpFieldID,
+		note:            note,
+		datePicker:      NewDatePicker(),
+		spinner:         s,
 	}
+	view.setContent()
+	return view
 }
 
-// Init initializes the IssueView and returns any initial command.
+// Init initializes the IssueView and starts loading the history of the issue.
 func (v *IssueView) Init() tea.Cmd {
-	return nil
+	return v.loadHistory()
 }
 
 // Update updates the IssueView based on the incoming message.
@@ -56,12 +77,51 @@ func (v *IssueView) Update(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case IssueActionOptionsLoadedMsg:
+		v.optionsLoaded(msg)
+		return nil
+	case IssueUpdatedMsg:
+		v.issueUpdated(msg)
+		return nil
+	case IssueLoadedMsg:
+		v.historyLoaded(msg)
+		return nil
+	case spinner.TickMsg:
+		return v.updateSpinner(msg)
 	case tea.KeyMsg:
+		if v.action != nil {
+			return v.updateAction(msg)
+		}
+
 		switch msg.String() {
+		case "c":
+			return v.startAction(issueActionStatus)
+		case "a":
+			return v.startAction(issueActionAssign)
+		case "%":
+			return v.startAction(issueActionDoneRatio)
+		case "f":
+			if v.followUpFieldID != 0 {
+				return v.startAction(issueActionFollowUp)
+			}
+			return nil
+		case "n":
+			return v.startAction(issueActionNote)
 		case "t":
 			return func() tea.Msg {
 				return messages.TimeEntryCreateMsg{Issue: v.Issue}
 			}
+		case "s":
+			return func() tea.Msg {
+				return messages.TimerToggleMsg{Issue: v.Issue}
+			}
+		case "e":
+			return func() tea.Msg {
+				return messages.TimeEntriesOpenMsg{
+					Title:  fmt.Sprintf("#%d", v.Issue.ID()),
+					Filter: models.TimeEntryFilter{UserID: "me", IssueID: v.Issue.ID()},
+				}
+			}
 		case "up", "k":
 			v.viewport, cmd = v.viewport.Update(msg)
 			return cmd
@@ -117,7 +177,14 @@ func (v *IssueView) Render() string {
 		style.Italic(true).Foreground(themes.TokyoNight.Primary).Render(v.Issue.Author()),
 	)
 
-	helpText := "↑/↓/j/k: scroll • pgup/pgdown: page scroll • home/end: jump • t: log time • esc: back • ctrl+c: quit"
+	helpText := "↑/↓/j/k: scroll • pgup/pgdown: page scroll • home/end: jump • t: log time • s: start/stop timer • e: my entries • alt+w: timesheet • esc: back • ctrl+c: quit"
+	if v.source != nil {
+		actions := "c: status • a: assign • %: done • n: note"
+		if v.followUpFieldID != 0 {
+			actions = "c: status • a: assign • %: done • f: follow-up • n: note"
+		}
+		helpText = actions + " • " + helpText
+	}
 	help := lipgloss.NewStyle().
 		Foreground(themes.TokyoNight.Foreground).
 		Background(themes.TokyoNight.Background).
@@ -135,16 +202,44 @@ func (v *IssueView) Render() string {
 			Render(v.Issue.Link()),
 	)
 
+	body := v.viewport.View()
+	if v.action != nil {
+		body = v.renderAction()
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		projectInfo,
 		style.Padding(1, 0, 1, 0).Render(linkInfo),
-		style.Width(v.width).PaddingBottom(1).Render(titleInfo),
-		style.PaddingBottom(1).Render(v.viewport.View()),
+		style.Width(v.width).Render(titleInfo),
+		style.Width(v.width).PaddingBottom(1).Render(v.renderDetails()),
+		style.PaddingBottom(1).Render(body),
 		help,
 	)
 }
 
+// renderDetails renders the status, assignee, progress and follow-up date of the issue, whether it is cached, and the result of the last change.
+func (v *IssueView) renderDetails() string {
+	details := []string{
+		"Status: " + orNone(v.Issue.Status(), "unknown"),
+		"Assignee: " + orNone(v.Issue.AssignedTo(), "nobody"),
+		fmt.Sprintf("Done: %d%%", v.Issue.DoneRatio()),
+	}
+	if v.followUpFieldID != 0 {
+		details = append(details, "Follow-up: "+orNone(v.Issue.CustomFieldValue(v.followUpFieldID), "none"))
+	}
+
+	line := lipgloss.NewStyle().Foreground(themes.TokyoNight.Secondary).Render(strings.Join(details, " • "))
+	if cached := cachedLabel(v.Issue); cached != "" {
+		line += "  " + lipgloss.NewStyle().Foreground(themes.TokyoNight.Warning).Render(cached)
+	}
+	if v.flash != "" {
+		line += "  " + lipgloss.NewStyle().Foreground(themes.TokyoNight.Success).Render("✓ "+v.flash)
+	}
+	return line
+}
+
+// SetSize sets the dimensions of the IssueView.
 func (v *IssueView) SetSize(width, height int) {
 	v.width = width - 4
 	v.height = height
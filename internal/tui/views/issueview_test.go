@@ -0,0 +1,177 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// fakeIssueActions implements IssueActionSource for testing
+type fakeIssueActions struct {
+	fakeFilterOptions
+	updates []models.UpdateIssueParams
+	err     error
+}
+
+// GetIssue returns a fresh issue, as Redmine would after the update
+func (f *fakeIssueActions) GetIssue(ctx context.Context, id int) (*domain.Issue, error) {
+	return domain.NewIssue(id, "", "Jane", "Reloaded", "", domain.NewProject(7, "Website")), nil
+}
+
+// UpdateIssue records the update or fails with the configured error
+func (f *fakeIssueActions) UpdateIssue(ctx context.Context, id int, params models.UpdateIssueParams) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.updates = append(f.updates, params)
+	return nil
+}
+
+// ListAllowedStatuses returns two statuses
+func (f *fakeIssueActions) ListAllowedStatuses(ctx context.Context, issueID int) ([]domain.FilterOption, error) {
+	return []domain.FilterOption{{Value: "2", Label: "In Progress"}, {Value: "5", Label: "Closed (closed)"}}, nil
+}
+
+// newTestIssueView creates an issue view whose note input does not blink, so commands return immediately
+func newTestIssueView(source *fakeIssueActions) *IssueView {
+	issue := domain.NewIssue(42, "", "Jane", "Fix login", "Login fails", domain.NewProject(7, "Website"))
+	view := NewIssueView(100, 40, issue, source, 9, redmine.TextFormatTextile)
+	view.note.Cursor.SetMode(cursor.CursorStatic)
+	return view
+}
+
+// pressIssueKeys sends the keys to the view and feeds the results of the returned commands back
+func pressIssueKeys(v *IssueView, keys ...tea.KeyMsg) {
+	var run func(cmd tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				run(c)
+			}
+		case IssueActionOptionsLoadedMsg, IssueUpdatedMsg:
+			v.Update(msg)
+		}
+	}
+
+	for _, key := range keys {
+		run(v.Update(key))
+	}
+}
+
+// TestIssueView_ChangeStatus verifies that a status is picked, confirmed with a note and saved
+func TestIssueView_ChangeStatus(t *testing.T) {
+	source := &fakeIssueActions{}
+	view := newTestIssueView(source)
+
+	pressIssueKeys(view, keyRunes("c"), keyDown, keyEnter)
+	if !view.IsEditing() || view.action.step != issueStepConfirm || view.action.value.Value != "5" {
+		t.Fatalf("expected the confirmation of the closed status, got %+v", view.action)
+	}
+	if len(source.updates) != 0 {
+		t.Fatalf("expected nothing to be saved before confirming, got %+v", source.updates)
+	}
+
+	pressIssueKeys(view, keyRunes("Done"), keyEnter)
+	want := models.UpdateIssueParams{StatusID: 5, Notes: "Done"}
+	if len(source.updates) != 1 || source.updates[0].StatusID != want.StatusID || source.updates[0].Notes != want.Notes {
+		t.Errorf("updates = %+v, want [%+v]", source.updates, want)
+	}
+	if view.IsEditing() || view.Issue.FullTitle() != "Reloaded" {
+		t.Errorf("expected the form to close and the issue to be reloaded, got %+v", view.action)
+	}
+}
+
+// TestIssueView_DoneRatioAndFollowUp verifies the progress and follow-up date changes
+func TestIssueView_DoneRatioAndFollowUp(t *testing.T) {
+	source := &fakeIssueActions{}
+	view := newTestIssueView(source)
+
+	pressIssueKeys(view, keyRunes("%"), keyDown, keyEnter, keyEnter)
+	pressIssueKeys(view, keyRunes("f"), keyRunes("x"), keyEnter)
+
+	if len(source.updates) != 2 {
+		t.Fatalf("expected 2 updates, got %+v", source.updates)
+	}
+	if ratio := source.updates[0].DoneRatio; ratio == nil || *ratio != 10 {
+		t.Errorf("DoneRatio = %v, want 10", ratio)
+	}
+	if fields := source.updates[1].CustomFields; len(fields) != 1 || fields[0] != (models.CustomFieldValue{ID: 9}) {
+		t.Errorf("CustomFields = %+v, want the follow-up field cleared", fields)
+	}
+}
+
+// TestIssueView_NoteFailure verifies that an empty note is refused and a rejected change keeps the form open
+func TestIssueView_NoteFailure(t *testing.T) {
+	source := &fakeIssueActions{err: errors.New("validation failed")}
+	view := newTestIssueView(source)
+
+	pressIssueKeys(view, keyRunes("n"), keyEnter)
+	if view.action == nil || view.action.err == nil {
+		t.Fatalf("expected an error for the empty note, got %+v", view.action)
+	}
+
+	pressIssueKeys(view, keyRunes("Called the customer"), keyEnter)
+	if view.action == nil || view.action.step != issueStepConfirm || view.action.err == nil {
+		t.Fatalf("expected the form to stay open with the error, got %+v", view.action)
+	}
+
+	pressIssueKeys(view, keyEsc)
+	if view.IsEditing() {
+		t.Error("expected esc to close the form")
+	}
+}
+
+// TestIssueView_LoadHistory verifies that the history is loaded on init and again after leaving the view aborted it
+func TestIssueView_LoadHistory(t *testing.T) {
+	view := newTestIssueView(&fakeIssueActions{})
+	if view.Init() == nil {
+		t.Fatal("expected Init to load the history")
+	}
+
+	view.Cancel()
+	if view.history.loading {
+		t.Fatal("expected Cancel to abort loading the history")
+	}
+
+	cmd := view.Resume()
+	if cmd == nil {
+		t.Fatal("expected Resume to load the history again")
+	}
+	if !strings.Contains(view.viewport.View(), "Loading history...") {
+		t.Errorf("expected the history to be loading, got %q", view.viewport.View())
+	}
+
+	view.Update(cmd())
+	if !view.history.loaded || view.Issue.FullTitle() != "Reloaded" {
+		t.Fatalf("expected the loaded issue to be shown, got %+v", view.history)
+	}
+	if !strings.Contains(view.viewport.View(), "No changes yet") {
+		t.Errorf("expected the empty history, got %q", view.viewport.View())
+	}
+	if view.Resume() != nil {
+		t.Error("expected a loaded history not to be loaded again")
+	}
+}
+
+// TestIssueView_RenderDescription verifies that the markup of the description is rendered instead of shown
+func TestIssueView_RenderDescription(t *testing.T) {
+	issue := domain.NewIssue(42, "", "Jane", "Fix login", "h2. Steps\n\n* open *the* page", domain.NewProject(7, "Website"))
+	view := NewIssueView(100, 40, issue, nil, 0, redmine.TextFormatAuto)
+
+	content := ansi.Strip(view.viewport.View())
+	if !strings.Contains(content, "Steps") || !strings.Contains(content, "• open the page") || strings.Contains(content, "h2.") {
+		t.Errorf("expected the rendered description, got %q", content)
+	}
+}
@@ -93,6 +93,17 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 					}
 				}
 			}
+		case "s":
+			// ListView starts or stops the timer only when the filter input is not active.
+			if v.list.FilterState() != list.Filtering {
+				if selectedItem := v.list.SelectedItem(); selectedItem != nil {
+					if issueItem, ok := selectedItem.(*domain.Issue); ok {
+						return func() tea.Msg {
+							return messages.TimerToggleMsg{Issue: issueItem}
+						}
+					}
+				}
+			}
 		case "enter":
 			if selectedItem := v.list.SelectedItem(); selectedItem != nil {
 				if issueItem, ok := selectedItem.(*domain.Issue); ok {
@@ -113,7 +124,7 @@ func (v *ListView) Update(msg tea.Msg) tea.Cmd {
 func (v *ListView) Render() string {
 	listView := v.list.View()
 
-	helpText := "↑/↓ • enter: select • /: filter • t: log time • esc: back • ctrl+c: quit"
+	helpText := "↑/↓ • enter: select • /: filter • t: log time • s: start/stop timer • alt+w: timesheet • esc: back • ctrl+c: quit"
 	helpStyle := lipgloss.NewStyle().
 		Foreground(themes.TokyoNight.Foreground).
 		Background(themes.TokyoNight.Background).
@@ -0,0 +1,368 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// OutboxSource sends the queued time entries and loads their issues for editing.
+type OutboxSource interface {
+	outbox.Sender
+	domain.IssueGetter
+}
+
+// OutboxSentMsg is sent when sending one or all entries of the outbox has finished.
+type OutboxSentMsg struct {
+	Results []outbox.Result
+	Error   error
+}
+
+// OutboxIssueLoadedMsg is sent when the issue of a queued entry has been loaded for editing the entry.
+type OutboxIssueLoadedMsg struct {
+	Issue *domain.Issue
+	Entry outbox.Entry
+	Error error
+}
+
+// OutboxView lists the time entries waiting in the outbox along with the reason they could not be sent.
+// OutboxView sends them again, opens them for editing and discards them after confirmation.
+type OutboxView struct {
+	width, height int
+
+	store   *outbox.Store
+	profile string
+	source  OutboxSource
+	cancel  context.CancelFunc
+	spinner spinner.Model
+
+	entries []outbox.Entry
+	cursor  int
+
+	busy       string // busy describes the request in flight, empty if there is none
+	confirming bool
+	notice     string // notice summarizes the last attempt to send entries
+	err        error
+}
+
+// NewOutboxView creates a view listing the entries queued in store for profile.
+func NewOutboxView(width, height int, store *outbox.Store, profile string, source OutboxSource) *OutboxView {
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
+
+	return &OutboxView{
+		width:   width,
+		height:  height,
+		store:   store,
+		profile: profile,
+		source:  source,
+		spinner: s,
+	}
+}
+
+// Init reads the queued entries.
+func (v *OutboxView) Init() tea.Cmd {
+	v.Reload()
+	return nil
+}
+
+// Reload reads the queued entries again, e.g. after they have been sent in the background.
+func (v *OutboxView) Reload() {
+	entries, err := v.store.List(v.profile)
+	if err != nil {
+		v.err = err
+		return
+	}
+
+	v.entries = entries
+	v.cursor = min(v.cursor, max(len(v.entries)-1, 0))
+}
+
+// Resume reads the queued entries again when the user returns from editing one of them.
+func (v *OutboxView) Resume() tea.Cmd {
+	v.Reload()
+	return nil
+}
+
+// Cancel aborts sending entries or loading the issue of an entry.
+func (v *OutboxView) Cancel() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	v.busy = ""
+}
+
+// Update handles navigation, sending, editing and discarding of queued entries.
+func (v *OutboxView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case OutboxSentMsg:
+		v.cancel = nil
+		v.busy = ""
+		v.err = msg.Error
+		if msg.Error == nil {
+			v.notice = summarizeResults(msg.Results)
+		}
+		v.Reload()
+		return func() tea.Msg { return messages.OutboxChangedMsg{} }
+
+	case OutboxIssueLoadedMsg:
+		v.cancel = nil
+		v.busy = ""
+		if msg.Error != nil {
+			v.err = fmt.Errorf("failed to load issue #%d for editing: %w", msg.Entry.Params.IssueID, msg.Error)
+			return nil
+		}
+		return func() tea.Msg {
+			return messages.OutboxEditMsg{Issue: msg.Issue, Entry: msg.Entry}
+		}
+
+	case spinner.TickMsg:
+		if v.busy == "" {
+			return nil
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
+
+	case tea.KeyMsg:
+		if v.busy != "" {
+			return nil
+		}
+
+		if v.confirming {
+			v.confirming = false
+			if msg.String() == "y" {
+				return v.discardSelected()
+			}
+			return nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			v.cursor = max(v.cursor-1, 0)
+		case "down", "j":
+			v.cursor = min(v.cursor+1, max(len(v.entries)-1, 0))
+		case "d":
+			if v.selectedEntry() != nil {
+				v.confirming = true
+			}
+		case "r":
+			if entry := v.selectedEntry(); entry != nil {
+				id := entry.ID
+				return v.send("Sending time entry", func(ctx context.Context) ([]outbox.Result, error) {
+					result, err := v.store.Send(ctx, v.source, id)
+					if err != nil {
+						return nil, err
+					}
+					return []outbox.Result{result}, nil
+				})
+			}
+		case "s":
+			if len(v.entries) > 0 {
+				return v.send("Sending the outbox", func(ctx context.Context) ([]outbox.Result, error) {
+					return v.store.Flush(ctx, v.source, v.profile)
+				})
+			}
+		case "enter":
+			return v.editSelected()
+		}
+	}
+
+	return nil
+}
+
+// selectedEntry returns the entry under the cursor or nil if the outbox is empty.
+func (v *OutboxView) selectedEntry() *outbox.Entry {
+	if v.cursor >= len(v.entries) {
+		return nil
+	}
+	return &v.entries[v.cursor]
+}
+
+// send returns a command sending entries of the outbox with the given function.
+func (v *OutboxView) send(busy string, send func(ctx context.Context) ([]outbox.Result, error)) tea.Cmd {
+	ctx := v.start(busy)
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		results, err := send(ctx)
+		return OutboxSentMsg{Results: results, Error: err}
+	})
+}
+
+// editSelected loads the issue of the selected entry and opens the entry in the time entry form.
+func (v *OutboxView) editSelected() tea.Cmd {
+	entry := v.selectedEntry()
+	if entry == nil {
+		return nil
+	}
+
+	selected := *entry
+	source := v.source
+	ctx := v.start("Loading issue")
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		issue, err := source.GetIssue(ctx, selected.Params.IssueID)
+		return OutboxIssueLoadedMsg{Issue: issue, Entry: selected, Error: err}
+	})
+}
+
+// discardSelected removes the selected entry from the outbox.
+func (v *OutboxView) discardSelected() tea.Cmd {
+	entry := v.selectedEntry()
+	if entry == nil {
+		return nil
+	}
+
+	v.notice = ""
+	if v.err = v.store.Remove(entry.ID); v.err != nil {
+		return nil
+	}
+	v.Reload()
+	return func() tea.Msg { return messages.OutboxChangedMsg{} }
+}
+
+// start marks the view busy with a new request and returns its context.
+func (v *OutboxView) start(busy string) context.Context {
+	v.Cancel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.busy = busy
+	v.notice = ""
+	v.err = nil
+	return ctx
+}
+
+// summarizeResults describes the outcome of sending entries, e.g. "2 sent, 1 failed".
+func summarizeResults(results []outbox.Result) string {
+	if len(results) == 0 {
+		return "Nothing to send"
+	}
+
+	counts := make(map[outbox.Outcome]int)
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+
+	var parts []string
+	for _, outcome := range []outbox.Outcome{outbox.Sent, outbox.AlreadySent, outbox.Failed} {
+		if counts[outcome] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[outcome], outcome))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SetSize sets the dimensions of the OutboxView.
+func (v *OutboxView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// Render renders the queued entries, the result of the last attempt to send them and the discard confirmation.
+func (v *OutboxView) Render() string {
+	title := titleStyle.Render("OUTBOX")
+
+	var body string
+	switch {
+	case v.busy != "":
+		body = loadingStyle.Render(v.spinner.View() + " " + v.busy + "...")
+	case len(v.entries) == 0:
+		body = emptyMessageStyle.Render("No time entries waiting to be sent")
+	default:
+		body = v.renderEntries()
+	}
+
+	sections := []string{title, body}
+
+	if v.notice != "" {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Info).
+			Padding(0, 1).
+			Render(v.notice))
+	}
+
+	if v.err != nil {
+		message := submissionErrorMessage(v.err)
+		if errors.Is(v.err, outbox.ErrBusy) {
+			message = "The outbox is being sent in the background, try again in a moment"
+		}
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Bold(true).
+			Padding(0, 1).
+			Render("⚠ "+message))
+	}
+
+	if v.confirming {
+		entry := v.selectedEntry()
+		prompt := fmt.Sprintf("Discard %.2fh on #%d (%s)? It will not be sent. y: discard • any other key: keep", entry.Params.Hours, entry.Params.IssueID, entry.Params.SpentOn)
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Warning).
+			Bold(true).
+			Padding(0, 1).
+			Render(prompt))
+	}
+
+	helpText := helpStyle.Render("↑/↓: select • enter: edit • r: retry • s: send all • d: discard • esc: back")
+	sections = append(sections, "", helpText)
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderEntries renders one line per queued entry with its comment and the reason it is still queued below.
+func (v *OutboxView) renderEntries() string {
+	var lines []string
+	for i, entry := range v.entries {
+		prefix := "  "
+		style := fieldValueStyle
+		if i == v.cursor {
+			prefix = "> "
+			style = focusedStyle
+		}
+
+		date := entry.Params.SpentOn
+		if spentOn, err := time.Parse("2006-01-02", entry.Params.SpentOn); err == nil {
+			date = spentOn.Format("Mon 02.01.")
+		}
+
+		label := fmt.Sprintf("#%d", entry.Params.IssueID)
+		if entry.Title != "" {
+			label += " " + entry.Title
+		}
+		line := fmt.Sprintf("%s  %5.2fh  %s", date, entry.Params.Hours, label)
+		lines = append(lines, prefix+style.Render(truncate(line, max(v.width-8, 20))))
+
+		if comment := strings.TrimSpace(entry.Params.Comments); comment != "" {
+			lines = append(lines, "    "+helpStyle.Render(truncate(comment, max(v.width-12, 20))))
+		}
+
+		status := fmt.Sprintf("queued %s", entry.QueuedAt.Local().Format("2006-01-02 15:04"))
+		if entry.Attempts > 0 {
+			status += fmt.Sprintf(" • %d attempts", entry.Attempts)
+		}
+		if entry.Held {
+			status += " • held until retried or edited"
+		}
+		lines = append(lines, "    "+helpStyle.Render(status))
+
+		if entry.LastError != "" {
+			lines = append(lines, "    "+lipgloss.NewStyle().
+				Foreground(themes.TokyoNight.Error).
+				Padding(0, 1).
+				Render(truncate(entry.LastError, max(v.width-12, 20))))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
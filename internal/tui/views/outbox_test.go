@@ -0,0 +1,116 @@
+package views
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mockOutboxSource implements OutboxSource for testing
+type mockOutboxSource struct {
+	mockIssueRepository
+	created []models.CreateTimeEntryParams
+}
+
+// CreateTimeEntry records the created time entry
+func (m *mockOutboxSource) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	m.created = append(m.created, params)
+	return &models.TimeEntry{ID: len(m.created)}, nil
+}
+
+// ListTimeEntries returns no time entries
+func (m *mockOutboxSource) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	return nil, nil
+}
+
+// runOutbox executes the batched command and feeds every non-tick message back into the view
+func runOutbox(v *OutboxView, cmd tea.Cmd) {
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		return
+	}
+	for _, c := range batch {
+		switch msg := c().(type) {
+		case OutboxSentMsg, OutboxIssueLoadedMsg:
+			v.Update(msg)
+		}
+	}
+}
+
+// newTestOutboxView creates an outbox view listing two queued entries
+func newTestOutboxView(t *testing.T) (*OutboxView, *outbox.Store, *mockOutboxSource) {
+	t.Helper()
+	store := outbox.NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+	for _, comment := range []string{"Fix login", "Review"} {
+		params := models.CreateTimeEntryParams{IssueID: 123, Hours: 1, ActivityID: 1, Comments: comment, SpentOn: "2025-08-13"}
+		if _, err := store.Add(outbox.Entry{Title: "Test Issue", Params: params, LastError: "connection refused"}); err != nil {
+			t.Fatalf("Add returned error: %v", err)
+		}
+	}
+
+	source := &mockOutboxSource{}
+	view := NewOutboxView(100, 40, store, "", source)
+	view.Init()
+	return view, store, source
+}
+
+// TestOutboxView_Retry verifies that the selected entry is sent and leaves the outbox
+func TestOutboxView_Retry(t *testing.T) {
+	view, store, source := newTestOutboxView(t)
+
+	rendered := view.Render()
+	for _, want := range []string{"#123 Test Issue", "Fix login", "connection refused"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Render() does not contain %q:\n%s", want, rendered)
+		}
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	runOutbox(view, view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}))
+
+	if len(source.created) != 1 || source.created[0].Comments != "Review" {
+		t.Fatalf("created = %+v, want the selected entry to be sent", source.created)
+	}
+	if len(view.entries) != 1 || view.entries[0].Params.Comments != "Fix login" {
+		t.Errorf("entries = %+v, want only the other entry to remain", view.entries)
+	}
+	if entries, _ := store.List(""); len(entries) != 1 {
+		t.Errorf("List() = %+v, want one queued entry", entries)
+	}
+	if !strings.Contains(view.Render(), "1 sent") {
+		t.Errorf("Render() does not summarize the result:\n%s", view.Render())
+	}
+}
+
+// TestOutboxView_DiscardRequiresConfirmation verifies that entries are only discarded after confirming with y
+func TestOutboxView_DiscardRequiresConfirmation(t *testing.T) {
+	view, store, source := newTestOutboxView(t)
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if len(view.entries) != 2 {
+		t.Fatalf("entries = %+v, want both entries to be kept", view.entries)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected a command after discarding, got nil")
+	}
+	if _, ok := cmd().(messages.OutboxChangedMsg); !ok {
+		t.Errorf("expected OutboxChangedMsg, got %T", cmd())
+	}
+
+	if entries, _ := store.List(""); len(entries) != 1 || entries[0].Params.Comments != "Review" {
+		t.Errorf("List() = %+v, want only the second entry to remain", entries)
+	}
+	if len(source.created) != 0 {
+		t.Errorf("created = %+v, want nothing to be sent", source.created)
+	}
+}
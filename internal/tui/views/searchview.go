@@ -1,7 +1,11 @@
 package views
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/b1tray3r/rmt/internal/config"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
@@ -18,14 +22,44 @@ const (
 	Favorites
 )
 
+// Modes of editing the favorites in the SearchView.
+const (
+	favoriteEditNone = iota
+	favoriteEditAdd
+	favoriteEditRename
+)
+
+// SavedQueriesLoadedMsg is sent when the queries saved in Redmine have been loaded.
+// Source identifies the repository they were loaded from, results of a previous profile are dropped.
+type SavedQueriesLoadedMsg struct {
+	Source    domain.QueryLister
+	Favorites []*domain.Favorite
+	Error     error
+}
+
 type SearchView struct {
 	width, height int
 	focusedIndex  int
 	views         map[int]any
 	config        *config.Config
+
+	// favorites are the favorites shown in the list, in the form they are saved to the config file.
+	favorites []config.FavoriteConfig
+
+	queries      domain.QueryLister // queries loads the queries saved in Redmine, nil to show local favorites only
+	savedQueries []*domain.Favorite // savedQueries are the queries saved in Redmine, shown after the local favorites
+
+	editMode      int             // editMode is the favorite edit mode, favoriteEditNone while browsing
+	editQuery     bool            // editQuery reports whether the query input has the focus instead of the name input
+	nameInput     textinput.Model // nameInput edits the name of a favorite
+	queryInput    textinput.Model // queryInput edits the query of a new favorite
+	confirmDelete bool            // confirmDelete reports whether the user is asked to confirm deleting the selected favorite
+	err           error           // err is the last failure of editing the favorites
 }
 
-func NewSearchView(width int, cfg *config.Config) *SearchView {
+// NewSearchView creates a SearchView offering the favorites of cfg and the queries saved in Redmine.
+// queries may be nil, the favorites list then only contains the favorites of the config file.
+func NewSearchView(width int, cfg *config.Config, queries domain.QueryLister) *SearchView {
 	ti := textinput.New()
 	ti.Placeholder = "Search issues... (e.g., #123, 'bug fix', etc.)"
 
@@ -78,26 +112,208 @@ func NewSearchView(width int, cfg *config.Config) *SearchView {
 			SearchInput: ti,
 			Favorites:   list,
 		},
-		config: cfg,
+		config:     cfg,
+		queries:    queries,
+		nameInput:  newFavoriteInput("Name", width),
+		queryInput: newFavoriteInput("Redmine query, e.g. status_id=open&assigned_to_id=me", width),
 	}
 }
 
-// InitializeFavorites sets up the initial favorites list
+// newFavoriteInput creates a text input of the favorite edit form.
+func newFavoriteInput(placeholder string, width int) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 1024
+	ti.Width = width - 12
+	ti.PlaceholderStyle = ti.PlaceholderStyle.
+		Background(themes.TokyoNight.Background).
+		Foreground(themes.TokyoNight.Foreground)
+	ti.TextStyle = ti.TextStyle.
+		Background(themes.TokyoNight.Background).
+		Foreground(themes.TokyoNight.Foreground)
+	return ti
+}
+
+// InitializeFavorites sets up the favorites list from the config, falling back to the built-in defaults
 func (v *SearchView) InitializeFavorites() {
-	favoritesList := v.views[Favorites].(list.Model)
+	v.favorites = slices.Clone(v.config.ActiveFavorites())
+	v.refreshFavorites()
+}
 
-	followUpFieldID := v.config.Redmine.FollowUpFieldID
+// refreshFavorites shows the current favorites in the list, keeping the selection where possible
+func (v *SearchView) refreshFavorites() {
+	favoritesList := v.views[Favorites].(list.Model)
 
-	favoritesList.SetItems([]list.Item{})
-	favorites := []list.Item{
-		domain.NewFavorite(1, "Follow-up: diese Woche", fmt.Sprintf("f%%5B%%5D=status_id&op%%5Bstatus_id%%5D=o&f%%5B%%5D=assigned_to_id&op%%5Bassigned_to_id%%5D=%%3D&v%%5Bassigned_to_id%%5D%%5B%%5D=me&f%%5B%%5D=cf_%d&op%%5Bcf_%d%%5D=w", followUpFieldID, followUpFieldID)),
-		domain.NewFavorite(2, "Meine offenen Tickets", "f%5B%5D=status_id&op%5Bstatus_id%5D=o&f%5B%5D=assigned_to_id&op%5Bassigned_to_id%5D=%3D&v%5Bassigned_to_id%5D%5B%5D=me"),
+	items := make([]list.Item, 0, len(v.favorites)+len(v.savedQueries))
+	for i, favorite := range v.favorites {
+		query, err := favorite.QueryString()
+		if err != nil {
+			query = err.Error()
+		}
+		items = append(items, domain.NewFavorite(i+1, favorite.Name, query, favorite.Hotkey))
+	}
+	for _, query := range v.savedQueries {
+		items = append(items, query)
 	}
 
-	favoritesList.SetItems(favorites)
+	favoritesList.SetItems(items)
+	if favoritesList.Index() >= len(items) && len(items) > 0 {
+		favoritesList.Select(len(items) - 1)
+	}
 	v.views[Favorites] = favoritesList
 }
 
+// loadQueries returns a command fetching the queries saved in Redmine, nil if there is no source for them.
+// loadQueries is not cancelled when a search is started, so the queries are there when the user comes back.
+func (v *SearchView) loadQueries() tea.Cmd {
+	if v.queries == nil {
+		return nil
+	}
+
+	source := v.queries
+	return func() tea.Msg {
+		favorites, err := source.ListQueries(context.Background())
+		return SavedQueriesLoadedMsg{Source: source, Favorites: favorites, Error: err}
+	}
+}
+
+// IsEditing reports whether a favorite is being edited, the view then needs the esc key to cancel.
+func (v *SearchView) IsEditing() bool {
+	return v.editMode != favoriteEditNone || v.confirmDelete
+}
+
+// selectedFavorite returns the index of the selected favorite in the favorites, -1 if none is selected.
+// Queries saved in Redmine are not part of the favorites, selectedFavorite returns -1 for them as well.
+func (v *SearchView) selectedFavorite() int {
+	favoritesList := v.views[Favorites].(list.Model)
+	favorite, ok := favoritesList.SelectedItem().(*domain.Favorite)
+	if !ok || favorite.IsSavedQuery() {
+		return -1
+	}
+	return favorite.ID() - 1
+}
+
+// saveFavorites writes the favorites to the config file and shows them once they are saved.
+func (v *SearchView) saveFavorites(favorites []config.FavoriteConfig) bool {
+	if err := v.config.SaveFavorites(slices.Clone(favorites)); err != nil {
+		v.err = err
+		return false
+	}
+
+	v.err = nil
+	v.favorites = favorites
+	v.refreshFavorites()
+	return true
+}
+
+// startEditing opens the favorite edit form in the given mode.
+func (v *SearchView) startEditing(mode int) tea.Cmd {
+	v.err = nil
+	v.editMode = mode
+	v.editQuery = false
+	v.nameInput.Reset()
+	v.queryInput.Reset()
+	v.queryInput.Blur()
+
+	switch mode {
+	case favoriteEditAdd:
+		// The current search is the most likely query to keep
+		v.queryInput.SetValue(v.views[SearchInput].(textinput.Model).Value())
+	case favoriteEditRename:
+		v.nameInput.SetValue(v.favorites[v.selectedFavorite()].Name)
+	}
+
+	return v.nameInput.Focus()
+}
+
+// stopEditing closes the favorite edit form.
+func (v *SearchView) stopEditing() {
+	v.editMode = favoriteEditNone
+	v.nameInput.Blur()
+	v.queryInput.Blur()
+}
+
+// submitEditing saves the favorite of the edit form.
+func (v *SearchView) submitEditing() {
+	name := strings.TrimSpace(v.nameInput.Value())
+	query := strings.TrimSpace(v.queryInput.Value())
+	if name == "" || (v.editMode == favoriteEditAdd && query == "") {
+		v.err = errors.New("name and query must not be empty")
+		return
+	}
+
+	favorites := slices.Clone(v.favorites)
+	switch v.editMode {
+	case favoriteEditAdd:
+		favorites = append(favorites, config.FavoriteConfig{Name: name, Query: query})
+	case favoriteEditRename:
+		favorites[v.selectedFavorite()].Name = name
+	}
+
+	if v.saveFavorites(favorites) {
+		v.stopEditing()
+	}
+}
+
+// updateEditing handles the input while the favorite edit form is open.
+func (v *SearchView) updateEditing(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			v.err = nil
+			v.stopEditing()
+			return nil
+		case "enter":
+			v.submitEditing()
+			return nil
+		case "tab", "shift+tab":
+			if v.editMode == favoriteEditAdd {
+				v.editQuery = !v.editQuery
+				if v.editQuery {
+					v.nameInput.Blur()
+					return v.queryInput.Focus()
+				}
+				v.queryInput.Blur()
+				return v.nameInput.Focus()
+			}
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if v.editQuery {
+		v.queryInput, cmd = v.queryInput.Update(msg)
+	} else {
+		v.nameInput, cmd = v.nameInput.Update(msg)
+	}
+	return cmd
+}
+
+// runHotkey returns the command running the favorite bound to key, nil if there is none.
+// While the search input has the focus, only hotkeys with a modifier apply, so typing is not affected.
+func (v *SearchView) runHotkey(key string) tea.Cmd {
+	if v.focusedIndex == SearchInput && !strings.Contains(key, "+") {
+		return nil
+	}
+
+	for _, favorite := range v.favorites {
+		if favorite.Hotkey == "" || favorite.Hotkey != key {
+			continue
+		}
+
+		query, err := favorite.QueryString()
+		if err != nil {
+			v.err = err
+			return nil
+		}
+		return func() tea.Msg {
+			return messages.SearchSubmittedMsg{Query: query}
+		}
+	}
+
+	return nil
+}
+
 func (v *SearchView) SetSize(width, height int) {
 	v.width = width
 	v.height = height - 1
@@ -110,13 +326,90 @@ func (v *SearchView) SetSize(width, height int) {
 }
 
 // Init initializes the SearchView and returns the blinking cursor command.
+// Init starts loading the queries saved in Redmine as well.
 func (v *SearchView) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, v.loadQueries())
 }
 
 func (v *SearchView) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(SavedQueriesLoadedMsg); ok {
+		if msg.Source != v.queries {
+			return nil
+		}
+		if msg.Error != nil {
+			v.err = fmt.Errorf("failed to load saved queries from Redmine: %w", msg.Error)
+			return nil
+		}
+		v.savedQueries = msg.Favorites
+		v.refreshFavorites()
+		return nil
+	}
+
+	if v.editMode != favoriteEditNone {
+		return v.updateEditing(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if v.confirmDelete {
+			v.confirmDelete = false
+			if msg.String() == "y" {
+				if index := v.selectedFavorite(); index >= 0 {
+					v.saveFavorites(slices.Delete(slices.Clone(v.favorites), index, index+1))
+				}
+			}
+			return nil
+		}
+
+		filtering := v.views[Favorites].(list.Model).FilterState() == list.Filtering
+		if !filtering {
+			if cmd := v.runHotkey(msg.String()); cmd != nil {
+				return cmd
+			}
+		}
+
+		if msg.String() == "alt+b" {
+			// The current search is refined in the builder, plain text is not a query to start from
+			query := v.views[SearchInput].(textinput.Model).Value()
+			if !strings.Contains(query, "=") {
+				query = ""
+			}
+			return func() tea.Msg {
+				return messages.FilterBuilderOpenMsg{Query: query}
+			}
+		}
+
+		if v.focusedIndex == Favorites && !filtering {
+			switch msg.String() {
+			case "b":
+				favorite, ok := v.views[Favorites].(list.Model).SelectedItem().(*domain.Favorite)
+				if !ok {
+					return nil
+				}
+				if favorite.IsSavedQuery() {
+					v.err = errors.New("queries saved in Redmine can only be changed in Redmine")
+					return nil
+				}
+				query := favorite.Config()
+				return func() tea.Msg {
+					return messages.FilterBuilderOpenMsg{Query: query}
+				}
+			case "a":
+				return v.startEditing(favoriteEditAdd)
+			case "r":
+				if v.selectedFavorite() >= 0 {
+					return v.startEditing(favoriteEditRename)
+				}
+				return nil
+			case "d":
+				if v.selectedFavorite() >= 0 {
+					v.err = nil
+					v.confirmDelete = true
+				}
+				return nil
+			}
+		}
+
 		switch msg.String() {
 		case "tab":
 			if v.focusedIndex == SearchInput {
@@ -185,26 +478,61 @@ func (v *SearchView) Render() string {
 		Padding(0, 2).
 		Render(v.views[SearchInput].(textinput.Model).View())
 
+	listContent := v.views[Favorites].(list.Model).View()
+	if v.editMode != favoriteEditNone {
+		listContent = v.renderEditForm()
+	}
+
 	listView := lipgloss.NewStyle().
 		Width(v.width).
 		Height(v.height - 7).
-		Render(v.views[Favorites].(list.Model).View())
+		Render(listContent)
 
 	var hint string
-	if v.focusedIndex == SearchInput {
-		hint = "Press 'Enter' to search, 'Tab' to switch to favorites, 'Esc' to clear, 'Ctrl+c' to quit"
-	} else {
-		hint = "Press 'Enter' to select favorite, 'Tab' to switch to search, 'Ctrl+c' to quit"
+	switch {
+	case v.editMode != favoriteEditNone:
+		hint = "Press 'Enter' to save the favorite, 'Tab' to switch fields, 'Esc' to cancel"
+	case v.confirmDelete:
+		hint = "Delete the selected favorite? Press 'y' to confirm, any other key to keep it"
+	case v.focusedIndex == SearchInput:
+		hint = "Press 'Enter' to search, 'Tab' to switch to favorites, 'Alt+b' to build a filter, 'Alt+w' for the timesheet, 'Ctrl+c' to quit"
+	default:
+		hint = "Press 'Enter' to select favorite, 'a'/'r'/'d' to add/rename/delete, 'b' to refine the filters, 'Tab' to switch to search, 'Ctrl+c' to quit"
 	}
 
 	hintView := lipgloss.NewStyle().
 		Foreground(themes.TokyoNight.Foreground).
 		Render(hint)
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		headline,
-		inputView,
-		listView,
-		hintView,
-	)
+	parts := []string{headline, inputView, listView, hintView}
+	if v.err != nil {
+		parts = append(parts, lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Render(v.err.Error()))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// renderEditForm renders the form for adding or renaming a favorite.
+func (v *SearchView) renderEditForm() string {
+	labelStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(themes.TokyoNight.Primary).
+		Width(8)
+
+	title := "Add favorite"
+	if v.editMode == favoriteEditRename {
+		title = "Rename favorite"
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(themes.TokyoNight.Secondary).PaddingBottom(1).Render(title),
+		lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Name"), v.nameInput.View()),
+	}
+	if v.editMode == favoriteEditAdd {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render("Query"), v.queryInput.View()))
+	}
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
 }
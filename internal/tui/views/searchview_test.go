@@ -0,0 +1,180 @@
+package views
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestSearchView creates a search view on a config loaded from a temporary file
+func newTestSearchView(t *testing.T, favorites string) (*SearchView, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	content := "redmine:\n  url: https://example.com\n  token: secret\n" + favorites
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	view := NewSearchView(80, cfg, nil)
+	view.InitializeFavorites()
+	view.SetSize(80, 40)
+	return view, path
+}
+
+// fakeQueryLister implements domain.QueryLister for testing
+type fakeQueryLister struct {
+	favorites []*domain.Favorite
+}
+
+// ListQueries returns the configured saved queries
+func (f *fakeQueryLister) ListQueries(ctx context.Context) ([]*domain.Favorite, error) {
+	return f.favorites, nil
+}
+
+// typeText sends the runes of text to the view as key presses
+func typeText(v *SearchView, text string) {
+	for _, r := range text {
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// TestSearchView_DefaultFavorites verifies that the built-in favorites are shown if the config declares none
+func TestSearchView_DefaultFavorites(t *testing.T) {
+	view, _ := newTestSearchView(t, "")
+
+	if len(view.favorites) != 1 || view.favorites[0].Name != "My open issues" {
+		t.Errorf("favorites = %+v, want the built-in default", view.favorites)
+	}
+}
+
+// TestSearchView_EditFavorites verifies that adding, renaming and deleting favorites is written to the config file
+func TestSearchView_EditFavorites(t *testing.T) {
+	view, path := newTestSearchView(t, "favorites: []\n")
+
+	typeText(view, "assigned_to_id=me")
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !view.IsEditing() {
+		t.Fatal("expected the edit form to be open after pressing a")
+	}
+	if got := view.queryInput.Value(); got != "assigned_to_id=me" {
+		t.Errorf("query = %q, want the current search", got)
+	}
+	typeText(view, "Mine")
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	reloaded, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if len(reloaded.Favorites) != 1 || reloaded.Favorites[0].Name != "Mine" || reloaded.Favorites[0].Query != "assigned_to_id=me" {
+		t.Fatalf("saved favorites = %+v, want the added favorite", reloaded.Favorites)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	for range "Mine" {
+		view.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	typeText(view, "Assigned to me")
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	reloaded, _ = config.LoadConfig(path)
+	if len(reloaded.Favorites) != 1 || reloaded.Favorites[0].Name != "Assigned to me" {
+		t.Fatalf("saved favorites = %+v, want the renamed favorite", reloaded.Favorites)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	reloaded, _ = config.LoadConfig(path)
+	if len(reloaded.Favorites) != 0 || reloaded.Favorites == nil {
+		t.Errorf("saved favorites = %#v, want an empty list", reloaded.Favorites)
+	}
+	if len(view.favorites) != 0 {
+		t.Errorf("expected no favorites to be shown, got %+v", view.favorites)
+	}
+}
+
+// TestSearchView_InvalidFavorite verifies that a favorite with an invalid query is refused and the form stays open
+func TestSearchView_InvalidFavorite(t *testing.T) {
+	view, path := newTestSearchView(t, "favorites: []\n")
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+
+	typeText(view, "f[]=status_id&op[status_id]=%3F")
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	typeText(view, "Broken")
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !view.IsEditing() || view.err == nil {
+		t.Errorf("expected the form to stay open with an error, editing = %v, err = %v", view.IsEditing(), view.err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Errorf("config file changed although the favorite was refused:\n%s", after)
+	}
+}
+
+// TestSearchView_Hotkey verifies that a favorite hotkey submits its query
+func TestSearchView_Hotkey(t *testing.T) {
+	view, _ := newTestSearchView(t, "favorites:\n  - name: Mine\n    query: assigned_to_id=me\n    hotkey: alt+1\n")
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1"), Alt: true})
+	if cmd == nil {
+		t.Fatal("expected a command for the hotkey, got nil")
+	}
+	msg, ok := cmd().(messages.SearchSubmittedMsg)
+	if !ok || msg.Query != "assigned_to_id=me" {
+		t.Errorf("expected SearchSubmittedMsg for the favorite, got %#v", cmd())
+	}
+}
+
+// TestSearchView_SavedQueries verifies that queries saved in Redmine are listed after the favorites and run by query ID
+func TestSearchView_SavedQueries(t *testing.T) {
+	view, _ := newTestSearchView(t, "favorites:\n  - name: Mine\n    query: assigned_to_id=me\n")
+	queries := &fakeQueryLister{favorites: []*domain.Favorite{
+		domain.NewSavedQuery(3, "Open bugs", "query_id=3", true),
+	}}
+	view.queries = queries
+
+	// Results loaded for another profile are dropped
+	view.Update(SavedQueriesLoadedMsg{Source: &fakeQueryLister{}, Favorites: queries.favorites})
+	if items := view.views[Favorites].(list.Model).Items(); len(items) != 1 {
+		t.Fatalf("expected only the local favorite, got %d items", len(items))
+	}
+
+	msg, _ := view.loadQueries()().(SavedQueriesLoadedMsg)
+	view.Update(msg)
+	if items := view.views[Favorites].(list.Model).Items(); len(items) != 2 {
+		t.Fatalf("expected the favorite and the saved query, got %d items", len(items))
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if view.IsEditing() {
+		t.Error("expected saved queries not to be renamed locally")
+	}
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command for the selected query, got nil")
+	}
+	if submitted, ok := cmd().(messages.SearchSubmittedMsg); !ok || submitted.Query != "query_id=3" {
+		t.Errorf("expected SearchSubmittedMsg for query 3, got %#v", cmd())
+	}
+}
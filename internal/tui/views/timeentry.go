@@ -4,14 +4,21 @@
 package views
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
 	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/timer"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
 	"github.com/b1tray3r/rmt/internal/tui/messages"
 	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -56,12 +63,6 @@ var (
 			Align(lipgloss.Center)
 )
 
-// Activity represents a Redmine activity that can be associated with time entries.
-type Activity struct {
-	ID   int
-	Name string
-}
-
 // TimeEntry represents a time entry record with all necessary fields for logging work.
 type TimeEntry struct {
 	IssueID     int
@@ -91,19 +92,57 @@ const (
 	StateSubmitting
 	StateCompleted
 	StateError
+	StateQueued
 )
 
+// ActivitiesLoadedMsg is sent when the activities of the issue's project have been loaded.
+// Recent is the activity the user logged time with last, zero if it is unknown or not needed.
+type ActivitiesLoadedMsg struct {
+	Activities []domain.Activity
+	Recent     int
+	Error      error
+}
+
 // TimeEntryView handles the time entry interface for logging work against issues.
 type TimeEntryView struct {
 	width  int
 	height int
 
 	timeLogService domain.TimeEntryCreator
+	cancel         context.CancelFunc // cancel aborts loading the activities
+	// cancelSubmission aborts the submission in flight, only the user does so, leaving the view lets it complete.
+	cancelSubmission context.CancelFunc
+	cancelling       bool // cancelling reports whether the user cancelled the submission in flight
+
+	// submitted is the time entry being sent and attemptedAt the moment it was sent, they are queued if sending fails.
+	submitted   models.CreateTimeEntryParams
+	attemptedAt time.Time
+
+	issueRepository   domain.IssueRepository
+	activityPatterns  []string
+	loadingActivities bool
+	activitiesErr     error
+	spinner           spinner.Model
+	// preferredActivity is the activity of the edited entry, it is selected once the activities are loaded.
+	preferredActivity *domain.Activity
+
+	// entry is the time entry being edited, nil when a new time entry is created.
+	entry          *models.TimeEntry
+	timeLogUpdater domain.TimeEntryUpdater
+
+	// outbox keeps new time entries that could not be sent, nil if failures are only shown.
+	outbox  *outbox.Store
+	profile string
+	// queued is the outbox entry being edited, nil unless a time entry waiting in the outbox is changed.
+	queued *outbox.Entry
+
+	// timer is the stopped timer whose time is logged, nil if the time entry does not log a timer.
+	timer *timer.Timer
 
 	issue *domain.Issue
 
-	activities       []Activity
-	selectedActivity *Activity
+	activities       []domain.Activity
+	selectedActivity *domain.Activity
 	datePicker       *DatePicker
 	hoursSelector    *HoursSelector
 	descInput        textinput.Model
@@ -114,9 +153,10 @@ type TimeEntryView struct {
 	SearchInput *textinput.Model
 }
 
-// NewTimeEntryView creates a new time entry view instance with the specified dimensions and context.
+// NewTimeEntryView creates a new time entry view instance with the specified dimensions.
 // NewTimeEntryView initializes all necessary components including date picker, hours selector, and activity list.
-// It returns an error if the issue or issueRepository parameters are nil, or if activities cannot be loaded.
+// The activities of the issue's project matching activityPatterns are loaded by Init.
+// It returns an error if the issue or issueRepository parameters are nil.
 func NewTimeEntryView(width, height int, activityPatterns []string, issue *domain.Issue, issueRepository domain.IssueRepository, timeLogService domain.TimeEntryCreator) (*TimeEntryView, error) {
 	if issue == nil {
 		return nil, fmt.Errorf("issue cannot be nil")
@@ -126,19 +166,9 @@ func NewTimeEntryView(width, height int, activityPatterns []string, issue *domai
 		return nil, fmt.Errorf("issueRepository cannot be nil")
 	}
 
-	activities, err := issueRepository.GetProjectActivities(issue.Project().ID(), activityPatterns)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get project activities: %w", err)
-	}
-
-	var activitiesList []Activity
-	for id, name := range activities {
-		activity := Activity{
-			ID:   id,
-			Name: name,
-		}
-		activitiesList = append(activitiesList, activity)
-	}
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
 
 	descInput := textinput.New()
 	descInput.Placeholder = "Data log: describe your digital work..."
@@ -150,26 +180,95 @@ func NewTimeEntryView(width, height int, activityPatterns []string, issue *domai
 	searchInput.Placeholder = "Search (unused)"
 
 	v := &TimeEntryView{
-		width:          width,
-		height:         height,
-		timeLogService: timeLogService,
-		issue:          issue,
-		datePicker:     NewDatePicker(),
-		hoursSelector:  NewHoursSelector(),
-		descInput:      descInput,
-		focusIndex:     DateIndex,
-		activities:     activitiesList,
-		state:          StateEditing,
-		SearchInput:    &searchInput,
+		width:            width,
+		height:           height,
+		timeLogService:   timeLogService,
+		issueRepository:  issueRepository,
+		activityPatterns: activityPatterns,
+		spinner:          s,
+		issue:            issue,
+		datePicker:       NewDatePicker(),
+		hoursSelector:    NewHoursSelector(),
+		descInput:        descInput,
+		focusIndex:       DateIndex,
+		state:            StateEditing,
+		SearchInput:      &searchInput,
 	}
 
 	return v, nil
 }
 
 // Init implements the tea.Model interface and returns the initial command for the time entry view.
-// Init sets up the text input blinking cursor animation.
+// Init sets up the text input blinking cursor animation and starts loading the activities.
 func (v *TimeEntryView) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, v.loadActivities())
+}
+
+// loadActivities returns the command loading the activities of the issue's project.
+// The activity the user logged time with last is only looked up if the project has no default activity.
+func (v *TimeEntryView) loadActivities() tea.Cmd {
+	v.Cancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.loadingActivities = true
+	v.activitiesErr = nil
+
+	repository := v.issueRepository
+	issueID := v.issue.ID()
+	projectID := 0
+	if v.issue.Project() != nil {
+		projectID = v.issue.Project().ID()
+	}
+	patterns := v.activityPatterns
+
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		activities, err := repository.GetProjectActivities(ctx, projectID, patterns)
+		if err != nil {
+			return ActivitiesLoadedMsg{Error: err}
+		}
+		if slices.ContainsFunc(activities, func(a domain.Activity) bool { return a.IsDefault }) {
+			return ActivitiesLoadedMsg{Activities: activities}
+		}
+
+		// The preselection is a convenience, so failing to look it up does not fail loading the activities
+		recent, _ := repository.RecentActivity(ctx, issueID, projectID)
+		return ActivitiesLoadedMsg{Activities: activities, Recent: recent}
+	})
+}
+
+// setActivities shows the loaded activities and preselects one of them.
+// The activity of an edited entry comes first, then the project's default activity, then the one used last.
+func (v *TimeEntryView) setActivities(activities []domain.Activity, recent int) {
+	v.activities = activities
+	v.selectedActivity = nil
+
+	if preferred := v.preferredActivity; preferred != nil {
+		// The activity of the entry stays selectable even if it does not match the configured patterns
+		if !slices.ContainsFunc(v.activities, func(a domain.Activity) bool { return a.ID == preferred.ID }) && preferred.Name != "" {
+			v.activities = append(v.activities, *preferred)
+		}
+		if v.selectActivity(func(a domain.Activity) bool { return a.ID == preferred.ID }) {
+			return
+		}
+	}
+
+	if v.selectActivity(func(a domain.Activity) bool { return a.IsDefault }) {
+		return
+	}
+	if recent != 0 && v.selectActivity(func(a domain.Activity) bool { return a.ID == recent }) {
+		return
+	}
+	v.selectActivity(func(domain.Activity) bool { return true })
+}
+
+// selectActivity selects the first activity matching and reports whether there was one.
+func (v *TimeEntryView) selectActivity(match func(domain.Activity) bool) bool {
+	index := slices.IndexFunc(v.activities, match)
+	if index < 0 {
+		return false
+	}
+	v.selectedActivity = &v.activities[index]
+	return true
 }
 
 // Update processes input messages and updates the time entry view state accordingly.
@@ -178,17 +277,46 @@ func (v *TimeEntryView) Init() tea.Cmd {
 func (v *TimeEntryView) Update(msg tea.Msg) tea.Cmd {
 	// Handle submission result messages
 	switch msg := msg.(type) {
+	case ActivitiesLoadedMsg:
+		v.cancel = nil
+		v.loadingActivities = false
+		if msg.Error != nil {
+			v.activitiesErr = msg.Error
+			return nil
+		}
+		v.setActivities(msg.Activities, msg.Recent)
+		return nil
+	case spinner.TickMsg:
+		if !v.loadingActivities && v.state != StateSubmitting {
+			return nil
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
 	case TimeEntrySubmissionSuccess:
+		// A submission that completed although it was cancelled has been saved nevertheless
+		v.stopSubmission()
+		v.cancelling = false
 		v.state = StateCompleted
-		return nil
+		return v.timerLogged()
 	case TimeEntrySubmissionError:
+		v.stopSubmission()
+		v.cancelling = false
+		if errors.Is(msg.Error, context.Canceled) {
+			v.state = StateEditing
+			v.errorMessage = "Submission cancelled. Redmine may have received it nevertheless, check your time entries before submitting again"
+			return nil
+		}
+		if v.entry == nil && v.queued == nil && v.outbox != nil && outbox.Queueable(msg.Error) {
+			return v.queue(v.submitted, v.attemptedAt, msg.Error)
+		}
 		v.state = StateError
-		v.errorMessage = msg.Error.Error()
+		v.errorMessage = submissionErrorMessage(msg.Error)
 		return nil
 	}
 
-	// Handle input in error or completed state
-	if v.state == StateError || v.state == StateCompleted {
+	// Handle input in error, completed or queued state
+	if v.state == StateError || v.state == StateCompleted || v.state == StateQueued {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
 			switch keyMsg.String() {
 			case "enter":
@@ -198,19 +326,25 @@ func (v *TimeEntryView) Update(msg tea.Msg) tea.Cmd {
 					v.errorMessage = ""
 					return nil
 				}
-				// For completed state, return to issue view
-				return func() tea.Msg { return messages.ReturnToIssueMsg{} }
+				// For completed state, return to where the time entry came from
+				return v.returnCommand()
 			default:
-				if v.state == StateCompleted {
-					// Any key press after completion should return to issue view
-					return func() tea.Msg { return messages.ReturnToIssueMsg{} }
+				if v.state != StateError {
+					// Any key press after completion should return to where the time entry came from
+					return v.returnCommand()
 				}
 			}
 		}
 		return nil
 	}
 
+	// Keys pressed while submitting must not submit the time entry twice, they can only cancel the submission.
+	// esc is ignored as well, the user has to wait for the outcome or cancel explicitly.
 	if v.state == StateSubmitting {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "c" && !v.cancelling {
+			v.cancelling = true
+			v.stopSubmission()
+		}
 		return nil
 	}
 
@@ -223,6 +357,11 @@ func (v *TimeEntryView) Update(msg tea.Msg) tea.Cmd {
 		}
 
 		switch msg.String() {
+		case "ctrl+d":
+			if v.timer != nil {
+				// The user does not want to log the measured time
+				return tea.Batch(v.timerLogged(), v.returnCommand())
+			}
 		case "tab":
 			v.nextField()
 			return nil
@@ -233,6 +372,9 @@ func (v *TimeEntryView) Update(msg tea.Msg) tea.Cmd {
 			if v.focusIndex == SubmitIndex {
 				return v.submitWithCommand(v.issue.ID())
 			}
+			if v.focusIndex == ActivityIndex && v.activitiesErr != nil {
+				return v.loadActivities()
+			}
 		case "up", "down":
 			if v.focusIndex == ActivityIndex && len(v.activities) > 0 {
 				v.handleActivitySelection(msg.String())
@@ -260,10 +402,20 @@ func (v *TimeEntryView) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
-// submitWithCommand validates the form data and handles the time entry submission process.
-// submitWithCommand performs field validation, creates the time entry, and manages state transitions.
-// It returns a tea command for async submission or nil if validation fails.
+// submitWithCommand validates the form data and starts the time entry submission.
+// submitWithCommand returns the command sending the time entry to Redmine, whose result arrives as
+// TimeEntrySubmissionSuccess or TimeEntrySubmissionError. Queued entries are changed in the outbox right away.
+// It returns nil if validation fails.
 func (v *TimeEntryView) submitWithCommand(issueID int) tea.Cmd {
+	switch {
+	case v.loadingActivities:
+		v.errorMessage = "Please wait until the activities are loaded"
+		return nil
+	case v.activitiesErr != nil:
+		v.errorMessage = "The activities could not be loaded, press Enter on the activity field to try again"
+		return nil
+	}
+
 	if !v.HasValidEntry() {
 		v.errorMessage = "Please fill in all required fields"
 		return nil
@@ -271,33 +423,102 @@ func (v *TimeEntryView) submitWithCommand(issueID int) tea.Cmd {
 
 	v.errorMessage = ""
 
-	v.state = StateSubmitting
-
 	activityID := 0
 	if v.selectedActivity != nil {
 		activityID = v.selectedActivity.ID
 	}
 
-	_, err := v.timeLogService.CreateTimeEntry(models.CreateTimeEntryParams{
+	params := models.CreateTimeEntryParams{
 		IssueID:    issueID,
 		ActivityID: activityID,
 		Hours:      v.hoursSelector.SelectedHours(),
 		Comments:   strings.TrimSpace(v.descInput.Value()),
 		SpentOn:    v.datePicker.SelectedDate().Format("2006-01-02"),
+	}
+
+	if v.queued != nil {
+		// The changed entry is sent with the rest of the outbox, changing it only writes the outbox file
+		if _, err := v.outbox.Edit(v.queued.ID, params); err != nil {
+			v.state = StateError
+			v.errorMessage = submissionErrorMessage(err)
+			return nil
+		}
+		v.state = StateCompleted
+		return func() tea.Msg { return messages.OutboxChangedMsg{} }
+	}
+
+	v.stopSubmission()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancelSubmission = cancel
+	v.cancelling = false
+	v.state = StateSubmitting
+	v.submitted = params
+	v.attemptedAt = time.Now()
+
+	entry, creator, updater := v.entry, v.timeLogService, v.timeLogUpdater
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		var err error
+		if entry != nil {
+			err = updater.UpdateTimeEntry(ctx, entry.ID, models.UpdateTimeEntryParams{
+				IssueID:    params.IssueID,
+				ActivityID: params.ActivityID,
+				Hours:      params.Hours,
+				Comments:   &params.Comments,
+				SpentOn:    params.SpentOn,
+			})
+		} else {
+			_, err = creator.CreateTimeEntry(ctx, params)
+		}
+		if err != nil {
+			return TimeEntrySubmissionError{View: v, Error: err}
+		}
+		return TimeEntrySubmissionSuccess{View: v}
+	})
+}
+
+// queue keeps the time entry that could not be sent in the outbox, so it is sent once Redmine is back.
+// The failed attempt is recorded, so the outbox checks whether it reached Redmine before sending the entry again.
+func (v *TimeEntryView) queue(params models.CreateTimeEntryParams, attemptedAt time.Time, sendErr error) tea.Cmd {
+	_, err := v.outbox.Add(outbox.Entry{
+		Profile:      v.profile,
+		Title:        v.issue.Title(),
+		Params:       params,
+		Attempts:     1,
+		FirstAttempt: attemptedAt,
+		Attempted:    []models.CreateTimeEntryParams{params},
+		LastError:    sendErr.Error(),
 	})
 	if err != nil {
 		v.state = StateError
-		v.errorMessage = err.Error()
+		v.errorMessage = fmt.Sprintf("%s\nThe time entry could not be queued either: %v", submissionErrorMessage(sendErr), err)
 		return nil
 	}
 
-	return func() tea.Msg { return TimeEntrySubmissionSuccess{} }
+	v.state = StateQueued
+	v.errorMessage = submissionErrorMessage(sendErr)
+	return tea.Batch(func() tea.Msg { return messages.OutboxChangedMsg{} }, v.timerLogged())
+}
+
+// timerLogged returns the command forgetting the stopped timer logged by the view, nil if there is none.
+func (v *TimeEntryView) timerLogged() tea.Cmd {
+	if v.timer == nil {
+		return nil
+	}
+	stopped := *v.timer
+	return func() tea.Msg { return messages.TimerLoggedMsg{Timer: stopped} }
 }
 
 // Render returns the time entry view using internal state and implements the View interface.
 // Render delegates to RenderWithParams using the view's internal state values.
 func (v *TimeEntryView) Render() string {
-	title := titleStyle.Width(v.width).Render("TIME ENTRY")
+	heading := "TIME ENTRY"
+	switch {
+	case v.queued != nil:
+		heading = "EDIT QUEUED TIME ENTRY"
+	case v.EditsEntry():
+		heading = "EDIT TIME ENTRY"
+	}
+	title := titleStyle.Width(v.width).Render(heading)
 
 	if v.issue == nil {
 		return title + "\n\n" + emptyMessageStyle.Render("No issue selected")
@@ -310,6 +531,8 @@ func (v *TimeEntryView) Render() string {
 		return v.renderCompletedState(title, v.issue, v.width, v.height)
 	case StateError:
 		return v.renderErrorState(title, v.issue, v.width, v.height)
+	case StateQueued:
+		return v.renderQueuedState(title, v.issue)
 	default:
 		return v.renderEditingState(title, v.issue, v.activities, v.width, v.height)
 	}
@@ -317,14 +540,17 @@ func (v *TimeEntryView) Render() string {
 
 // renderEditingState renders the normal editing form with all input fields and validation.
 // renderEditingState creates the complete form interface including date picker, hours selector, and activity selection.
-func (v *TimeEntryView) renderEditingState(title string, issue *domain.Issue, activities []Activity, width, height int) string {
+func (v *TimeEntryView) renderEditingState(title string, issue *domain.Issue, activities []domain.Activity, width, height int) string {
 	issueInfo := fieldLabelStyle.Render(fmt.Sprintf("Issue: #%d %s", issue.ID(), issue.Title()))
 
 	var activitySection string
-	if len(activities) > 0 {
+	switch {
+	case v.loadingActivities:
+		activitySection = fieldLabelStyle.Render("Activity:") + "\n" + loadingStyle.Render(v.spinner.View()+" Loading activities...")
+	case v.activitiesErr != nil:
+		activitySection = v.renderActivityError()
+	default:
 		activitySection = v.renderActivitySelection(activities)
-	} else {
-		activitySection = loadingStyle.Render("Loading activities...")
 	}
 
 	dateSection := v.renderDatePicker()
@@ -349,7 +575,11 @@ func (v *TimeEntryView) renderEditingState(title string, issue *domain.Issue, ac
 			Render("⚠ " + v.errorMessage)
 	}
 
-	helpText := helpStyle.Render("Tab/Shift+Tab: next/previous field | Esc: back")
+	help := "Tab/Shift+Tab: next/previous field | Esc: back"
+	if v.timer != nil {
+		help += " | ctrl+d: discard timer"
+	}
+	helpText := helpStyle.Render(help)
 
 	sections := []string{
 		title,
@@ -377,24 +607,19 @@ func (v *TimeEntryView) renderEditingState(title string, issue *domain.Issue, ac
 }
 
 // renderSubmittingState renders the loading state during time entry submission.
-// renderSubmittingState displays a spinner animation and loading message while the form is being processed.
+// renderSubmittingState displays a spinner animation and loading message while the time entry is sent.
 func (v *TimeEntryView) renderSubmittingState(title string, issue *domain.Issue, width, height int) string {
 	issueInfo := fieldLabelStyle.Render(fmt.Sprintf("Issue: #%d %s", issue.ID(), issue.Title()))
 
-	loadingMessage := loadingStyle.Render("Submitting time entry...")
-
-	spinner := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	spinnerChar := spinner[int(time.Now().Unix())%len(spinner)]
-	spinnerStyle := lipgloss.NewStyle().
-		Foreground(themes.TokyoNight.Info).
-		Bold(true)
-
-	loadingWithSpinner := lipgloss.JoinHorizontal(lipgloss.Left,
-		spinnerStyle.Render(spinnerChar+" "),
-		loadingMessage,
-	)
+	message := "Submitting time entry..."
+	help := "Please wait while your time entry is being submitted... | c: cancel"
+	if v.cancelling {
+		message = "Cancelling submission..."
+		help = "Waiting for the request to stop..."
+	}
+	loadingWithSpinner := loadingStyle.Render(v.spinner.View() + " " + message)
 
-	helpText := helpStyle.Render("Please wait while your time entry is being submitted...")
+	helpText := helpStyle.Render(help)
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -415,14 +640,25 @@ func (v *TimeEntryView) renderSubmittingState(title string, issue *domain.Issue,
 func (v *TimeEntryView) renderCompletedState(title string, issue *domain.Issue, width, height int) string {
 	issueInfo := fieldLabelStyle.Render(fmt.Sprintf("Issue: #%d %s", issue.ID(), issue.Title()))
 
+	message := "✓ Time entry submitted successfully!"
+	help := "Press any key to return to issue view..."
+	switch {
+	case v.queued != nil:
+		message = "✓ Queued time entry updated, it is sent with the rest of the outbox"
+		help = "Press any key to return to the outbox..."
+	case v.EditsEntry():
+		message = "✓ Time entry updated successfully!"
+		help = "Press any key to return to your time entries..."
+	}
+
 	successMessage := lipgloss.NewStyle().
 		Foreground(themes.TokyoNight.Success).
 		Bold(true).
 		Padding(1, 3).
 		Align(lipgloss.Center).
-		Render("✓ Time entry submitted successfully!")
+		Render(message)
 
-	helpText := helpStyle.Render("Press any key to return to issue view...")
+	helpText := helpStyle.Render(help)
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -467,12 +703,142 @@ func (v *TimeEntryView) renderErrorState(title string, issue *domain.Issue, widt
 	)
 }
 
+// renderQueuedState renders the notice that the time entry could not be sent and waits in the outbox.
+func (v *TimeEntryView) renderQueuedState(title string, issue *domain.Issue) string {
+	issueInfo := fieldLabelStyle.Render(fmt.Sprintf("Issue: #%d %s", issue.ID(), issue.Title()))
+
+	queuedMessage := lipgloss.NewStyle().
+		Foreground(themes.TokyoNight.Warning).
+		Bold(true).
+		Padding(1, 3).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(themes.TokyoNight.Warning).
+		Render("⧗ The time entry could not be sent and has been queued in the outbox.\n" +
+			"It is sent automatically once Redmine is reachable (alt+o: outbox).\n\n" +
+			v.errorMessage)
+
+	helpText := helpStyle.Render("Press any key to return to issue view...")
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		issueInfo,
+		"",
+		"",
+		"",
+		queuedMessage,
+		"",
+		"",
+		helpText,
+	)
+}
+
 // SetIssue updates the current issue context for the time entry view.
 // SetIssue allows changing the issue that time will be logged against.
 func (v *TimeEntryView) SetIssue(issue *domain.Issue) {
 	v.issue = issue
 }
 
+// SetDate preselects the date the time entry is logged for.
+// SetDate is used to prefill the form when the date is known upfront, e.g. from the timesheet.
+func (v *TimeEntryView) SetDate(date time.Time) {
+	v.datePicker.SetDate(date)
+}
+
+// SetHours preselects the hours of the time entry.
+// SetHours is used to prefill the form with the time measured by a stopped timer.
+func (v *TimeEntryView) SetHours(hours float64) {
+	v.hoursSelector.SetHours(hours)
+}
+
+// SetTimer makes the view log the time measured by the stopped timer t.
+// The timer is forgotten once the time entry has been saved or queued, or the user discards it with ctrl+d.
+// Leaving the form keeps the timer, so its time can be logged later.
+func (v *TimeEntryView) SetTimer(t *timer.Timer) {
+	v.timer = t
+}
+
+// EditEntry switches the view into edit mode for an existing time entry.
+// EditEntry prefills date, hours, activity and comment from the entry, submitting then updates it via updater.
+func (v *TimeEntryView) EditEntry(entry models.TimeEntry, updater domain.TimeEntryUpdater) {
+	v.entry = &entry
+	v.timeLogUpdater = updater
+
+	if spentOn, err := time.ParseInLocation("2006-01-02", entry.SpentOn, time.Local); err == nil {
+		v.datePicker.SetDate(spentOn)
+	}
+	v.hoursSelector.SetHours(entry.Hours)
+	v.descInput.SetValue(entry.Comments)
+	v.preferActivity(domain.Activity{ID: entry.Activity.ID, Name: entry.Activity.Name})
+}
+
+// SetOutbox makes the view queue new time entries in store if they cannot be sent, e.g. because Redmine is unreachable.
+// The queued entries belong to profile, the active Redmine instance.
+func (v *TimeEntryView) SetOutbox(store *outbox.Store, profile string) {
+	v.outbox = store
+	v.profile = profile
+}
+
+// EditQueued switches the view into edit mode for a time entry waiting in the outbox set by SetOutbox.
+// EditQueued prefills the form from the entry, submitting then changes the entry in the outbox instead of sending it.
+func (v *TimeEntryView) EditQueued(entry outbox.Entry) {
+	v.queued = &entry
+
+	if spentOn, err := time.ParseInLocation("2006-01-02", entry.Params.SpentOn, time.Local); err == nil {
+		v.datePicker.SetDate(spentOn)
+	}
+	v.hoursSelector.SetHours(entry.Params.Hours)
+	v.descInput.SetValue(entry.Params.Comments)
+	v.preferActivity(domain.Activity{ID: entry.Params.ActivityID})
+}
+
+// preferActivity selects the activity of an edited entry, now if the activities are loaded or once they are.
+func (v *TimeEntryView) preferActivity(activity domain.Activity) {
+	if activity.ID == 0 {
+		return
+	}
+	v.preferredActivity = &activity
+	if v.activities != nil {
+		v.setActivities(v.activities, 0)
+	}
+}
+
+// EditsEntry reports whether the view edits an existing time entry instead of creating a new one.
+func (v *TimeEntryView) EditsEntry() bool {
+	return v.entry != nil
+}
+
+// IsEditing reports whether the view needs the esc key, which it does while a time entry is being submitted.
+// Leaving the view then would hide whether the time entry has been saved, the submission can be cancelled with c.
+func (v *TimeEntryView) IsEditing() bool {
+	return v.state == StateSubmitting
+}
+
+// Outcome summarizes the result of the last submission in a single line, empty while nothing has been saved.
+// Outcome tells the user how a submission ended that completed after they left the view.
+func (v *TimeEntryView) Outcome() string {
+	switch v.state {
+	case StateCompleted:
+		return fmt.Sprintf("✓ Time entry for #%d saved", v.issue.ID())
+	case StateQueued:
+		return fmt.Sprintf("⧗ Time entry for #%d queued in the outbox", v.issue.ID())
+	case StateError:
+		return fmt.Sprintf("✗ Time entry for #%d failed: %s", v.issue.ID(), v.errorMessage)
+	}
+	return ""
+}
+
+// returnCommand returns the command navigating back once the time entry has been saved.
+func (v *TimeEntryView) returnCommand() tea.Cmd {
+	if v.queued != nil {
+		return func() tea.Msg { return messages.ReturnToOutboxMsg{} }
+	}
+	if v.EditsEntry() {
+		return func() tea.Msg { return messages.ReturnToTimeEntriesMsg{} }
+	}
+	return func() tea.Msg { return messages.ReturnToIssueMsg{} }
+}
+
 // SetSize updates the dimensions of the time entry view and adjusts child components accordingly.
 // SetSize resizes the view and updates the description input field width to match.
 func (v *TimeEntryView) SetSize(width, height int) {
@@ -493,6 +859,23 @@ func (v *TimeEntryView) GetErrorMessage() string {
 	return v.errorMessage
 }
 
+// Cancel aborts loading the activities, it is a no-op when they are not being loaded.
+// A submission in flight is not aborted when the user leaves the view, its result is delivered to the view nevertheless.
+func (v *TimeEntryView) Cancel() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// stopSubmission aborts the submission in flight, if any, and releases its context.
+func (v *TimeEntryView) stopSubmission() {
+	if v.cancelSubmission != nil {
+		v.cancelSubmission()
+		v.cancelSubmission = nil
+	}
+}
+
 // Reset resets the time entry view to its initial state, clearing all form data and errors.
 // Reset restores default values, clears error messages, and returns focus to the first field.
 func (v *TimeEntryView) Reset() {
@@ -504,7 +887,7 @@ func (v *TimeEntryView) Reset() {
 
 // renderActivitySelection creates the visual representation of available activities with selection highlighting.
 // renderActivitySelection displays the list of activities and highlights the currently selected one.
-func (v *TimeEntryView) renderActivitySelection(activities []Activity) string {
+func (v *TimeEntryView) renderActivitySelection(activities []domain.Activity) string {
 	if len(activities) == 0 {
 		return fieldLabelStyle.Render("Activity: ") + "No activities available"
 	}
@@ -536,6 +919,20 @@ func (v *TimeEntryView) renderActivitySelection(activities []Activity) string {
 	return content.String()
 }
 
+// renderActivityError renders the reason the activities could not be loaded and how to try again.
+func (v *TimeEntryView) renderActivityError() string {
+	message := lipgloss.NewStyle().
+		Foreground(themes.TokyoNight.Error).
+		Padding(0, 1).
+		Render("⚠ Failed to load activities: " + v.activitiesErr.Error())
+
+	content := fieldLabelStyle.Render("Activity:") + "\n" + message
+	if v.focusIndex == ActivityIndex {
+		content += "\n\n" + helpStyle.Render("Press Enter to load the activities again")
+	}
+	return content
+}
+
 // renderInput renders a labeled input field for the TimeEntryView.
 // If the label is "Description:", it displays a multi-line, textarea-like input with word wrapping,
 // a visible cursor when focused, and a character count indicator. For other fields, it renders a
@@ -648,6 +1045,12 @@ func (v *TimeEntryView) renderHoursSelector() string {
 // renderSubmitButton handles the visual representation of the form submission button.
 func (v *TimeEntryView) renderSubmitButton() string {
 	buttonText := "Submit Time Entry"
+	switch {
+	case v.queued != nil:
+		buttonText = "Update Queued Entry"
+	case v.EditsEntry():
+		buttonText = "Update Time Entry"
+	}
 	focused := v.focusIndex == SubmitIndex
 
 	var buttonStyle lipgloss.Style
@@ -711,13 +1114,29 @@ func (v *TimeEntryView) handleActivitySelection(direction string) {
 }
 
 // TimeEntrySubmissionSuccess represents a successful time entry submission event.
-type TimeEntrySubmissionSuccess struct{}
+// View is the view that sent the time entry, the result belongs to it even if the user has moved on.
+type TimeEntrySubmissionSuccess struct {
+	View *TimeEntryView
+}
 
 // TimeEntrySubmissionError represents a failed time entry submission event with error details.
+// View is the view that sent the time entry, the result belongs to it even if the user has moved on.
 type TimeEntrySubmissionError struct {
+	View  *TimeEntryView
 	Error error
 }
 
+// submissionErrorMessage formats a submission error for display.
+// submissionErrorMessage lists the validation messages if Redmine rejected the time entry.
+func submissionErrorMessage(err error) string {
+	var apiErr *redmine.APIError
+	if errors.As(err, &apiErr) && redmine.IsValidation(err) && len(apiErr.Messages) > 0 {
+		return "Redmine rejected the time entry:\n• " + strings.Join(apiErr.Messages, "\n• ")
+	}
+
+	return err.Error()
+}
+
 // HasValidEntry validates that all required fields are properly filled for submission.
 // HasValidEntry checks hours, description, and activity selection to ensure form completeness.
 func (v *TimeEntryView) HasValidEntry() bool {
@@ -1,17 +1,30 @@
 package views
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/b1tray3r/rmt/internal/outbox"
+	"github.com/b1tray3r/rmt/internal/redmine"
 	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/timer"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Mock implementations for testing
 
 // mockIssueRepository implements domain.IssueRepository for testing
 type mockIssueRepository struct {
-	activities map[int]string
+	activities []domain.Activity
+	recent     int
 	err        error
 }
 
@@ -21,12 +34,12 @@ func (m *mockIssueRepository) GetBaseURL() string {
 }
 
 // GetIssue returns a mock issue
-func (m *mockIssueRepository) GetIssue(id int) (*domain.Issue, error) {
+func (m *mockIssueRepository) GetIssue(ctx context.Context, id int) (*domain.Issue, error) {
 	return createTestIssue(), nil
 }
 
 // GetProjectActivities returns mock activities or an error
-func (m *mockIssueRepository) GetProjectActivities(projectID int, activityPatterns []string) (map[int]string, error) {
+func (m *mockIssueRepository) GetProjectActivities(ctx context.Context, projectID int, activityPatterns []string) ([]domain.Activity, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -34,25 +47,30 @@ func (m *mockIssueRepository) GetProjectActivities(projectID int, activityPatter
 		return m.activities, nil
 	}
 	// Default activities
-	return map[int]string{
-		1: "Development",
-		2: "Testing",
-		3: "Documentation",
+	return []domain.Activity{
+		{ID: 1, Name: "Development"},
+		{ID: 2, Name: "Testing"},
+		{ID: 3, Name: "Documentation"},
 	}, nil
 }
 
+// RecentActivity returns the configured activity used last
+func (m *mockIssueRepository) RecentActivity(ctx context.Context, issueID, projectID int) (int, error) {
+	return m.recent, nil
+}
+
 // Search is a mock implementation - not used in TimeEntryView tests
-func (m *mockIssueRepository) Search(query string) ([]*domain.Issue, error) {
+func (m *mockIssueRepository) Search(ctx context.Context, query string) ([]*domain.Issue, error) {
 	return nil, nil
 }
 
 // SearchWithFilter searches issues using filters (mock implementation)
-func (m *mockIssueRepository) SearchWithFilter(query string) ([]*domain.Issue, error) {
+func (m *mockIssueRepository) SearchWithFilter(ctx context.Context, query string) ([]*domain.Issue, error) {
 	return nil, nil
 }
 
 // CreateTimeEntry creates a mock time entry or returns an error
-func (m *mockIssueRepository) CreateTimeEntry(params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+func (m *mockIssueRepository) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -81,6 +99,38 @@ func createTestIssue() *domain.Issue {
 	return domain.NewIssue(123, "http://example.com/issues/123", "test.user", "Test Issue", "Test Description", project)
 }
 
+// initTimeEntryView runs Init and feeds the loaded activities into the view
+func initTimeEntryView(v *TimeEntryView) {
+	runActivities(v, v.Init())
+}
+
+// runActivities executes the possibly nested batched command and feeds the loaded activities into the view
+func runActivities(v *TimeEntryView, cmd tea.Cmd) {
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runActivities(v, c)
+		}
+	case ActivitiesLoadedMsg:
+		v.Update(msg)
+	}
+}
+
+// runSubmission executes the batched submission command, feeds its result into the view and returns the view's follow-up command
+func runSubmission(v *TimeEntryView, cmd tea.Cmd) tea.Cmd {
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		return nil
+	}
+	for _, c := range batch {
+		switch msg := c().(type) {
+		case TimeEntrySubmissionSuccess, TimeEntrySubmissionError:
+			return v.Update(msg)
+		}
+	}
+	return nil
+}
+
 // Test NewTimeEntryView
 
 // TestNewTimeEntryView_Success verifies that NewTimeEntryView creates a valid instance with proper initialization
@@ -96,6 +146,7 @@ func TestNewTimeEntryView_Success(t *testing.T) {
 	if view == nil {
 		t.Fatal("NewTimeEntryView returned nil view")
 	}
+	initTimeEntryView(view)
 	if view.width != 80 {
 		t.Errorf("width = %d, want 80", view.width)
 	}
@@ -115,3 +166,382 @@ func TestNewTimeEntryView_Success(t *testing.T) {
 		t.Errorf("initial focus = %v, want DateIndex", view.focusIndex)
 	}
 }
+
+// TestTimeEntryView_ValidationError verifies that Redmine's validation messages are shown in the error state
+func TestTimeEntryView_ValidationError(t *testing.T) {
+	issue := createTestIssue()
+	repo := &mockIssueRepository{}
+
+	view, err := NewTimeEntryView(80, 24, nil, issue, repo, repo)
+	if err != nil {
+		t.Fatalf("NewTimeEntryView returned error: %v", err)
+	}
+	initTimeEntryView(view)
+
+	repo.err = &redmine.APIError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Method:     http.MethodPost,
+		Endpoint:   "/time_entries.json",
+		Messages:   []string{"Activity cannot be blank", "Comment is too long"},
+	}
+
+	view.selectedActivity = &view.activities[0]
+	view.descInput.SetValue("Worked on it")
+	view.focusIndex = SubmitIndex
+	runSubmission(view, view.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+
+	if view.state != StateError {
+		t.Fatalf("state = %v, want StateError", view.state)
+	}
+	for _, want := range []string{"Activity cannot be blank", "Comment is too long"} {
+		if !strings.Contains(view.GetErrorMessage(), want) {
+			t.Errorf("error message %q does not contain %q", view.GetErrorMessage(), want)
+		}
+	}
+	if strings.Contains(view.GetErrorMessage(), "status: 422") {
+		t.Errorf("error message %q should not contain the raw status", view.GetErrorMessage())
+	}
+}
+
+// TestTimeEntryView_LoadActivities verifies that activities load in the background and the default or recent activity is preselected
+func TestTimeEntryView_LoadActivities(t *testing.T) {
+	issue := createTestIssue()
+	repo := &mockIssueRepository{recent: 2}
+
+	view, err := NewTimeEntryView(80, 24, nil, issue, repo, repo)
+	if err != nil {
+		t.Fatalf("NewTimeEntryView returned error: %v", err)
+	}
+	cmd := view.Init()
+	if !strings.Contains(view.Render(), "Loading activities") {
+		t.Errorf("Render() does not show the activities loading:\n%s", view.Render())
+	}
+
+	// Submitting waits for the activities instead of logging time without one
+	view.descInput.SetValue("Worked on it")
+	view.focusIndex = SubmitIndex
+	view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view.state != StateEditing || !strings.Contains(view.GetErrorMessage(), "wait") {
+		t.Fatalf("state = %v, error = %q, want the submission to wait for the activities", view.state, view.GetErrorMessage())
+	}
+
+	runActivities(view, cmd)
+	if view.selectedActivity == nil || view.selectedActivity.ID != 2 {
+		t.Errorf("selected activity = %+v, want the recent activity 2", view.selectedActivity)
+	}
+
+	repo.activities = []domain.Activity{{ID: 1, Name: "Development"}, {ID: 3, Name: "Documentation", IsDefault: true}}
+	initTimeEntryView(view)
+	if view.selectedActivity == nil || view.selectedActivity.ID != 3 {
+		t.Errorf("selected activity = %+v, want the default activity 3", view.selectedActivity)
+	}
+
+	repo.err = errors.New("connection refused")
+	initTimeEntryView(view)
+	if !strings.Contains(view.Render(), "Failed to load activities") {
+		t.Errorf("Render() does not show the failure:\n%s", view.Render())
+	}
+
+	repo.err = nil
+	view.focusIndex = ActivityIndex
+	if cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || !view.loadingActivities {
+		t.Error("expected enter on the activity field to load the activities again")
+	}
+}
+
+// stubTimeEntryCreator implements domain.TimeEntryCreator for testing, optionally blocking until the request is cancelled
+type stubTimeEntryCreator struct {
+	block bool
+	calls int
+}
+
+// CreateTimeEntry counts the call and waits for the cancellation if block is set
+func (s *stubTimeEntryCreator) CreateTimeEntry(ctx context.Context, params models.CreateTimeEntryParams) (*models.TimeEntry, error) {
+	s.calls++
+	if s.block {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return nil, errors.New("the request was not cancelled")
+		}
+	}
+	return &models.TimeEntry{ID: 1}, nil
+}
+
+// newSubmittableTimeEntryView creates a view with loaded activities and a filled in form, focused on the submit button
+func newSubmittableTimeEntryView(t *testing.T, creator domain.TimeEntryCreator) *TimeEntryView {
+	t.Helper()
+	view, err := NewTimeEntryView(80, 24, nil, createTestIssue(), &mockIssueRepository{}, creator)
+	if err != nil {
+		t.Fatalf("NewTimeEntryView returned error: %v", err)
+	}
+	initTimeEntryView(view)
+	view.descInput.SetValue("Worked on it")
+	view.focusIndex = SubmitIndex
+	return view
+}
+
+// TestTimeEntryView_SubmitAsync verifies that the time entry is sent by the returned command and only once
+func TestTimeEntryView_SubmitAsync(t *testing.T) {
+	creator := &stubTimeEntryCreator{}
+	view := newSubmittableTimeEntryView(t, creator)
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command sending the time entry, got nil")
+	}
+	if view.state != StateSubmitting || creator.calls != 0 {
+		t.Fatalf("state = %v, calls = %d, want the submission to wait for the command", view.state, creator.calls)
+	}
+	if !strings.Contains(view.Render(), "Submitting time entry") {
+		t.Errorf("Render() does not show the submission:\n%s", view.Render())
+	}
+
+	// Pressing enter again while submitting does not send the time entry twice
+	if again := view.Update(tea.KeyMsg{Type: tea.KeyEnter}); again != nil {
+		t.Error("expected no command while submitting")
+	}
+
+	runSubmission(view, cmd)
+	if creator.calls != 1 || view.state != StateCompleted {
+		t.Errorf("state = %v, calls = %d, want a single completed submission", view.state, creator.calls)
+	}
+}
+
+// TestTimeEntryView_LogTimer verifies that the stopped timer is only forgotten once it is logged or discarded
+func TestTimeEntryView_LogTimer(t *testing.T) {
+	stopped := &timer.Timer{IssueID: 123, StartedAt: time.Now().Add(-time.Hour), StoppedAt: time.Now()}
+
+	view := newSubmittableTimeEntryView(t, &stubTimeEntryCreator{})
+	view.SetTimer(stopped)
+	cmd := runSubmission(view, view.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if cmd == nil {
+		t.Fatal("expected a command forgetting the timer after the submission, got nil")
+	}
+	if msg, ok := cmd().(messages.TimerLoggedMsg); !ok || msg.Timer.IssueID != 123 {
+		t.Errorf("command returned %T, want TimerLoggedMsg for issue 123", msg)
+	}
+
+	view = newSubmittableTimeEntryView(t, &stubTimeEntryCreator{})
+	view.SetTimer(stopped)
+	batch, ok := view.Update(tea.KeyMsg{Type: tea.KeyCtrlD})().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected ctrl+d to return a batch of commands")
+	}
+	var logged, returned bool
+	for _, c := range batch {
+		switch c().(type) {
+		case messages.TimerLoggedMsg:
+			logged = true
+		case messages.ReturnToIssueMsg:
+			returned = true
+		}
+	}
+	if !logged || !returned {
+		t.Errorf("ctrl+d logged = %v, returned = %v, want the timer discarded and the form left", logged, returned)
+	}
+}
+
+// TestTimeEntryView_CancelSubmission verifies that c cancels the submission in flight and keeps the form
+func TestTimeEntryView_CancelSubmission(t *testing.T) {
+	creator := &stubTimeEntryCreator{block: true}
+	view := newSubmittableTimeEntryView(t, creator)
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !strings.Contains(view.Render(), "Cancelling") {
+		t.Errorf("Render() does not show the cancellation:\n%s", view.Render())
+	}
+
+	runSubmission(view, cmd)
+	if view.state != StateEditing || !strings.Contains(view.GetErrorMessage(), "cancelled") {
+		t.Fatalf("state = %v, error = %q, want the form back with a notice", view.state, view.GetErrorMessage())
+	}
+	if view.descInput.Value() != "Worked on it" {
+		t.Errorf("description = %q, want the form to keep its values", view.descInput.Value())
+	}
+}
+
+// TestTimeEntryView_LeaveWhileSubmitting verifies that esc is held back and leaving the view does not abort the submission
+func TestTimeEntryView_LeaveWhileSubmitting(t *testing.T) {
+	creator := &stubTimeEntryCreator{}
+	view := newSubmittableTimeEntryView(t, creator)
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !view.IsEditing() {
+		t.Fatal("IsEditing() = false while submitting, want the view to keep the esc key")
+	}
+	if view.Update(tea.KeyMsg{Type: tea.KeyEsc}); view.state != StateSubmitting {
+		t.Errorf("state = %v after esc, want the submission to go on", view.state)
+	}
+
+	// Switching to another view cancels it, which must not abort the submission
+	view.Cancel()
+	batch, _ := cmd().(tea.BatchMsg)
+	for _, c := range batch {
+		if msg, ok := c().(TimeEntrySubmissionSuccess); ok {
+			if msg.View != view {
+				t.Fatal("expected the result to name the view that sent the time entry")
+			}
+			msg.View.Update(msg)
+		}
+	}
+	if creator.calls != 1 || view.state != StateCompleted {
+		t.Fatalf("state = %v, calls = %d, want the time entry saved", view.state, creator.calls)
+	}
+	if !strings.Contains(view.Outcome(), "#123 saved") {
+		t.Errorf("Outcome() = %q, want the saved time entry", view.Outcome())
+	}
+}
+
+// mockTimeEntryUpdater implements domain.TimeEntryUpdater for testing
+type mockTimeEntryUpdater struct {
+	id     int
+	params models.UpdateTimeEntryParams
+	calls  int
+}
+
+// UpdateTimeEntry records the update
+func (m *mockTimeEntryUpdater) UpdateTimeEntry(ctx context.Context, id int, params models.UpdateTimeEntryParams) error {
+	m.id = id
+	m.params = params
+	m.calls++
+	return nil
+}
+
+// TestTimeEntryView_EditEntry verifies that edit mode prefills the form and updates instead of creating
+func TestTimeEntryView_EditEntry(t *testing.T) {
+	issue := createTestIssue()
+	repo := &mockIssueRepository{}
+	updater := &mockTimeEntryUpdater{}
+
+	view, err := NewTimeEntryView(80, 24, nil, issue, repo, repo)
+	if err != nil {
+		t.Fatalf("NewTimeEntryView returned error: %v", err)
+	}
+	initTimeEntryView(view)
+
+	var entry models.TimeEntry
+	entry.ID = 42
+	entry.Hours = 9.5
+	entry.Comments = "Code review"
+	entry.SpentOn = "2025-08-12"
+	entry.Activity.ID = 77
+	entry.Activity.Name = "Review"
+
+	view.EditEntry(entry, updater)
+
+	if !view.EditsEntry() {
+		t.Fatal("EditsEntry() = false, want true")
+	}
+	if got := view.datePicker.SelectedDate().Format("2006-01-02"); got != "2025-08-12" {
+		t.Errorf("selected date = %s, want 2025-08-12", got)
+	}
+	if got := view.hoursSelector.SelectedHours(); got != 9.5 {
+		t.Errorf("selected hours = %v, want 9.5", got)
+	}
+	if got := view.descInput.Value(); got != "Code review" {
+		t.Errorf("description = %q, want %q", got, "Code review")
+	}
+	if view.selectedActivity == nil || view.selectedActivity.ID != 77 {
+		t.Errorf("selected activity = %+v, want ID 77", view.selectedActivity)
+	}
+
+	view.focusIndex = SubmitIndex
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command after submitting, got nil")
+	}
+	runSubmission(view, cmd)
+
+	if updater.calls != 1 || updater.id != 42 {
+		t.Fatalf("expected one update of entry 42, got %d calls for entry %d", updater.calls, updater.id)
+	}
+	if updater.params.Hours != 9.5 || updater.params.ActivityID != 77 || updater.params.SpentOn != "2025-08-12" {
+		t.Errorf("unexpected update params: %+v", updater.params)
+	}
+	if view.state != StateCompleted {
+		t.Fatalf("state = %v, want StateCompleted", view.state)
+	}
+
+	back := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := back().(messages.ReturnToTimeEntriesMsg); !ok {
+		t.Errorf("expected ReturnToTimeEntriesMsg after completing an edit, got %T", back())
+	}
+}
+
+// TestTimeEntryView_QueueWhenUnreachable verifies that a time entry that cannot be sent is queued in the outbox
+func TestTimeEntryView_QueueWhenUnreachable(t *testing.T) {
+	issue := createTestIssue()
+	repo := &mockIssueRepository{}
+	store := outbox.NewStore(filepath.Join(t.TempDir(), "outbox.json"))
+
+	view, err := NewTimeEntryView(80, 24, nil, issue, repo, repo)
+	if err != nil {
+		t.Fatalf("NewTimeEntryView returned error: %v", err)
+	}
+	initTimeEntryView(view)
+	view.SetOutbox(store, "work")
+
+	repo.err = &url.Error{Op: "Post", URL: "http://example.com/time_entries.json", Err: errors.New("connection refused")}
+	view.selectedActivity = &view.activities[0]
+	view.descInput.SetValue("Worked on it")
+	view.focusIndex = SubmitIndex
+	cmd := runSubmission(view, view.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+
+	if view.state != StateQueued {
+		t.Fatalf("state = %v, want StateQueued (error: %s)", view.state, view.GetErrorMessage())
+	}
+	if cmd == nil {
+		t.Fatal("expected a command announcing the queued entry, got nil")
+	}
+	if _, ok := cmd().(messages.OutboxChangedMsg); !ok {
+		t.Errorf("expected OutboxChangedMsg, got %T", cmd())
+	}
+
+	entries, err := store.List("work")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Params.IssueID != 123 || entries[0].Params.Comments != "Worked on it" || entries[0].Attempts != 1 || len(entries[0].Attempted) != 1 {
+		t.Fatalf("List() = %+v, want the entry with its failed attempt", entries)
+	}
+	if !strings.Contains(view.Render(), "queued in the outbox") {
+		t.Errorf("Render() does not mention the outbox:\n%s", view.Render())
+	}
+
+	// Submitting the same entry again does not queue it twice
+	view.Reset()
+	view.descInput.SetValue("Worked on it")
+	view.focusIndex = SubmitIndex
+	runSubmission(view, view.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if view.state != StateError || !strings.Contains(view.GetErrorMessage(), "already queued") {
+		t.Errorf("state = %v, error = %q, want the duplicate to be refused", view.state, view.GetErrorMessage())
+	}
+	if entries, _ := store.List("work"); len(entries) != 1 {
+		t.Errorf("List() = %+v, want a single queued entry", entries)
+	}
+}
+
+// TestQuarterHours verifies that durations are rounded to the quarter hours offered by the hours selector
+func TestQuarterHours(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     float64
+	}{
+		{0, 0.25},
+		{5 * time.Minute, 0.25},
+		{22 * time.Minute, 0.25},
+		{23 * time.Minute, 0.5},
+		{time.Hour + 7*time.Minute, 1},
+		{time.Hour + 8*time.Minute, 1.25},
+		{9*time.Hour + 50*time.Minute, 9.75},
+	}
+
+	for _, tt := range tests {
+		if got := QuarterHours(tt.duration); got != tt.want {
+			t.Errorf("QuarterHours(%v) = %v, want %v", tt.duration, got, tt.want)
+		}
+	}
+}
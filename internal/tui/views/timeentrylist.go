@@ -0,0 +1,323 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TimeEntrySource provides the data shown and changed by the TimeEntryListView.
+type TimeEntrySource interface {
+	domain.TimeEntryLister
+	domain.TimeEntryDeleter
+	domain.IssuesGetter
+}
+
+// TimeEntriesLoadedMsg is sent when the time entries of a TimeEntryListView have been loaded.
+type TimeEntriesLoadedMsg struct {
+	Entries []models.TimeEntry
+	Issues  map[int]*domain.Issue
+	Error   error
+}
+
+// TimeEntryDeletedMsg is sent when deleting a time entry has finished.
+type TimeEntryDeletedMsg struct {
+	ID    int
+	Error error
+}
+
+// TimeEntryListView lists the current user's time entries for an issue or a day.
+// TimeEntryListView opens the selected entry for editing and deletes entries after confirmation.
+type TimeEntryListView struct {
+	width, height int
+
+	title   string
+	filter  models.TimeEntryFilter
+	source  TimeEntrySource
+	cancel  context.CancelFunc
+	spinner spinner.Model
+
+	entries []models.TimeEntry
+	issues  map[int]*domain.Issue
+	cursor  int
+
+	loading    bool
+	confirming bool
+	err        error
+}
+
+// NewTimeEntryListView creates a view listing the time entries matching filter.
+func NewTimeEntryListView(width, height int, title string, filter models.TimeEntryFilter, source TimeEntrySource) *TimeEntryListView {
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
+
+	return &TimeEntryListView{
+		width:   width,
+		height:  height,
+		title:   title,
+		filter:  filter,
+		source:  source,
+		spinner: s,
+	}
+}
+
+// Init loads the time entries.
+func (v *TimeEntryListView) Init() tea.Cmd {
+	return v.Reload()
+}
+
+// Reload fetches the time entries again, e.g. after one of them has been edited.
+func (v *TimeEntryListView) Reload() tea.Cmd {
+	v.Cancel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.loading = true
+	v.confirming = false
+	v.err = nil
+
+	filter := v.filter
+	source := v.source
+	load := func() tea.Msg {
+		entries, err := source.ListTimeEntries(ctx, filter)
+		if err != nil {
+			return TimeEntriesLoadedMsg{Error: err}
+		}
+
+		var ids []int
+		seen := make(map[int]bool)
+		for _, entry := range entries {
+			if entry.Issue.ID != 0 && !seen[entry.Issue.ID] {
+				seen[entry.Issue.ID] = true
+				ids = append(ids, entry.Issue.ID)
+			}
+		}
+
+		// Entries whose issue cannot be resolved are still listed, but cannot be edited
+		issues := make(map[int]*domain.Issue)
+		if len(ids) > 0 {
+			if resolved, err := source.GetIssues(ctx, ids); err == nil {
+				for _, issue := range resolved {
+					issues[issue.ID()] = issue
+				}
+			}
+		}
+
+		return TimeEntriesLoadedMsg{Entries: entries, Issues: issues}
+	}
+
+	return tea.Batch(v.spinner.Tick, load)
+}
+
+// Cancel aborts loading or deleting time entries.
+func (v *TimeEntryListView) Cancel() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// Update handles navigation, editing and deletion of time entries.
+func (v *TimeEntryListView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case TimeEntriesLoadedMsg:
+		v.loading = false
+		v.cancel = nil
+		v.err = msg.Error
+		if msg.Error == nil {
+			v.entries = msg.Entries
+			v.issues = msg.Issues
+			v.cursor = min(v.cursor, max(len(v.entries)-1, 0))
+		}
+		return nil
+
+	case TimeEntryDeletedMsg:
+		v.cancel = nil
+		if msg.Error != nil {
+			v.loading = false
+			v.err = msg.Error
+			return nil
+		}
+		return v.Reload()
+
+	case spinner.TickMsg:
+		if !v.loading {
+			return nil
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
+
+	case tea.KeyMsg:
+		if v.loading {
+			return nil
+		}
+
+		if v.confirming {
+			v.confirming = false
+			if msg.String() == "y" {
+				return v.deleteSelected()
+			}
+			return nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			v.cursor = max(v.cursor-1, 0)
+		case "down", "j":
+			v.cursor = min(v.cursor+1, max(len(v.entries)-1, 0))
+		case "d":
+			if v.selectedEntry() != nil {
+				v.confirming = true
+			}
+		case "r":
+			return v.Reload()
+		case "enter":
+			return v.editSelected()
+		}
+	}
+
+	return nil
+}
+
+// selectedEntry returns the time entry under the cursor or nil if the list is empty.
+func (v *TimeEntryListView) selectedEntry() *models.TimeEntry {
+	if v.cursor >= len(v.entries) {
+		return nil
+	}
+	return &v.entries[v.cursor]
+}
+
+// editSelected opens the selected time entry in the time entry form.
+// editSelected ignores entries booked on a project only, as the form requires an issue.
+func (v *TimeEntryListView) editSelected() tea.Cmd {
+	entry := v.selectedEntry()
+	if entry == nil {
+		return nil
+	}
+
+	issue, ok := v.issues[entry.Issue.ID]
+	if !ok {
+		v.err = fmt.Errorf("time entry #%d is not booked on an issue that could be loaded", entry.ID)
+		return nil
+	}
+
+	selected := *entry
+	return func() tea.Msg {
+		return messages.TimeEntryEditMsg{Issue: issue, Entry: selected}
+	}
+}
+
+// deleteSelected returns a command deleting the selected time entry.
+func (v *TimeEntryListView) deleteSelected() tea.Cmd {
+	entry := v.selectedEntry()
+	if entry == nil {
+		return nil
+	}
+
+	v.Cancel()
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.loading = true
+	v.err = nil
+
+	id := entry.ID
+	source := v.source
+	return tea.Batch(v.spinner.Tick, func() tea.Msg {
+		return TimeEntryDeletedMsg{ID: id, Error: source.DeleteTimeEntry(ctx, id)}
+	})
+}
+
+// SetSize sets the dimensions of the TimeEntryListView.
+func (v *TimeEntryListView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// Render renders the list of time entries and the delete confirmation.
+func (v *TimeEntryListView) Render() string {
+	title := titleStyle.Render("TIME ENTRIES " + v.title)
+
+	var body string
+	switch {
+	case v.loading:
+		body = loadingStyle.Render(v.spinner.View() + " Loading time entries...")
+	case len(v.entries) == 0:
+		body = emptyMessageStyle.Render("No time entries found")
+	default:
+		body = v.renderEntries()
+	}
+
+	sections := []string{title, body}
+
+	if v.err != nil {
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Bold(true).
+			Padding(0, 1).
+			Render("⚠ "+submissionErrorMessage(v.err)))
+	}
+
+	if v.confirming {
+		entry := v.selectedEntry()
+		prompt := fmt.Sprintf("Delete %.2fh on %s (%s)? y: delete • any other key: keep", entry.Hours, v.entryLabel(*entry), entry.SpentOn)
+		sections = append(sections, "", lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Warning).
+			Bold(true).
+			Padding(0, 1).
+			Render(prompt))
+	}
+
+	helpText := helpStyle.Render("↑/↓: select • enter: edit • d: delete • r: reload • esc: back")
+	sections = append(sections, "", helpText)
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderEntries renders one line per time entry with its comment below.
+func (v *TimeEntryListView) renderEntries() string {
+	var lines []string
+	for i, entry := range v.entries {
+		prefix := "  "
+		style := fieldValueStyle
+		if i == v.cursor {
+			prefix = "> "
+			style = focusedStyle
+		}
+
+		date := entry.SpentOn
+		if spentOn, err := time.Parse("2006-01-02", entry.SpentOn); err == nil {
+			date = spentOn.Format("Mon 02.01.")
+		}
+
+		line := fmt.Sprintf("%s  %5.2fh  %-16s  %s", date, entry.Hours, truncate(entry.Activity.Name, 16), v.entryLabel(entry))
+		lines = append(lines, prefix+style.Render(truncate(line, max(v.width-8, 20))))
+
+		if comment := strings.TrimSpace(entry.Comments); comment != "" {
+			lines = append(lines, "    "+helpStyle.Render(truncate(comment, max(v.width-12, 20))))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// entryLabel describes what a time entry is booked on.
+func (v *TimeEntryListView) entryLabel(entry models.TimeEntry) string {
+	if issue, ok := v.issues[entry.Issue.ID]; ok {
+		return fmt.Sprintf("#%d %s", issue.ID(), issue.Title())
+	}
+	if entry.Issue.ID != 0 {
+		return fmt.Sprintf("#%d", entry.Issue.ID)
+	}
+	return entry.Project.Name
+}
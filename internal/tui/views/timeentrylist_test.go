@@ -0,0 +1,111 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mockTimeEntrySource implements TimeEntrySource for testing
+type mockTimeEntrySource struct {
+	mockTimesheetSource
+	deleted []int
+}
+
+// DeleteTimeEntry records the deleted entry
+func (m *mockTimeEntrySource) DeleteTimeEntry(ctx context.Context, id int) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+// newTestTimeEntryListView creates a loaded list view with two time entries on issue 123
+func newTestTimeEntryListView() (*TimeEntryListView, *mockTimeEntrySource) {
+	var first, second models.TimeEntry
+	first.ID, first.Hours, first.SpentOn = 1, 1.5, "2025-08-11"
+	first.Issue.ID = 123
+	second.ID, second.Hours, second.SpentOn = 2, 0.5, "2025-08-11"
+	second.Issue.ID = 123
+
+	source := &mockTimeEntrySource{}
+	source.entries = []models.TimeEntry{first, second}
+
+	view := NewTimeEntryListView(100, 40, "#123", models.TimeEntryFilter{UserID: "me", IssueID: 123}, source)
+	runLoad(view, view.Reload())
+	return view, source
+}
+
+// runLoad executes the batched command and feeds every non-tick message back into the view
+func runLoad(v *TimeEntryListView, cmd tea.Cmd) {
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		return
+	}
+	for _, c := range batch {
+		switch msg := c().(type) {
+		case TimeEntriesLoadedMsg, TimeEntryDeletedMsg:
+			if next := v.Update(msg); next != nil {
+				runLoad(v, next)
+			}
+		}
+	}
+}
+
+// TestTimeEntryListView_Edit verifies that enter opens the selected entry for editing
+func TestTimeEntryListView_Edit(t *testing.T) {
+	view, _ := newTestTimeEntryListView()
+
+	if len(view.entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(view.entries))
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command after pressing enter, got nil")
+	}
+
+	msg, ok := cmd().(messages.TimeEntryEditMsg)
+	if !ok {
+		t.Fatalf("expected TimeEntryEditMsg, got %T", cmd())
+	}
+	if msg.Entry.ID != 2 || msg.Issue.ID() != 123 {
+		t.Errorf("unexpected edit message: entry %d, issue %d", msg.Entry.ID, msg.Issue.ID())
+	}
+}
+
+// TestTimeEntryListView_DeleteRequiresConfirmation verifies that entries are only deleted after confirming with y
+func TestTimeEntryListView_DeleteRequiresConfirmation(t *testing.T) {
+	view, source := newTestTimeEntryListView()
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !view.confirming {
+		t.Fatal("expected confirmation prompt after pressing d")
+	}
+	if cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); cmd != nil {
+		t.Error("expected no command when declining the deletion")
+	}
+	if view.confirming || len(source.deleted) != 0 {
+		t.Fatalf("expected deletion to be declined, deleted %v", source.deleted)
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected a delete command after confirming, got nil")
+	}
+	runLoad(view, cmd)
+
+	if len(source.deleted) != 1 || source.deleted[0] != 1 {
+		t.Errorf("deleted = %v, want [1]", source.deleted)
+	}
+	if len(source.filters) != 2 {
+		t.Errorf("expected the list to be reloaded after deleting, got %d loads", len(source.filters))
+	}
+}
+
+var _ TimeEntrySource = (*mockTimeEntrySource)(nil)
+var _ domain.TimeEntryDeleter = (*mockTimeEntrySource)(nil)
@@ -0,0 +1,339 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	"github.com/b1tray3r/rmt/internal/tui/themes"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timesheetCellWidth is the width of a single day or total column of the timesheet grid.
+const timesheetCellWidth = 8
+
+// TimesheetSource provides the data shown by the TimesheetView.
+type TimesheetSource interface {
+	domain.TimeEntryLister
+	domain.IssuesGetter
+}
+
+// TimesheetLoadedMsg is sent when the time entries of a week have been loaded.
+type TimesheetLoadedMsg struct {
+	Week  time.Time
+	Sheet *domain.Timesheet
+	Error error
+}
+
+// TimesheetView shows the current user's time entries of a week as a grid of issues and weekdays.
+type TimesheetView struct {
+	width, height int
+
+	source  TimesheetSource
+	cancel  context.CancelFunc
+	spinner spinner.Model
+
+	week    time.Time
+	sheet   *domain.Timesheet
+	loading bool
+	err     error
+	notice  string // notice explains why the selected cell cannot be logged against, until the next key press
+
+	row, col int
+}
+
+// NewTimesheetView creates a new TimesheetView showing the week containing today.
+func NewTimesheetView(width, height int, source TimesheetSource) *TimesheetView {
+	s := spinner.New()
+	s.Spinner = spinner.Line
+	s.Style = lipgloss.NewStyle().Foreground(themes.TokyoNight.Highlight)
+
+	now := time.Now()
+	col := int(now.Weekday()) - 1
+	if col < 0 { // Sunday
+		col = domain.DaysPerWeek - 1
+	}
+
+	return &TimesheetView{
+		width:   width,
+		height:  height,
+		source:  source,
+		spinner: s,
+		week:    domain.WeekStart(now),
+		col:     col,
+	}
+}
+
+// Init loads the time entries of the current week.
+func (v *TimesheetView) Init() tea.Cmd {
+	return tea.Batch(v.spinner.Tick, v.load())
+}
+
+// Resume loads the time entries again when the user returns, e.g. after logging time on a cell.
+func (v *TimesheetView) Resume() tea.Cmd {
+	return tea.Batch(v.spinner.Tick, v.load())
+}
+
+// Cancel aborts loading the time entries.
+func (v *TimesheetView) Cancel() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+}
+
+// load returns a command fetching the time entries of the displayed week and the issues they are booked on.
+func (v *TimesheetView) load() tea.Cmd {
+	v.Cancel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.loading = true
+	v.err = nil
+
+	week := v.week
+	source := v.source
+	return func() tea.Msg {
+		entries, err := source.ListTimeEntries(ctx, models.TimeEntryFilter{
+			UserID: "me",
+			From:   week.Format("2006-01-02"),
+			To:     week.AddDate(0, 0, domain.DaysPerWeek-1).Format("2006-01-02"),
+		})
+		if err != nil {
+			return TimesheetLoadedMsg{Week: week, Error: err}
+		}
+
+		sheet := domain.NewTimesheet(week, entries)
+
+		// Missing issue titles only degrade the labels, the hours are still correct
+		if ids := sheet.IssueIDs(); len(ids) > 0 {
+			if issues, err := source.GetIssues(ctx, ids); err == nil {
+				sheet.SetIssues(issues)
+			}
+		}
+
+		return TimesheetLoadedMsg{Week: week, Sheet: sheet}
+	}
+}
+
+// Update handles navigation within the grid and between weeks.
+func (v *TimesheetView) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case TimesheetLoadedMsg:
+		// Results for a week that is no longer displayed are dropped
+		if !msg.Week.Equal(v.week) {
+			return nil
+		}
+
+		v.loading = false
+		v.cancel = nil
+		v.err = msg.Error
+		if msg.Error == nil {
+			v.sheet = msg.Sheet
+			v.row = min(v.row, max(len(v.sheet.Rows)-1, 0))
+		}
+		return nil
+
+	case spinner.TickMsg:
+		if !v.loading {
+			return nil
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
+
+	case tea.KeyMsg:
+		v.notice = ""
+		switch msg.String() {
+		case "left", "h":
+			v.col = max(v.col-1, 0)
+		case "right", "l":
+			v.col = min(v.col+1, domain.DaysPerWeek-1)
+		case "up", "k":
+			v.row = max(v.row-1, 0)
+		case "down", "j":
+			if v.sheet != nil {
+				v.row = min(v.row+1, max(len(v.sheet.Rows)-1, 0))
+			}
+		case "p", "[":
+			return v.showWeek(v.week.AddDate(0, 0, -domain.DaysPerWeek))
+		case "n", "]":
+			return v.showWeek(v.week.AddDate(0, 0, domain.DaysPerWeek))
+		case "home":
+			return v.showWeek(domain.WeekStart(time.Now()))
+		case "r":
+			return tea.Batch(v.spinner.Tick, v.load())
+		case "enter":
+			return v.selectCell()
+		case "e":
+			return v.openDay()
+		}
+	}
+
+	return nil
+}
+
+// showWeek switches to the week starting on week and loads its time entries.
+func (v *TimesheetView) showWeek(week time.Time) tea.Cmd {
+	if week.Equal(v.week) {
+		return nil
+	}
+
+	v.week = week
+	v.sheet = nil
+	v.row = 0
+	return tea.Batch(v.spinner.Tick, v.load())
+}
+
+// selectCell opens the time entry form for the issue and day of the selected cell.
+// selectCell shows a notice instead if the row has no issue to log time on.
+func (v *TimesheetView) selectCell() tea.Cmd {
+	row := v.selectedRow()
+	switch {
+	case row == nil:
+		return nil
+	case row.IssueID == 0:
+		v.notice = fmt.Sprintf("%s: time booked on the project itself, search an issue to log time on (alt+f)", row.ProjectName)
+		return nil
+	case row.Issue == nil:
+		v.notice = fmt.Sprintf("Issue #%d could not be loaded, press r to reload", row.IssueID)
+		return nil
+	}
+
+	issue := row.Issue
+	date := v.sheet.Day(v.col)
+	return func() tea.Msg {
+		return messages.TimeEntryCreateMsg{Issue: issue, Date: date}
+	}
+}
+
+// openDay lists the time entries of the selected day for editing.
+func (v *TimesheetView) openDay() tea.Cmd {
+	if v.sheet == nil {
+		return nil
+	}
+
+	day := v.sheet.Day(v.col).Format("2006-01-02")
+	title := v.sheet.Day(v.col).Format("Mon 02.01.2006")
+	return func() tea.Msg {
+		return messages.TimeEntriesOpenMsg{
+			Title:  title,
+			Filter: models.TimeEntryFilter{UserID: "me", From: day, To: day},
+		}
+	}
+}
+
+// selectedRow returns the row under the cursor or nil if the timesheet is empty.
+func (v *TimesheetView) selectedRow() *domain.TimesheetRow {
+	if v.sheet == nil || v.row >= len(v.sheet.Rows) {
+		return nil
+	}
+	return v.sheet.Rows[v.row]
+}
+
+// SetSize sets the dimensions of the TimesheetView.
+func (v *TimesheetView) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+}
+
+// Render renders the weekly grid with daily and weekly totals.
+func (v *TimesheetView) Render() string {
+	last := v.week.AddDate(0, 0, domain.DaysPerWeek-1)
+	title := titleStyle.Render(fmt.Sprintf("TIMESHEET %s – %s", v.week.Format("02.01."), last.Format("02.01.2006")))
+
+	var body string
+	switch {
+	case v.loading:
+		body = loadingStyle.Render(v.spinner.View() + " Loading time entries...")
+	case v.err != nil:
+		body = lipgloss.NewStyle().
+			Foreground(themes.TokyoNight.Error).
+			Bold(true).
+			Padding(1, 1).
+			Render("✗ Error: " + v.err.Error())
+	case v.sheet == nil || len(v.sheet.Rows) == 0:
+		body = emptyMessageStyle.Render("No time logged this week")
+	default:
+		body = v.renderGrid()
+	}
+
+	helpText := helpStyle.Render("←/→/↑/↓: select cell • enter: log time • e: edit day • p/n: previous/next week • home: this week • r: reload • esc: back")
+
+	sections := []string{title, body, ""}
+	if v.notice != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(themes.TokyoNight.Warning).Render("⚠ "+v.notice), "")
+	}
+	sections = append(sections, helpText)
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderGrid renders the table of rows, days and totals.
+func (v *TimesheetView) renderGrid() string {
+	labelWidth := max(v.width-8-timesheetCellWidth*(domain.DaysPerWeek+1), 12)
+
+	headerStyle := fieldLabelStyle.Padding(0)
+	cellStyle := fieldValueStyle.Padding(0).Width(timesheetCellWidth).Align(lipgloss.Right)
+	totalStyle := cellStyle.Bold(true).Foreground(themes.TokyoNight.Info)
+	selectedStyle := focusedStyle.Padding(0).Width(timesheetCellWidth).Align(lipgloss.Right)
+
+	today := time.Now()
+	header := headerStyle.Width(labelWidth).Render("Issue")
+	for day := range domain.DaysPerWeek {
+		date := v.sheet.Day(day)
+		style := headerStyle.Width(timesheetCellWidth).Align(lipgloss.Right)
+		if date.Year() == today.Year() && date.YearDay() == today.YearDay() {
+			style = style.Foreground(themes.TokyoNight.Info)
+		}
+		header += style.Render(date.Format("Mon 02"))
+	}
+	header += headerStyle.Width(timesheetCellWidth).Align(lipgloss.Right).Render("Total")
+
+	lines := []string{header}
+	for i, row := range v.sheet.Rows {
+		label := truncate(row.Label(), labelWidth-1)
+		line := fieldValueStyle.Padding(0).Width(labelWidth).Render(label)
+		for day := range domain.DaysPerWeek {
+			style := cellStyle
+			if i == v.row && day == v.col {
+				style = selectedStyle
+			}
+			line += style.Render(formatHours(row.Hours[day]))
+		}
+		line += totalStyle.Render(formatHours(row.Total()))
+		lines = append(lines, line)
+	}
+
+	footer := headerStyle.Width(labelWidth).Render("Total")
+	for day := range domain.DaysPerWeek {
+		footer += totalStyle.Render(formatHours(v.sheet.DayTotal(day)))
+	}
+	footer += totalStyle.Render(formatHours(v.sheet.Total()))
+	lines = append(lines, strings.Repeat("─", labelWidth+timesheetCellWidth*(domain.DaysPerWeek+1)), footer)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatHours formats hours for a timesheet cell, leaving empty cells blank apart from a dot.
+func formatHours(hours float64) string {
+	if hours == 0 {
+		return "·"
+	}
+	return fmt.Sprintf("%.2f", hours)
+}
+
+// truncate shortens s to at most width runes, marking the cut with an ellipsis.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width || width < 1 {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
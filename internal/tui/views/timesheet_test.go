@@ -0,0 +1,143 @@
+package views
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/b1tray3r/rmt/internal/redmine/models"
+	"github.com/b1tray3r/rmt/internal/tui/domain"
+	"github.com/b1tray3r/rmt/internal/tui/messages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// mockTimesheetSource implements TimesheetSource for testing
+type mockTimesheetSource struct {
+	entries []models.TimeEntry
+	filters []models.TimeEntryFilter
+}
+
+// ListTimeEntries records the filter and returns the mock entries
+func (m *mockTimesheetSource) ListTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]models.TimeEntry, error) {
+	m.filters = append(m.filters, filter)
+	return m.entries, nil
+}
+
+// GetIssues returns a test issue for every ID
+func (m *mockTimesheetSource) GetIssues(ctx context.Context, ids []int) ([]*domain.Issue, error) {
+	var issues []*domain.Issue
+	for _, id := range ids {
+		issues = append(issues, domain.NewIssue(id, "", "", "Test Issue", "", &domain.Project{}))
+	}
+	return issues, nil
+}
+
+// loadTimesheet runs the load command of the view directly, without spinner ticks, and feeds the result back
+func loadTimesheet(v *TimesheetView) {
+	v.Update(v.load()())
+}
+
+// TestTimesheetView_SelectCell verifies that selecting a cell opens the time entry form for that issue and day
+func TestTimesheetView_SelectCell(t *testing.T) {
+	week := domain.WeekStart(time.Now())
+
+	var entry models.TimeEntry
+	entry.Issue.ID = 123
+	entry.SpentOn = week.Format("2006-01-02")
+	entry.Hours = 2
+
+	source := &mockTimesheetSource{entries: []models.TimeEntry{entry}}
+	view := NewTimesheetView(120, 40, source)
+	loadTimesheet(view)
+
+	if len(source.filters) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(source.filters))
+	}
+	filter := source.filters[0]
+	if filter.UserID != "me" || filter.From != week.Format("2006-01-02") || filter.To != week.AddDate(0, 0, 6).Format("2006-01-02") {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+
+	if view.sheet == nil || len(view.sheet.Rows) != 1 {
+		t.Fatalf("expected a timesheet with 1 row, got %+v", view.sheet)
+	}
+
+	// Move to Wednesday
+	for range 6 {
+		view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	}
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+	view.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+	cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command after selecting a cell, got nil")
+	}
+
+	msg, ok := cmd().(messages.TimeEntryCreateMsg)
+	if !ok {
+		t.Fatalf("expected TimeEntryCreateMsg, got %T", cmd())
+	}
+	if msg.Issue.ID() != 123 {
+		t.Errorf("Issue.ID() = %d, want 123", msg.Issue.ID())
+	}
+	if want := week.AddDate(0, 0, 2); !msg.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", msg.Date, want)
+	}
+}
+
+// TestTimesheetView_SelectUnloggableCell verifies that cells without an issue explain why no time can be logged
+func TestTimesheetView_SelectUnloggableCell(t *testing.T) {
+	var entry models.TimeEntry
+	entry.Project.ID = 7
+	entry.Project.Name = "Website"
+	entry.SpentOn = domain.WeekStart(time.Now()).Format("2006-01-02")
+	entry.Hours = 1
+
+	source := &mockTimesheetSource{entries: []models.TimeEntry{entry}}
+	view := NewTimesheetView(120, 40, source)
+	loadTimesheet(view)
+
+	if cmd := view.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Errorf("expected no command for a project row, got %T", cmd())
+	}
+	if !strings.Contains(view.Render(), "Website: time booked on the project itself") {
+		t.Errorf("Render() does not explain the project row:\n%s", view.Render())
+	}
+
+	// The notice is cleared by the next key press and the sheet is reloaded when the user returns
+	view.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if view.notice != "" {
+		t.Errorf("notice = %q, want it cleared", view.notice)
+	}
+	if cmd := view.Resume(); cmd == nil || !view.loading {
+		t.Error("expected Resume to reload the time entries")
+	}
+}
+
+// TestTimesheetView_WeekNavigation verifies that switching weeks loads the entries of the new week
+func TestTimesheetView_WeekNavigation(t *testing.T) {
+	source := &mockTimesheetSource{}
+	view := NewTimesheetView(120, 40, source)
+	current := view.week
+
+	if cmd := view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")}); cmd == nil {
+		t.Fatal("expected a load command when switching weeks, got nil")
+	}
+	if want := current.AddDate(0, 0, -7); !view.week.Equal(want) {
+		t.Errorf("week = %v, want %v", view.week, want)
+	}
+
+	// A result for the week that was left must be ignored
+	stale := domain.NewTimesheet(current, nil)
+	view.Update(TimesheetLoadedMsg{Week: current, Sheet: stale})
+	if view.sheet != nil {
+		t.Error("expected result of the previous week to be ignored")
+	}
+
+	view.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if !view.week.Equal(current) {
+		t.Errorf("week = %v, want %v", view.week, current)
+	}
+}
@@ -19,6 +19,21 @@ type View interface {
 	SetSize(width, height int)
 }
 
+// Canceler is implemented by views that run requests which must be aborted when the view is left.
+type Canceler interface {
+	Cancel()
+}
+
+// Resumer is implemented by views that need to restart aborted requests when the user returns to them.
+type Resumer interface {
+	Resume() tea.Cmd
+}
+
+// Editor is implemented by views with inputs or dialogs that need the esc key to close them.
+type Editor interface {
+	IsEditing() bool
+}
+
 type RMTIssueDelegate struct {
 	maxWidth int
 }
@@ -43,6 +58,9 @@ func (d RMTIssueDelegate) Render(w io.Writer, m list.Model, index int, item list
 		title = d.highlightMatches(title, m.FilterValue())
 		description = d.highlightMatches(description, m.FilterValue())
 	}
+	if cached := cachedLabel(issue); cached != "" {
+		title += " (" + cached + ")"
+	}
 
 	prefix := "- "
 	var titleStyle, descStyle lipgloss.Style
@@ -76,6 +94,15 @@ func (d RMTIssueDelegate) Render(w io.Writer, m list.Model, index int, item list
 	fmt.Fprint(w, descStyle.Padding(0, 1).PaddingBottom(1).Render(description))
 }
 
+// cachedLabel describes when the issue was cached if it was served from the cache because Redmine was unreachable.
+// cachedLabel returns an empty string for current issues.
+func cachedLabel(issue *domain.Issue) string {
+	if issue.CachedAt().IsZero() {
+		return ""
+	}
+	return "cached " + issue.CachedAt().Local().Format("2006-01-02 15:04")
+}
+
 func (d RMTIssueDelegate) highlightMatches(text, filter string) string {
 	if filter == "" {
 		return text
@@ -145,6 +172,15 @@ func (d RMTFavoriteDelegate) Render(w io.Writer, m list.Model, index int, item l
 	}
 
 	prefix := "⭐ "
+	if favorite.IsSavedQuery() {
+		prefix = "🔖 "
+		if favorite.IsPublic() {
+			name += " (Redmine, public)"
+		} else {
+			name += " (Redmine, personal)"
+		}
+	}
+
 	var nameStyle, configStyle lipgloss.Style
 	if isSelected {
 		nameStyle = lipgloss.NewStyle().
@@ -170,6 +206,10 @@ func (d RMTFavoriteDelegate) Render(w io.Writer, m list.Model, index int, item l
 			Width(d.maxWidth)
 	}
 
+	if hotkey := favorite.Hotkey(); hotkey != "" {
+		name += " [" + hotkey + "]"
+	}
+
 	fmt.Fprint(w, nameStyle.Render(prefix+name))
 	fmt.Fprint(w, "\n")
 	fmt.Fprint(w, configStyle.Padding(0, 1).Render(config))
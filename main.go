@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 
+	"github.com/b1tray3r/rmt/internal/cache"
+	"github.com/b1tray3r/rmt/internal/cli"
 	"github.com/b1tray3r/rmt/internal/config"
+	"github.com/b1tray3r/rmt/internal/outbox"
 	"github.com/b1tray3r/rmt/internal/redmine"
+	"github.com/b1tray3r/rmt/internal/timer"
 	"github.com/b1tray3r/rmt/internal/tui"
 	"github.com/b1tray3r/rmt/internal/tui/domain"
 	tea "github.com/charmbracelet/bubbletea"
@@ -37,18 +43,67 @@ func loadConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
-func run() error {
+// newRepository creates the repository talking to the given Redmine instance.
+// The repository caches its data below XDG_CACHE_HOME, unless the cache directory cannot be located.
+func newRepository(cfg config.RedmineConfig) *domain.RedmineIssueRepository {
+	client := redmine.NewRestClient(cfg.URL, cfg.Token)
+	client.SetRetryPolicy(redmine.NewBackoffPolicy(
+		cfg.Retry.MaxAttempts,
+		cfg.Retry.BaseDelay,
+		cfg.Retry.MaxDelay,
+	))
+
+	repo := domain.NewRedmineIssueRepository(client, cfg.MaxResults)
+	if dir, err := cache.DefaultDir(); err == nil {
+		repo.SetCache(cache.NewStore(filepath.Join(dir, cache.Namespace(cfg.URL, cfg.Token))))
+	}
+
+	return repo
+}
+
+// run executes the command given by args, or starts the TUI if args is empty.
+func run(args []string) error {
+	profile, args, err := cli.SplitProfile(args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 && cli.IsHelp(args[0]) {
+		fmt.Print(cli.Usage)
+		return nil
+	}
+
 	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed loading config: %w", err)
+		return &cli.ExitError{Code: cli.ExitConfig, Err: fmt.Errorf("failed loading config: %w", err)}
+	}
+	if profile != "" {
+		if err := cfg.UseProfile(profile); err != nil {
+			return &cli.ExitError{Code: cli.ExitConfig, Err: err}
+		}
+	}
+
+	outboxPath, err := outbox.DefaultPath()
+	if err != nil {
+		return fmt.Errorf("failed locating outbox file: %w", err)
 	}
 
-	client := redmine.NewRestClient(cfg.Redmine.URL, cfg.Redmine.Token)
+	if len(args) > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
 
-	issueService := domain.NewRedmineIssueRepository(client)
+		runner := cli.NewRunner(newRepository(cfg.Redmine), cfg, os.Stdout)
+		runner.SetOutbox(outbox.NewStore(outboxPath))
+		return runner.Run(ctx, args)
+	}
+
+	timerPath, err := timer.DefaultPath()
+	if err != nil {
+		return fmt.Errorf("failed locating timer file: %w", err)
+	}
 
 	program := tea.NewProgram(
-		tui.NewApplication(issueService, cfg),
+		tui.NewApplication(cfg, newRepository, timer.NewStore(timerPath), outbox.NewStore(outboxPath)),
 		tea.WithAltScreen(),
 	)
 	if _, err := program.Run(); err != nil {
@@ -59,8 +114,11 @@ func run() error {
 }
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if cli.ExitCode(err) == cli.ExitUsage {
+			fmt.Fprint(os.Stderr, "\n"+cli.Usage)
+		}
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -368,7 +368,7 @@ redmine:
 	}
 
 	// Test that run returns an error
-	err = run()
+	err = run(nil)
 	if err == nil {
 		t.Fatal("run() should return error for invalid config")
 	}
@@ -414,7 +414,7 @@ func TestRun_MissingConfig(t *testing.T) {
 	}()
 
 	// Test that run returns an error
-	err = run()
+	err = run(nil)
 	if err == nil {
 		t.Fatal("run() should return error when config file doesn't exist")
 	}
@@ -452,7 +452,7 @@ redmine:
 	}
 
 	// Test that run returns an error
-	err = run()
+	err = run(nil)
 	if err == nil {
 		t.Fatal("run() should return error for invalid config")
 	}